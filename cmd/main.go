@@ -4,11 +4,41 @@ package main
 
 import (
 	"fmt"
+	"io"
+	"os"
 
+	"github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
 	"github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+	"github.com/mohit-bhandari45/Compiler-GO.git/internal/parser"
 )
 
+const usage = `usage: compiler [command] [file]
+
+With no command, prints the tokens of a built-in example.
+
+Commands:
+	dot [file]	print the syntax tree of file (or standard input) as a Graphviz DOT graph
+`
+
 func main() {
+	if len(os.Args) < 2 {
+		tokens()
+		return
+	}
+
+	switch os.Args[1] {
+	case "dot":
+		if err := dot(os.Stdout, os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+func tokens() {
 	input := `
 	five
 	`
@@ -19,3 +49,42 @@ func main() {
 		fmt.Printf("%+v\n", tok)
 	}
 }
+
+// dot parses the named file, or standard input, and writes its syntax tree
+// as DOT to w. Source starting with a package clause is parsed as a file, anything
+// else as a statement list, so that small snippets such as 1 + 2 * 3 can be
+// drawn too:
+//
+//	echo '1 + 2 * 3' | compiler dot | dot -Tpng -o tree.png
+func dot(w io.Writer, args []string) error {
+	var src []byte
+	var err error
+	switch len(args) {
+	case 0:
+		src, err = io.ReadAll(os.Stdin)
+	case 1:
+		src, err = os.ReadFile(args[0])
+	default:
+		return fmt.Errorf("dot: too many arguments\n%s", usage)
+	}
+	if err != nil {
+		return err
+	}
+
+	var tree ast.Node
+	p := parser.New(lexer.New(string(src)))
+	if lexer.New(string(src)).NextToken().Type == lexer.PACKAGE {
+		tree = p.ParseFile()
+	} else {
+		tree = p.ParseProgram()
+	}
+	if errs := p.Errors(); len(errs) > 0 {
+		for _, e := range errs {
+			fmt.Fprintln(os.Stderr, e)
+		}
+		return fmt.Errorf("dot: %d syntax errors", len(errs))
+	}
+
+	_, err = io.WriteString(w, ast.ToDot(tree))
+	return err
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+// TestDot checks the output of the dot command for each testdata/*.input
+// file against the .golden file next to it. Run with -update to rewrite the
+// golden files.
+func TestDot(t *testing.T) {
+	inputs, err := filepath.Glob(filepath.Join("testdata", "*.input"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inputs) == 0 {
+		t.Fatal("no input files")
+	}
+	for _, input := range inputs {
+		t.Run(filepath.Base(input), func(t *testing.T) {
+			var out strings.Builder
+			if err := dot(&out, []string{input}); err != nil {
+				t.Fatal(err)
+			}
+			golden := strings.TrimSuffix(input, ".input") + ".golden"
+			if *update {
+				if err := os.WriteFile(golden, []byte(out.String()), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := out.String(); got != string(want) {
+				t.Errorf("dot output differs from %s; run with -update if the change is intended\ngot:\n%s", golden, got)
+			}
+		})
+	}
+}
+
+func TestDotErrors(t *testing.T) {
+	var out strings.Builder
+	if err := dot(&out, []string{"a", "b"}); err == nil || !strings.Contains(err.Error(), "too many arguments") {
+		t.Errorf("dot with two files: error %v, want too many arguments", err)
+	}
+	if err := dot(&out, []string{filepath.Join("testdata", "missing.input")}); err == nil {
+		t.Error("dot of a missing file: no error")
+	}
+	if out.Len() != 0 {
+		t.Errorf("dot wrote %q on error", out.String())
+	}
+}
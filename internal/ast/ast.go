@@ -2,6 +2,8 @@ package ast
 
 import (
 	"bytes"
+	"math/big"
+	"strconv"
 	"strings"
 
 	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
@@ -10,6 +12,8 @@ import (
 type Node interface {
 	TokenLiteral() string
 	String() string
+	Pos() lexer.Pos // position of the first character belonging to the node
+	End() lexer.Pos // position of the first character immediately after the node
 }
 
 type Statement interface {
@@ -22,6 +26,28 @@ type Expression interface {
 	expressionNode()
 }
 
+// Program is the root node of a parsed statement list.
+type Program struct {
+	Statements []Statement
+}
+
+// Returns the literal value of the first statement's token
+func (p *Program) TokenLiteral() string {
+	if len(p.Statements) > 0 {
+		return p.Statements[0].TokenLiteral()
+	}
+	return ""
+}
+
+// Returns a string representation of the whole program (useful for printing the AST)
+func (p *Program) String() string {
+	var out strings.Builder
+	for _, s := range p.Statements {
+		out.WriteString(s.String())
+	}
+	return out.String()
+}
+
 // ------- Expressions -------- //
 
 // Identifier represents variable names, function names, or any user-defined identifiers
@@ -47,10 +73,11 @@ func (i *Identifier) String() string {
 
 // ---------------------------------------------------------------------------- //
 
-// IntegerLiteral represents integer numbers in the source code (e.g., 5, 42, 100).
+// IntegerLiteral represents integer numbers in the source code (e.g., 5, 0x2A, 1_000).
 type IntegerLiteral struct {
 	Token lexer.Token // The token corresponding to this integer (Type: INT)
 	Value string      // The literal value of the integer as a string
+	Int   *big.Int    // The exact value; nil if the literal is malformed
 }
 
 // Marks this node as an Expression (required by the Expression interface)
@@ -69,12 +96,19 @@ func (il *IntegerLiteral) String() string {
 // ---------------------------------------------------------------------------- //
 
 // FloatLiteral represents floating-point numbers in the source code
-// (e.g., 3.14, 0.5, 10.0).
+// (e.g., 3.14, .5, 1e9, 0x1p-2).
 type FloatLiteral struct {
 	Token lexer.Token // The token corresponding to this float (Type: FLOAT)
 	Value string      // The literal value of the float as a string
+	Rat   *big.Rat    // The exact value; nil if the literal is malformed
+	Float *big.Float  // The value rounded to FloatPrec bits; nil if the literal is malformed
 }
 
+// FloatPrec is the precision, in bits, of FloatLiteral.Float. It is far
+// beyond float64's, so that rounding the literal to a machine float gives the
+// same result as rounding its exact value.
+const FloatPrec = 512
+
 // Marks this node as an Expression (required by the Expression interface)
 func (fl *FloatLiteral) expressionNode() {}
 
@@ -90,11 +124,12 @@ func (fl *FloatLiteral) String() string {
 
 // ---------------------------------------------------------------------------- //
 
-// StringLiteral represents string values in the source code (e.g., "hello", "world").
-// Unlike identifiers, these include quotes in the source but are stored without quotes in Value.
+// StringLiteral represents string values in the source code (e.g., "hello", `raw`).
+// Unlike identifiers, these include quotes in the source but are stored decoded in Value:
+// without quotes and with escape sequences such as \n replaced by the bytes they stand for.
 type StringLiteral struct {
 	Token lexer.Token // The token corresponding to this string literal (Type: STRING)
-	Value string      // The content of the string without quotes
+	Value string      // The decoded string
 }
 
 // Marks this node as an Expression (required by the Expression interface)
@@ -113,6 +148,27 @@ func (sl *StringLiteral) String() string {
 
 // ---------------------------------------------------------------------------- //
 
+// RuneLiteral represents rune (character) values in the source code (e.g., 'a', '\n').
+type RuneLiteral struct {
+	Token lexer.Token // The token corresponding to this rune literal (Type: CHAR)
+	Value rune        // The decoded rune
+}
+
+// Marks this node as an Expression (required by the Expression interface)
+func (rl *RuneLiteral) expressionNode() {}
+
+// Returns the literal value of the token as it appeared in the source code (with quotes)
+func (rl *RuneLiteral) TokenLiteral() string {
+	return rl.Token.Literal
+}
+
+// Returns a string representation of the rune literal (useful for printing the AST)
+func (rl *RuneLiteral) String() string {
+	return strconv.QuoteRune(rl.Value)
+}
+
+// ---------------------------------------------------------------------------- //
+
 // PrefixExpression represents unary operations in the source code,
 // such as !foo or -5.
 type PrefixExpression struct {
@@ -173,11 +229,285 @@ func (ie *InfixExpression) String() string {
 
 // ---------------------------------------------------------------------------- //
 
+// ParenExpr represents a parenthesized expression, such as (a + b).
+type ParenExpr struct {
+	Token  lexer.Token // The token corresponding to the opening parenthesis `(`
+	X      Expression  // The expression inside the parentheses
+	Rparen lexer.Token // The closing parenthesis
+}
+
+// Marks this node as an Expression (required by the Expression interface)
+func (pe *ParenExpr) expressionNode() {}
+
+// Returns the literal value of the token as it appeared in the source code
+func (pe *ParenExpr) TokenLiteral() string {
+	return pe.Token.Literal
+}
+
+// Returns a string representation of the parenthesized expression
+func (pe *ParenExpr) String() string {
+	return "(" + pe.X.String() + ")"
+}
+
+// ---------------------------------------------------------------------------- //
+
+// TypeAssertExpr represents a type assertion, such as x.(T).
+// Type is nil for the x.(type) guard of a type switch.
+type TypeAssertExpr struct {
+	Token  lexer.Token // The token corresponding to the `.`
+	X      Expression  // The expression being asserted
+	Type   Expression  // The asserted type, or nil for x.(type)
+	Rparen lexer.Token // The closing parenthesis
+}
+
+// Marks this node as an Expression (required by the Expression interface)
+func (ta *TypeAssertExpr) expressionNode() {}
+
+// Returns the literal value of the token as it appeared in the source code
+func (ta *TypeAssertExpr) TokenLiteral() string {
+	return ta.Token.Literal
+}
+
+// Returns a string representation of the type assertion
+// Example: x.(int) or x.(type)
+func (ta *TypeAssertExpr) String() string {
+	if ta.Type == nil {
+		return ta.X.String() + ".(type)"
+	}
+	return ta.X.String() + ".(" + ta.Type.String() + ")"
+}
+
+// ---------------------------------------------------------------------------- //
+
+// FuncLit represents a function literal (closure), such as
+// func(x int) int { return x * 2 }.
+type FuncLit struct {
+	Token    lexer.Token     // The token corresponding to `func`
+	Type     *FuncType       // The signature
+	Body     *BlockStatement // The function body
+	FreeVars []*Identifier   // The declaring identifiers of the variables of enclosing functions used in the body, in order of first use; not walked
+}
+
+// Marks this node as an Expression (required by the Expression interface)
+func (fl *FuncLit) expressionNode() {}
+
+// Returns the literal value of the token as it appeared in the source code
+func (fl *FuncLit) TokenLiteral() string {
+	return fl.Token.Literal
+}
+
+// Returns a string representation of the function literal
+func (fl *FuncLit) String() string {
+	return fl.Type.String() + " { " + fl.Body.String() + " }"
+}
+
+// ---------------------------------------------------------------------------- //
+
+// CallExpr represents a function call, such as f(a, b) or f(a, rest...).
+type CallExpr struct {
+	Token    lexer.Token  // The token corresponding to the opening parenthesis `(`
+	Fun      Expression   // The function being called
+	Args     []Expression // The arguments
+	Ellipsis lexer.Token  // The `...` after a spread final argument; zero value otherwise
+	Rparen   lexer.Token  // The closing parenthesis
+}
+
+// Marks this node as an Expression (required by the Expression interface)
+func (ce *CallExpr) expressionNode() {}
+
+// Returns the literal value of the token as it appeared in the source code
+func (ce *CallExpr) TokenLiteral() string {
+	return ce.Token.Literal
+}
+
+// Returns a string representation of the call
+// Example: add(1, 2)
+func (ce *CallExpr) String() string {
+	out := ce.Fun.String() + "(" + joinExprs(ce.Args)
+	if ce.Ellipsis.Type != "" {
+		out += "..."
+	}
+	return out + ")"
+}
+
+// ---------------------------------------------------------------------------- //
+
+// IndexExpr represents an index expression such as a[i], or a generic
+// function or type instantiated with a single type argument, such as List[T].
+type IndexExpr struct {
+	Token  lexer.Token // The token corresponding to `[`
+	X      Expression  // The indexed expression or the generic function or type
+	Index  Expression  // The index or the type argument
+	Rbrack lexer.Token // The closing bracket
+}
+
+// Marks this node as an Expression (required by the Expression interface)
+func (ie *IndexExpr) expressionNode() {}
+
+// Returns the literal value of the token as it appeared in the source code
+func (ie *IndexExpr) TokenLiteral() string {
+	return ie.Token.Literal
+}
+
+// Returns a string representation of the index expression
+// Example: a[i] or List[int]
+func (ie *IndexExpr) String() string {
+	index := ""
+	if ie.Index != nil {
+		index = ie.Index.String()
+	}
+	return ie.X.String() + "[" + index + "]"
+}
+
+// ---------------------------------------------------------------------------- //
+
+// IndexListExpr represents a generic function or type instantiated with
+// several type arguments, such as Map[K, V].
+type IndexListExpr struct {
+	Token   lexer.Token  // The token corresponding to `[`
+	X       Expression   // The generic function or type
+	Indices []Expression // The type arguments
+	Rbrack  lexer.Token  // The closing bracket
+}
+
+// Marks this node as an Expression (required by the Expression interface)
+func (il *IndexListExpr) expressionNode() {}
+
+// Returns the literal value of the token as it appeared in the source code
+func (il *IndexListExpr) TokenLiteral() string {
+	return il.Token.Literal
+}
+
+// Returns a string representation of the instantiation
+func (il *IndexListExpr) String() string {
+	return il.X.String() + "[" + joinExprs(il.Indices) + "]"
+}
+
+// ---------------------------------------------------------------------------- //
+
+// SelectorExpr represents a field or method selector, or a qualified
+// identifier, such as p.X, s.Push or fmt.Println.
+type SelectorExpr struct {
+	Token lexer.Token // The token corresponding to `.`
+	X     Expression  // The expression or package name before the dot
+	Sel   *Identifier // The selected field, method or package member
+}
+
+// Marks this node as an Expression (required by the Expression interface)
+func (se *SelectorExpr) expressionNode() {}
+
+// Returns the literal value of the token as it appeared in the source code
+func (se *SelectorExpr) TokenLiteral() string {
+	return se.Token.Literal
+}
+
+// Returns a string representation of the selector
+func (se *SelectorExpr) String() string {
+	return se.X.String() + "." + se.Sel.String()
+}
+
+// ---------------------------------------------------------------------------- //
+
+// SliceExpr represents a slice expression, such as s[lo:hi] or s[lo:hi:max].
+type SliceExpr struct {
+	Token  lexer.Token // The token corresponding to `[`
+	X      Expression  // The sliced expression
+	Low    Expression  // The low bound; or nil
+	High   Expression  // The high bound; or nil
+	Max    Expression  // The capacity bound; or nil
+	Slice3 bool        // True for the 3-index form s[lo:hi:max]
+	Rbrack lexer.Token // The closing bracket
+}
+
+// Marks this node as an Expression (required by the Expression interface)
+func (se *SliceExpr) expressionNode() {}
+
+// Returns the literal value of the token as it appeared in the source code
+func (se *SliceExpr) TokenLiteral() string {
+	return se.Token.Literal
+}
+
+// Returns a string representation of the slice expression
+func (se *SliceExpr) String() string {
+	bound := func(x Expression) string {
+		if x == nil {
+			return ""
+		}
+		return x.String()
+	}
+	out := se.X.String() + "[" + bound(se.Low) + ":" + bound(se.High)
+	if se.Slice3 {
+		out += ":" + bound(se.Max)
+	}
+	return out + "]"
+}
+
+// ---------------------------------------------------------------------------- //
+
+// CompositeLit represents a composite literal, such as Point{X: 1, Y: 2},
+// []int{1, 2, 3} or map[string]int{"a": 1}. Type is nil for the elided
+// inner literals of {{1}, {2}}.
+type CompositeLit struct {
+	Token  lexer.Token  // The token corresponding to the opening brace `{`
+	Type   Expression   // The literal type; or nil
+	Elts   []Expression // The elements, possibly *KeyValueExpr
+	Rbrace lexer.Token  // The closing brace
+}
+
+// Marks this node as an Expression (required by the Expression interface)
+func (cl *CompositeLit) expressionNode() {}
+
+// Returns the literal value of the token as it appeared in the source code
+func (cl *CompositeLit) TokenLiteral() string {
+	return cl.Token.Literal
+}
+
+// Returns a string representation of the composite literal
+func (cl *CompositeLit) String() string {
+	typ := ""
+	if cl.Type != nil {
+		typ = cl.Type.String()
+	}
+	return typ + "{" + joinExprs(cl.Elts) + "}"
+}
+
+// ---------------------------------------------------------------------------- //
+
+// KeyValueExpr represents a key: value element of a composite literal.
+type KeyValueExpr struct {
+	Token lexer.Token // The token corresponding to `:`
+	Key   Expression  // The field name, index or map key
+	Value Expression  // The element value
+}
+
+// Marks this node as an Expression (required by the Expression interface)
+func (kv *KeyValueExpr) expressionNode() {}
+
+// Returns the literal value of the token as it appeared in the source code
+func (kv *KeyValueExpr) TokenLiteral() string {
+	return kv.Token.Literal
+}
+
+// Returns a string representation of the key-value pair
+func (kv *KeyValueExpr) String() string {
+	key, value := "", ""
+	if kv.Key != nil {
+		key = kv.Key.String()
+	}
+	if kv.Value != nil {
+		value = kv.Value.String()
+	}
+	return key + ": " + value
+}
+
+// ---------------------------------------------------------------------------- //
+
 // BlockStatement represents a block of statements enclosed by braces `{ ... }`
 // Commonly used in function bodies, if/else blocks, loops, etc.
 type BlockStatement struct {
 	Token      lexer.Token // The token corresponding to the opening brace `{`
 	Statements []Statement // A slice of statements contained in this block
+	Rbrace     lexer.Token // The token corresponding to the closing brace `}`
 }
 // Marks this node as a Statement (required by the Statement interface)
 func (bs *BlockStatement) statementNode() {}
@@ -199,10 +529,6 @@ func (bs *BlockStatement) String() string {
 
 // helpers
 func strconvQuote(s string) string {
-	// minimal quoting to make String() readable
-	var b bytes.Buffer
-	b.WriteByte('"')
-	b.WriteString(s)
-	b.WriteByte('"')
-	return b.String()
+	// the value is decoded, so quote it the way Go would
+	return strconv.Quote(s)
 }
@@ -0,0 +1,79 @@
+package ast
+
+import (
+	"math/big"
+	"reflect"
+)
+
+// Clone returns a deep copy of the subtree rooted at node: every node, slice
+// and comment group is copied, so the copy can be modified without affecting
+// the original. Nodes that appear more than once in the original, such as the
+// import specs referenced by both File.Imports and File.Decls, are copied
+// once and stay shared in the copy. Clone(nil) returns nil.
+func Clone(node Node) Node {
+	if node == nil {
+		return nil
+	}
+	c := cloner{seen: make(map[any]reflect.Value)}
+	return c.clone(reflect.ValueOf(node)).Interface().(Node)
+}
+
+type cloner struct {
+	seen map[any]reflect.Value // original pointer -> copy
+}
+
+func (c *cloner) clone(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		if dup, ok := c.seen[v.Interface()]; ok {
+			return dup
+		}
+		// literal values share their digits with nothing else once copied
+		switch x := v.Interface().(type) {
+		case *big.Int:
+			return reflect.ValueOf(new(big.Int).Set(x))
+		case *big.Rat:
+			return reflect.ValueOf(new(big.Rat).Set(x))
+		case *big.Float:
+			return reflect.ValueOf(new(big.Float).Copy(x))
+		}
+		dup := reflect.New(v.Type().Elem())
+		c.seen[v.Interface()] = dup
+		dup.Elem().Set(c.clone(v.Elem()))
+		return dup
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		dup := reflect.New(v.Type()).Elem()
+		dup.Set(c.clone(v.Elem()))
+		return dup
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		dup := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			dup.Index(i).Set(c.clone(v.Index(i)))
+		}
+		return dup
+
+	case reflect.Struct:
+		dup := reflect.New(v.Type()).Elem()
+		dup.Set(v) // copies the plain fields, such as tokens
+		for i := 0; i < v.NumField(); i++ {
+			if dup.Field(i).CanSet() {
+				dup.Field(i).Set(c.clone(v.Field(i)))
+			}
+		}
+		return dup
+
+	default:
+		return v
+	}
+}
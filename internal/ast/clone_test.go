@@ -0,0 +1,166 @@
+package ast_test
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+	parser "github.com/mohit-bhandari45/Compiler-GO.git/internal/parser"
+)
+
+// pointers adds to seen the pointers and the backing arrays of the
+// non-empty slices reachable from v, by type and address.
+func pointers(v reflect.Value, seen map[[2]any]bool) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return
+		}
+		key := [2]any{v.Type(), v.Pointer()}
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		pointers(v.Elem(), seen)
+	case reflect.Interface:
+		if !v.IsNil() {
+			pointers(v.Elem(), seen)
+		}
+	case reflect.Slice:
+		if v.Len() > 0 {
+			seen[[2]any{v.Type(), v.Pointer()}] = true
+		}
+		for i := 0; i < v.Len(); i++ {
+			pointers(v.Index(i), seen)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				pointers(v.Field(i), seen)
+			}
+		}
+	}
+}
+
+// TestClone checks that a clone is Equal to the original and shares no
+// memory with it, while the nodes shared within the original stay shared in
+// the clone.
+func TestClone(t *testing.T) {
+	file := parseFile(t, filepath.Join("testdata", "nodes.input"))
+	clone := ast.Clone(file).(*ast.File)
+	if !ast.Equal(clone, file, 0) {
+		t.Fatal("clone is not Equal to the original")
+	}
+
+	orig := make(map[[2]any]bool)
+	pointers(reflect.ValueOf(file), orig)
+	copied := make(map[[2]any]bool)
+	pointers(reflect.ValueOf(clone), copied)
+	for key := range copied {
+		if orig[key] {
+			t.Errorf("clone shares a %v with the original", key[0])
+		}
+	}
+	if len(copied) != len(orig) {
+		t.Errorf("clone has %d pointers and slices, want %d as in the original", len(copied), len(orig))
+	}
+
+	// import specs are referenced by both Imports and Decls
+	if len(clone.Imports) == 0 {
+		t.Fatal("no imports")
+	}
+	for _, spec := range clone.Imports {
+		found := false
+		for _, decl := range clone.Decls {
+			if d, ok := decl.(*ast.GenDecl); ok {
+				for _, s := range d.Specs {
+					found = found || s == ast.Spec(spec)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("cloned import %s is not shared with the declarations", spec.Path.Value)
+		}
+	}
+
+	// comment groups are referenced by both Comments and the nodes they document
+	comments := make(map[*ast.CommentGroup]bool)
+	for _, g := range clone.Comments {
+		comments[g] = true
+	}
+	docs := 0
+	ast.Inspect(clone, func(n ast.Node) bool {
+		if g, ok := n.(*ast.CommentGroup); ok {
+			docs++
+			if !comments[g] {
+				t.Errorf("cloned comment %q is not shared with File.Comments", g.Text())
+			}
+		}
+		return true
+	})
+	if docs == 0 {
+		t.Error("no doc or line comments")
+	}
+
+	// editing the clone leaves the original alone
+	clone.Name.Value = "changed"
+	if file.Name.Value == "changed" {
+		t.Error("editing the clone changed the original")
+	}
+
+	if ast.Clone(nil) != nil {
+		t.Error("Clone(nil) is not nil")
+	}
+}
+
+// parseSource parses src with its comments.
+func parseSource(t *testing.T, src string) *ast.File {
+	t.Helper()
+	p := parser.New(lexer.NewWithMode(src, lexer.ScanComments))
+	file := p.ParseFile()
+	if errs := p.Errors(); len(errs) > 0 {
+		t.Fatalf("parse errors: %v", errs)
+	}
+	return file
+}
+
+// TestEqual checks which differences each mode of Equal ignores.
+func TestEqual(t *testing.T) {
+	const src = "package p\n\n// f doc\nfunc f() int { return 1 } // line\n"
+	tests := []struct {
+		name, other string
+		want        [4]bool // for modes 0, IgnorePositions, IgnoreComments and both
+	}{
+		{"same", src, [4]bool{true, true, true, true}},
+		{"moved", "\n\n" + src, [4]bool{false, true, false, true}},
+		{"other comment text", "package p\n\n// g doc\nfunc f() int { return 1 } // line\n", [4]bool{false, false, true, true}},
+		{"no comments", "package p\n\nfunc f() int { return 1 }\n", [4]bool{false, false, false, true}},
+		{"other literal", "package p\n\n// f doc\nfunc f() int { return 2 } // line\n", [4]bool{false, false, false, false}},
+		{"other name", "package p\n\n// f doc\nfunc g() int { return 1 } // line\n", [4]bool{false, false, false, false}},
+	}
+	modes := [4]ast.EqualMode{0, ast.IgnorePositions, ast.IgnoreComments, ast.IgnorePositions | ast.IgnoreComments}
+	a := parseSource(t, src)
+	for _, tt := range tests {
+		b := parseSource(t, tt.other)
+		for i, mode := range modes {
+			if got := ast.Equal(a, b, mode); got != tt.want[i] {
+				t.Errorf("%s: Equal in mode %d = %v, want %v", tt.name, mode, got, tt.want[i])
+			}
+			if got := ast.Equal(b, a, mode); got != tt.want[i] {
+				t.Errorf("%s: Equal in mode %d, reversed = %v, want %v", tt.name, mode, got, tt.want[i])
+			}
+		}
+	}
+
+	if !ast.Equal(nil, nil, 0) {
+		t.Error("Equal(nil, nil) = false")
+	}
+	if ast.Equal(a, nil, 0) || ast.Equal(nil, a, 0) {
+		t.Error("Equal of a tree and nil = true")
+	}
+	if ast.Equal(a.Name, a.Decls[0], 0) {
+		t.Error("Equal of different node types = true")
+	}
+}
@@ -0,0 +1,88 @@
+package ast
+
+import (
+	"strings"
+
+	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+)
+
+// ------- Comments -------- //
+
+// Comment represents a single // or /* */ comment.
+type Comment struct {
+	Token lexer.Token // The COMMENT token; its literal includes the comment markers
+}
+
+// Returns the literal value of the token as it appeared in the source code
+func (c *Comment) TokenLiteral() string {
+	return c.Token.Literal
+}
+
+// Returns the comment as it appeared in the source code
+func (c *Comment) String() string {
+	return c.Token.Literal
+}
+
+// EndLine returns the line on which the comment ends.
+func (c *Comment) EndLine() int {
+	return c.Token.Line + strings.Count(c.Token.Literal, "\n")
+}
+
+// ---------------------------------------------------------------------------- //
+
+// CommentGroup represents a sequence of comments with no tokens and no
+// empty lines between them.
+type CommentGroup struct {
+	List []*Comment // The comments of the group; never empty
+}
+
+// Returns the literal value of the first comment as it appeared in the source code
+func (g *CommentGroup) TokenLiteral() string {
+	return g.List[0].TokenLiteral()
+}
+
+// Returns the comments of the group, one per line
+func (g *CommentGroup) String() string {
+	lines := make([]string, len(g.List))
+	for i, c := range g.List {
+		lines[i] = c.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Text returns the text of the comment group without the comment markers,
+// the space that conventionally follows //, trailing spaces and leading or
+// trailing empty lines. Consecutive empty lines collapse into one, and a
+// non-empty result ends in a newline. Text is nil-safe.
+func (g *CommentGroup) Text() string {
+	if g == nil {
+		return ""
+	}
+	var lines []string
+	for _, c := range g.List {
+		text := c.Token.Literal
+		if strings.HasPrefix(text, "//") {
+			text = strings.TrimPrefix(text[2:], " ")
+		} else {
+			text = strings.TrimSuffix(text[2:], "*/")
+		}
+		for _, line := range strings.Split(text, "\n") {
+			lines = append(lines, strings.TrimRight(line, " \t\r"))
+		}
+	}
+
+	var out strings.Builder
+	blank := false
+	for _, line := range lines {
+		if line == "" {
+			blank = out.Len() > 0
+			continue
+		}
+		if blank {
+			out.WriteString("\n")
+			blank = false
+		}
+		out.WriteString(line + "\n")
+	}
+	return out.String()
+}
@@ -0,0 +1,259 @@
+package ast
+
+import (
+	"strings"
+
+	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+)
+
+// Declaration is a top-level declaration: a FuncDecl or a GenDecl.
+type Declaration interface {
+	Node
+	declarationNode()
+}
+
+// Spec is a single specification inside a GenDecl: an ImportSpec, a ValueSpec
+// or a TypeSpec.
+type Spec interface {
+	Node
+	specNode()
+}
+
+// ------- File -------- //
+
+// File represents a single Go source file: the package clause followed by
+// imports and top-level declarations.
+type File struct {
+	Doc      *CommentGroup   // The package documentation; or nil
+	Token    lexer.Token     // The token corresponding to `package`
+	Name     *Identifier     // The package name
+	Imports  []*ImportSpec   // All imports of the file, in source order
+	Decls    []Declaration   // The top-level declarations, including import declarations
+	Comments []*CommentGroup // All comments of the file, in source order (ScanComments mode only)
+}
+
+// Returns the literal value of the token as it appeared in the source code
+func (f *File) TokenLiteral() string {
+	return f.Token.Literal
+}
+
+// Returns a string representation of the file (useful for printing the AST)
+func (f *File) String() string {
+	var out strings.Builder
+	if f.Name != nil {
+		out.WriteString("package " + f.Name.String())
+	}
+	for _, d := range f.Decls {
+		out.WriteString("\n" + d.String())
+	}
+	return out.String()
+}
+
+// ------- Declarations -------- //
+
+// GenDecl represents an import, const, type or var declaration, either a
+// single spec (var x int) or a parenthesized group (var ( ... )).
+type GenDecl struct {
+	Doc    *CommentGroup // The associated documentation; or nil
+	Token  lexer.Token   // The keyword token (IMPORT, CONST, TYPE or VAR)
+	Lparen lexer.Token   // The opening parenthesis of a group; zero value if ungrouped
+	Specs  []Spec        // The specs of the declaration
+	Rparen lexer.Token   // The closing parenthesis of a group; zero value if ungrouped
+}
+
+// Marks this node as a Declaration (required by the Declaration interface)
+func (gd *GenDecl) declarationNode() {}
+
+// Returns the literal value of the token as it appeared in the source code
+func (gd *GenDecl) TokenLiteral() string {
+	return gd.Token.Literal
+}
+
+// Returns a string representation of the declaration
+// Example: var x int or import ("fmt"; "os")
+func (gd *GenDecl) String() string {
+	if gd.Lparen.Type == "" && len(gd.Specs) == 1 {
+		return gd.Token.Literal + " " + gd.Specs[0].String()
+	}
+	parts := make([]string, len(gd.Specs))
+	for i, s := range gd.Specs {
+		parts[i] = s.String()
+	}
+	return gd.Token.Literal + " (" + strings.Join(parts, "; ") + ")"
+}
+
+// ---------------------------------------------------------------------------- //
+
+// ImportSpec represents a single import, such as "fmt" or f "fmt".
+type ImportSpec struct {
+	Doc     *CommentGroup  // The associated documentation; or nil
+	Name    *Identifier    // The local package name (including `.` and `_`); or nil
+	Path    *StringLiteral // The import path
+	Comment *CommentGroup  // The line comment; or nil
+}
+
+// Marks this node as a Spec (required by the Spec interface)
+func (is *ImportSpec) specNode() {}
+
+// Returns the literal value of the path token as it appeared in the source code
+func (is *ImportSpec) TokenLiteral() string {
+	return is.Path.TokenLiteral()
+}
+
+// Returns a string representation of the import spec
+func (is *ImportSpec) String() string {
+	if is.Name != nil {
+		return is.Name.String() + " " + is.Path.String()
+	}
+	return is.Path.String()
+}
+
+// ---------------------------------------------------------------------------- //
+
+// ValueSpec represents a single const or var spec, such as x, y int = 1, 2.
+type ValueSpec struct {
+	Doc     *CommentGroup // The associated documentation; or nil
+	Names   []*Identifier // The declared names
+	Type    Expression    // The declared type; or nil
+	Values  []Expression  // The initial values; or nil
+	Comment *CommentGroup // The line comment; or nil
+}
+
+// Marks this node as a Spec (required by the Spec interface)
+func (vs *ValueSpec) specNode() {}
+
+// Returns the literal value of the first name token as it appeared in the source code
+func (vs *ValueSpec) TokenLiteral() string {
+	return vs.Names[0].TokenLiteral()
+}
+
+// Returns a string representation of the value spec
+func (vs *ValueSpec) String() string {
+	names := make([]string, len(vs.Names))
+	for i, n := range vs.Names {
+		names[i] = n.String()
+	}
+	out := strings.Join(names, ", ")
+	if vs.Type != nil {
+		out += " " + vs.Type.String()
+	}
+	if vs.Values != nil {
+		out += " = " + joinExprs(vs.Values)
+	}
+	return out
+}
+
+// ---------------------------------------------------------------------------- //
+
+// TypeSpec represents a single type declaration, such as
+// Stack struct { ... }, Pair[K comparable, V any] struct { ... } or the
+// alias A = B.
+type TypeSpec struct {
+	Doc        *CommentGroup // The associated documentation; or nil
+	Name       *Identifier   // The declared type name
+	TypeParams *FieldList    // The type parameters of a generic type; or nil
+	Assign     lexer.Token   // The `=` of an alias declaration; zero value otherwise
+	Type       Expression    // The underlying (or aliased) type
+	Comment    *CommentGroup // The line comment; or nil
+}
+
+// Marks this node as a Spec (required by the Spec interface)
+func (ts *TypeSpec) specNode() {}
+
+// Returns the literal value of the name token as it appeared in the source code
+func (ts *TypeSpec) TokenLiteral() string {
+	return ts.Name.TokenLiteral()
+}
+
+// Returns a string representation of the type spec
+func (ts *TypeSpec) String() string {
+	out := ts.Name.String()
+	if ts.TypeParams != nil {
+		out += ts.TypeParams.bracketed()
+	}
+	if ts.Assign.Type != "" {
+		out += " ="
+	}
+	if ts.Type != nil {
+		out += " " + ts.Type.String()
+	}
+	return out
+}
+
+// ---------------------------------------------------------------------------- //
+
+// FuncDecl represents a function or method declaration, such as
+// func divmod(a, b int) (q, r int) { ... } or func (s *Stack) Push(v int) { ... }.
+type FuncDecl struct {
+	Doc        *CommentGroup   // The associated documentation; or nil
+	Token      lexer.Token     // The token corresponding to `func`
+	Recv       *FieldList      // The receiver of a method; nil for a function
+	Name       *Identifier     // The function name
+	TypeParams *FieldList      // The type parameters of a generic function; or nil
+	Type       *FuncType       // The signature: parameters and results
+	Body       *BlockStatement // The function body; nil for an external (body-less) function
+}
+
+// Marks this node as a Declaration (required by the Declaration interface)
+func (fd *FuncDecl) declarationNode() {}
+
+// Returns the literal value of the token as it appeared in the source code
+func (fd *FuncDecl) TokenLiteral() string {
+	return fd.Token.Literal
+}
+
+// Returns a string representation of the function declaration
+func (fd *FuncDecl) String() string {
+	out := "func "
+	if fd.Recv != nil {
+		out += fd.Recv.String() + " "
+	}
+	out += fd.Name.String()
+	if fd.TypeParams != nil {
+		out += fd.TypeParams.bracketed()
+	}
+	out += fd.Type.signature()
+	if fd.Body != nil {
+		out += " { " + fd.Body.String() + " }"
+	}
+	return out
+}
+
+// RecvBase returns the base type name of a method's receiver, whether the
+// receiver is a pointer, and the receiver type parameters: for
+// func (l *List[T]) Len() it returns List, true and [T].
+// The name is nil for functions and for malformed receivers.
+func (fd *FuncDecl) RecvBase() (name *Identifier, pointer bool, typeParams []*Identifier) {
+	if fd.Recv == nil || len(fd.Recv.List) != 1 {
+		return nil, false, nil
+	}
+	typ := fd.Recv.List[0].Type
+	if p, ok := typ.(*ParenExpr); ok {
+		typ = p.X
+	}
+	if star, ok := typ.(*StarExpr); ok {
+		pointer = true
+		typ = star.X
+	}
+
+	var params []Expression
+	switch t := typ.(type) {
+	case *IndexExpr:
+		typ, params = t.X, []Expression{t.Index}
+	case *IndexListExpr:
+		typ, params = t.X, t.Indices
+	}
+	for _, param := range params {
+		ident, ok := param.(*Identifier)
+		if !ok {
+			return nil, false, nil
+		}
+		typeParams = append(typeParams, ident)
+	}
+
+	name, _ = typ.(*Identifier)
+	if name == nil {
+		return nil, false, nil
+	}
+	return name, pointer, typeParams
+}
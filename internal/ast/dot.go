@@ -0,0 +1,88 @@
+package ast
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ToDot renders the subtree rooted at node as a Graphviz DOT digraph. Each
+// node is labeled with its type and, where it has one, its operator, name or
+// literal; each edge is labeled with the field that holds the child, so that
+//
+//	dot -Tsvg -o tree.svg tree.dot
+//
+// draws the tree top-down.
+func ToDot(node Node) string {
+	var out strings.Builder
+	out.WriteString("digraph AST {\n")
+	out.WriteString("\tnode [shape=box, fontname=\"monospace\"];\n")
+
+	ids := map[Node]int{}
+	Apply(node, func(c *Cursor) bool {
+		n := c.Node()
+		if n == nil {
+			return false
+		}
+		id := len(ids)
+		ids[n] = id
+		fmt.Fprintf(&out, "\tn%d [label=\"%s\"];\n", id, dotEscape(dotLabel(n)))
+		if parent, ok := ids[c.Parent()]; ok {
+			field := c.Name()
+			if i := c.Index(); i >= 0 {
+				field = fmt.Sprintf("%s[%d]", field, i)
+			}
+			fmt.Fprintf(&out, "\tn%d -> n%d [label=\"%s\"];\n", parent, id, field)
+		}
+		return true
+	}, nil)
+
+	out.WriteString("}\n")
+	return out.String()
+}
+
+// dotLabel returns the label of n: its type name, followed on a second line
+// by the detail that its children do not show.
+func dotLabel(n Node) string {
+	kind := strings.TrimPrefix(fmt.Sprintf("%T", n), "*ast.")
+	var detail string
+	switch n := n.(type) {
+	case *Identifier:
+		detail = n.Value
+	case *IntegerLiteral, *FloatLiteral, *StringLiteral, *RuneLiteral, *Comment:
+		detail = n.TokenLiteral()
+	case *PrefixExpression:
+		detail = n.Operator
+	case *InfixExpression:
+		detail = n.Operator
+	case *AssignStmt, *IncDecStmt, *BranchStmt, *GenDecl, *SendStmt:
+		detail = n.TokenLiteral()
+	case *RangeStmt:
+		detail = n.Tok.Literal
+	case *ChanType:
+		switch n.Dir {
+		case SEND:
+			detail = "chan<-"
+		case RECV:
+			detail = "<-chan"
+		default:
+			detail = "chan"
+		}
+	case *SliceExpr:
+		if n.Slice3 {
+			detail = "[::]"
+		}
+	case *CallExpr:
+		if n.Ellipsis.Type != "" {
+			detail = "..."
+		}
+	}
+	if detail == "" {
+		return kind
+	}
+	return kind + "\n" + detail
+}
+
+// dotEscape escapes s for use inside a double-quoted DOT string.
+func dotEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
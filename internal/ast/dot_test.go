@@ -0,0 +1,29 @@
+package ast_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+)
+
+// TestDotGolden checks the rendering of testdata/dot.input against
+// testdata/dot.golden. Run with -update to rewrite the golden file.
+func TestDotGolden(t *testing.T) {
+	file := parseFile(t, filepath.Join("testdata", "dot.input"))
+	got := ast.ToDot(file)
+	golden := filepath.Join("testdata", "dot.golden")
+	if *update {
+		if err := os.WriteFile(golden, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != string(want) {
+		t.Errorf("ToDot differs from %s; run with -update if the change is intended\ngot:\n%s", golden, got)
+	}
+}
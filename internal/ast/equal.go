@@ -0,0 +1,87 @@
+package ast
+
+import (
+	"math/big"
+	"reflect"
+
+	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+)
+
+// EqualMode controls which parts of the trees take part in Equal.
+type EqualMode uint
+
+const (
+	// IgnorePositions compares tokens by type and literal only, ignoring
+	// their lines, offsets and positions.
+	IgnorePositions EqualMode = 1 << iota
+	// IgnoreComments ignores doc comments, line comments and File.Comments.
+	IgnoreComments
+)
+
+var (
+	tokenType        = reflect.TypeOf(lexer.Token{})
+	commentGroupType = reflect.TypeOf((*CommentGroup)(nil))
+	commentListType  = reflect.TypeOf([]*CommentGroup(nil))
+)
+
+// Equal reports whether the subtrees rooted at a and b are structurally
+// equal: they have the same node types with equal fields, recursively. The
+// mode selects the parts of the trees that are ignored.
+func Equal(a, b Node, mode EqualMode) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return equal(reflect.ValueOf(a), reflect.ValueOf(b), mode)
+}
+
+func equal(a, b reflect.Value, mode EqualMode) bool {
+	if a.Type() != b.Type() {
+		return false
+	}
+	if mode&IgnoreComments != 0 && (a.Type() == commentGroupType || a.Type() == commentListType) {
+		return true
+	}
+
+	switch a.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		// literal values are equal if they denote the same number
+		switch x := a.Interface().(type) {
+		case *big.Int:
+			return x.Cmp(b.Interface().(*big.Int)) == 0
+		case *big.Rat:
+			return x.Cmp(b.Interface().(*big.Rat)) == 0
+		case *big.Float:
+			return x.Cmp(b.Interface().(*big.Float)) == 0
+		}
+		return equal(a.Elem(), b.Elem(), mode)
+
+	case reflect.Slice:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !equal(a.Index(i), b.Index(i), mode) {
+				return false
+			}
+		}
+		return true
+
+	case reflect.Struct:
+		if a.Type() == tokenType && mode&IgnorePositions != 0 {
+			at, bt := a.Interface().(lexer.Token), b.Interface().(lexer.Token)
+			return at.Type == bt.Type && at.Literal == bt.Literal
+		}
+		for i := 0; i < a.NumField(); i++ {
+			if !equal(a.Field(i), b.Field(i), mode) {
+				return false
+			}
+		}
+		return true
+
+	default:
+		return a.Equal(b)
+	}
+}
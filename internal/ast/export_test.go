@@ -0,0 +1,4 @@
+package ast
+
+// NodeTypes exports nodeTypes for the external tests.
+var NodeTypes = nodeTypes
@@ -0,0 +1,284 @@
+package ast
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+)
+
+// JSON encoding of syntax trees. Every node becomes an object whose "Kind"
+// is the node's type name, followed by its "Pos" and "End" and then its
+// non-zero fields in declaration order:
+//
+//	{"Kind": "InfixExpression", "Pos": 12, "End": 17,
+//	 "Token": {"Type": "+", "Literal": "+", "Line": 2, "Offset": 13, "Pos": 14},
+//	 "Left": {"Kind": "Identifier", ...}, "Operator": "+", "Right": {...}}
+//
+// Pos and End are informational; they are computed from the tokens and
+// ignored by FromJSON. Tokens are kept in full, so a decoded tree is Equal to
+// the original in every mode. The values of number literals are written in
+// their JSON or text form: a big.Int as a number, a big.Rat as "a/b" and a
+// big.Float in decimal.
+
+// nodeTypes lists every node type by its Kind.
+var nodeTypes = map[string]reflect.Type{}
+
+func init() {
+	for _, n := range []Node{
+		// Comments
+		&Comment{}, &CommentGroup{},
+		// Expressions
+		&Identifier{}, &IntegerLiteral{}, &FloatLiteral{}, &StringLiteral{}, &RuneLiteral{},
+		&PrefixExpression{}, &InfixExpression{}, &ParenExpr{}, &TypeAssertExpr{},
+		&FuncLit{}, &CallExpr{}, &IndexExpr{}, &IndexListExpr{}, &SelectorExpr{},
+		&SliceExpr{}, &CompositeLit{}, &KeyValueExpr{},
+		// Types
+		&Field{}, &FieldList{}, &FuncType{}, &Ellipsis{}, &StarExpr{},
+		&ArrayType{}, &MapType{}, &ChanType{}, &StructType{}, &InterfaceType{},
+		// Statements
+		&Program{}, &BlockStatement{}, &ExprStmt{}, &AssignStmt{}, &BranchStmt{},
+		&LabeledStmt{}, &CaseClause{}, &SwitchStmt{}, &TypeSwitchStmt{},
+		&ReturnStmt{}, &DeclStmt{}, &IncDecStmt{}, &IfStmt{}, &ForStmt{},
+		&RangeStmt{}, &GoStmt{}, &DeferStmt{}, &SendStmt{}, &CommClause{},
+		&SelectStmt{},
+		// Declarations
+		&File{}, &GenDecl{}, &ImportSpec{}, &ValueSpec{}, &TypeSpec{}, &FuncDecl{},
+	} {
+		t := reflect.TypeOf(n).Elem()
+		nodeTypes[t.Name()] = t
+	}
+}
+
+var (
+	nodeType            = reflect.TypeOf((*Node)(nil)).Elem()
+	bigFloatType        = reflect.TypeOf((*big.Float)(nil))
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// ToJSON encodes the subtree rooted at node as indented JSON.
+func ToJSON(node Node) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := encodeJSON(&buf, reflect.ValueOf(node)); err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, buf.Bytes(), "", "\t"); err != nil {
+		return nil, err
+	}
+	out.WriteByte('\n')
+	return out.Bytes(), nil
+}
+
+func encodeJSON(buf *bytes.Buffer, v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Invalid:
+		buf.WriteString("null")
+
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		if v.Kind() == reflect.Interface {
+			return encodeJSON(buf, v.Elem())
+		}
+		if v.Type().Implements(textMarshalerType) {
+			b, err := json.Marshal(v.Interface())
+			if err != nil {
+				return err
+			}
+			buf.Write(b)
+			return nil
+		}
+		n, ok := v.Interface().(Node)
+		if !ok {
+			return encodeJSON(buf, v.Elem())
+		}
+		if _, ok := nodeTypes[v.Elem().Type().Name()]; !ok {
+			return fmt.Errorf("ast.ToJSON: unexpected node type %T", n)
+		}
+		fmt.Fprintf(buf, `{"Kind":%q,"Pos":%d,"End":%d`, v.Elem().Type().Name(), n.Pos(), n.End())
+		return encodeFields(buf, v.Elem(), true)
+
+	case reflect.Slice:
+		buf.WriteByte('[')
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := encodeJSON(buf, v.Index(i)); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+
+	case reflect.Struct:
+		buf.WriteByte('{')
+		return encodeFields(buf, v, false)
+
+	default:
+		b, err := json.Marshal(v.Interface())
+		if err != nil {
+			return err
+		}
+		buf.Write(b)
+	}
+	return nil
+}
+
+// encodeFields writes the non-zero exported fields of the struct v and the
+// closing brace of its object. sep reports whether a field must be preceded
+// by a comma.
+func encodeFields(buf *bytes.Buffer, v reflect.Value, sep bool) error {
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		if !f.IsExported() || v.Field(i).IsZero() {
+			continue
+		}
+		if sep {
+			buf.WriteByte(',')
+		}
+		sep = true
+		fmt.Fprintf(buf, "%q:", f.Name)
+		if err := encodeJSON(buf, v.Field(i)); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+// FromJSON decodes a tree encoded by ToJSON. Import specs and comment groups,
+// which a File references from more than one field, and the declaring
+// identifiers a FuncLit references from its FreeVars are shared again in the
+// decoded tree.
+func FromJSON(data []byte) (Node, error) {
+	d := decoder{shared: make(map[sharedKey]reflect.Value)}
+	v := reflect.New(nodeType).Elem()
+	if err := d.decode(json.RawMessage(data), v); err != nil {
+		return nil, err
+	}
+	if v.IsNil() {
+		return nil, nil
+	}
+	return v.Interface().(Node), nil
+}
+
+// sharedKey identifies a node that may be referenced more than once.
+type sharedKey struct {
+	kind string
+	pos  lexer.Pos
+}
+
+type decoder struct {
+	shared map[sharedKey]reflect.Value
+}
+
+// decode decodes data into v, which must be settable.
+func (d *decoder) decode(data json.RawMessage, v reflect.Value) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		if v.Type() == bigFloatType {
+			// the text form is exact only at the precision it was written with
+			f := new(big.Float).SetPrec(FloatPrec)
+			if err := json.Unmarshal(data, f); err != nil {
+				return err
+			}
+			v.Set(reflect.ValueOf(f))
+			return nil
+		}
+		if v.Kind() == reflect.Pointer && !v.Type().Implements(nodeType) {
+			p := reflect.New(v.Type().Elem())
+			if v.Type().Implements(textUnmarshalerType) {
+				if err := json.Unmarshal(data, p.Interface()); err != nil {
+					return err
+				}
+				v.Set(p)
+				return nil
+			}
+			if err := d.decode(data, p.Elem()); err != nil {
+				return err
+			}
+			v.Set(p)
+			return nil
+		}
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return err
+		}
+		var kind string
+		if err := json.Unmarshal(obj["Kind"], &kind); err != nil {
+			return fmt.Errorf("ast.FromJSON: node without a Kind: %s", data)
+		}
+		t, ok := nodeTypes[kind]
+		if !ok {
+			return fmt.Errorf("ast.FromJSON: unknown node kind %q", kind)
+		}
+		p := reflect.New(t)
+		if !p.Type().AssignableTo(v.Type()) {
+			return fmt.Errorf("ast.FromJSON: %s cannot be used as %s", kind, v.Type())
+		}
+		if err := d.decodeFields(obj, p.Elem()); err != nil {
+			return err
+		}
+		if kind == "ImportSpec" || kind == "CommentGroup" || kind == "Identifier" {
+			key := sharedKey{kind, p.Interface().(Node).Pos()}
+			if prev, ok := d.shared[key]; ok && key.pos.IsValid() {
+				p = prev
+			}
+			d.shared[key] = p
+		}
+		v.Set(p)
+
+	case reflect.Slice:
+		var elems []json.RawMessage
+		if err := json.Unmarshal(data, &elems); err != nil {
+			return err
+		}
+		s := reflect.MakeSlice(v.Type(), len(elems), len(elems))
+		for i, e := range elems {
+			if err := d.decode(e, s.Index(i)); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+
+	case reflect.Struct:
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return err
+		}
+		return d.decodeFields(obj, v)
+
+	default:
+		return json.Unmarshal(data, v.Addr().Interface())
+	}
+	return nil
+}
+
+// decodeFields decodes the members of obj into the fields of the struct v.
+// Members that are not fields, such as Kind, Pos and End, are ignored.
+func (d *decoder) decodeFields(obj map[string]json.RawMessage, v reflect.Value) error {
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		data, ok := obj[f.Name]
+		if !ok || !f.IsExported() {
+			continue
+		}
+		if err := d.decode(data, v.Field(i)); err != nil {
+			return fmt.Errorf("%s.%s: %w", v.Type().Name(), f.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package ast_test
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+	parser "github.com/mohit-bhandari45/Compiler-GO.git/internal/parser"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+// parseFile parses the named testdata file with its comments.
+func parseFile(t *testing.T, name string) *ast.File {
+	t.Helper()
+	src, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fset := lexer.NewFileSet()
+	f := fset.AddFile(name, string(src))
+	p := parser.New(lexer.NewInFile(f, string(src), lexer.ScanComments))
+	file := p.ParseFile()
+	if errs := p.Errors(); len(errs) > 0 {
+		t.Fatalf("parse errors: %v", errs)
+	}
+	return file
+}
+
+// TestJSONGolden checks the encoding of testdata/nodes.input against
+// testdata/nodes.golden, and that decoding it gives back the tree. Run with
+// -update to rewrite the golden file.
+func TestJSONGolden(t *testing.T) {
+	file := parseFile(t, filepath.Join("testdata", "nodes.input"))
+	got, err := ast.ToJSON(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden := filepath.Join("testdata", "nodes.golden")
+	if *update {
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("ToJSON differs from %s; run with -update if the change is intended", golden)
+	}
+
+	decoded, err := ast.FromJSON(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ast.Equal(decoded, file, 0) {
+		t.Error("FromJSON of the golden file is not Equal to the parsed tree")
+	}
+
+	// the free variables of a function literal are its declaring identifiers
+	idents := make(map[lexer.Pos]*ast.Identifier)
+	var lit *ast.FuncLit
+	ast.Inspect(decoded, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.Identifier:
+			idents[n.Pos()] = n
+		case *ast.FuncLit:
+			lit = n
+		}
+		return true
+	})
+	if lit == nil || len(lit.FreeVars) != 1 {
+		t.Fatalf("decoded function literal %v, want one with one free variable", lit)
+	}
+	if decl := lit.FreeVars[0]; idents[decl.Pos()] != decl {
+		t.Errorf("decoded free variable %s is not its declaring identifier", decl.Value)
+	}
+}
+
+// TestJSONRoundTripCoversAllNodes checks that the trees round-tripped by
+// the JSON tests use every node type, so that a node added without JSON
+// support is caught.
+func TestJSONRoundTripCoversAllNodes(t *testing.T) {
+	seen := make(map[string]bool)
+	record := func(n ast.Node) bool {
+		if n != nil {
+			seen[reflectName(n)] = true
+		}
+		return true
+	}
+	file := parseFile(t, filepath.Join("testdata", "nodes.input"))
+	ast.Inspect(file, record)
+	for _, c := range file.Comments {
+		ast.Inspect(c, record)
+	}
+
+	p := parser.New(lexer.New("x := 1\n"))
+	prog := p.ParseProgram()
+	ast.Inspect(prog, record)
+	data, err := ast.ToJSON(prog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := ast.FromJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ast.Equal(decoded, prog, 0) {
+		t.Error("a Program does not round-trip")
+	}
+
+	for name := range ast.NodeTypes {
+		if !seen[name] {
+			t.Errorf("testdata/nodes.input has no %s", name)
+		}
+	}
+}
+
+func reflectName(n ast.Node) string {
+	return reflect.TypeOf(n).Elem().Name()
+}
@@ -0,0 +1,318 @@
+package ast
+
+import (
+	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+)
+
+// Pos and End of every node. Pos is the position of the node's first
+// character and End the position just past its last one, so the node spans
+// the source range [Pos, End). Nodes of an incomplete tree, as built for
+// source with syntax errors, may report NoPos for missing parts.
+
+// ------- Expressions -------- //
+
+func (i *Identifier) Pos() lexer.Pos { return i.Token.Pos }
+func (i *Identifier) End() lexer.Pos { return i.Token.EndPos() }
+
+func (il *IntegerLiteral) Pos() lexer.Pos { return il.Token.Pos }
+func (il *IntegerLiteral) End() lexer.Pos { return il.Token.EndPos() }
+
+func (fl *FloatLiteral) Pos() lexer.Pos { return fl.Token.Pos }
+func (fl *FloatLiteral) End() lexer.Pos { return fl.Token.EndPos() }
+
+func (sl *StringLiteral) Pos() lexer.Pos { return sl.Token.Pos }
+func (sl *StringLiteral) End() lexer.Pos { return sl.Token.EndPos() }
+
+func (rl *RuneLiteral) Pos() lexer.Pos { return rl.Token.Pos }
+func (rl *RuneLiteral) End() lexer.Pos { return rl.Token.EndPos() }
+
+func (pe *PrefixExpression) Pos() lexer.Pos { return pe.Token.Pos }
+func (pe *PrefixExpression) End() lexer.Pos { return endOr(pe.Right, pe.Token) }
+
+func (ie *InfixExpression) Pos() lexer.Pos { return posOr(ie.Left, ie.Token) }
+func (ie *InfixExpression) End() lexer.Pos { return endOr(ie.Right, ie.Token) }
+
+func (pe *ParenExpr) Pos() lexer.Pos { return pe.Token.Pos }
+func (pe *ParenExpr) End() lexer.Pos { return pe.Rparen.EndPos() }
+
+func (ta *TypeAssertExpr) Pos() lexer.Pos { return posOr(ta.X, ta.Token) }
+func (ta *TypeAssertExpr) End() lexer.Pos { return ta.Rparen.EndPos() }
+
+func (fl *FuncLit) Pos() lexer.Pos { return fl.Token.Pos }
+func (fl *FuncLit) End() lexer.Pos {
+	if fl.Body != nil {
+		return fl.Body.End()
+	}
+	return fl.Type.End()
+}
+
+func (ce *CallExpr) Pos() lexer.Pos { return posOr(ce.Fun, ce.Token) }
+func (ce *CallExpr) End() lexer.Pos { return ce.Rparen.EndPos() }
+
+func (ie *IndexExpr) Pos() lexer.Pos { return posOr(ie.X, ie.Token) }
+func (ie *IndexExpr) End() lexer.Pos { return ie.Rbrack.EndPos() }
+
+func (il *IndexListExpr) Pos() lexer.Pos { return posOr(il.X, il.Token) }
+func (il *IndexListExpr) End() lexer.Pos { return il.Rbrack.EndPos() }
+
+func (se *SelectorExpr) Pos() lexer.Pos { return posOr(se.X, se.Token) }
+func (se *SelectorExpr) End() lexer.Pos {
+	if se.Sel != nil {
+		return se.Sel.End()
+	}
+	return se.Token.EndPos()
+}
+
+func (se *SliceExpr) Pos() lexer.Pos { return posOr(se.X, se.Token) }
+func (se *SliceExpr) End() lexer.Pos { return se.Rbrack.EndPos() }
+
+func (cl *CompositeLit) Pos() lexer.Pos { return posOr(cl.Type, cl.Token) }
+func (cl *CompositeLit) End() lexer.Pos { return cl.Rbrace.EndPos() }
+
+func (kv *KeyValueExpr) Pos() lexer.Pos { return posOr(kv.Key, kv.Token) }
+func (kv *KeyValueExpr) End() lexer.Pos { return endOr(kv.Value, kv.Token) }
+
+// ------- Types -------- //
+
+func (f *Field) Pos() lexer.Pos {
+	if len(f.Names) > 0 {
+		return f.Names[0].Pos()
+	}
+	return posOr(f.Type, lexer.Token{})
+}
+func (f *Field) End() lexer.Pos {
+	if f.Tag != nil {
+		return f.Tag.End()
+	}
+	return endOr(f.Type, lexer.Token{})
+}
+
+func (fl *FieldList) Pos() lexer.Pos {
+	if fl.Token.Pos.IsValid() || len(fl.List) == 0 {
+		return fl.Token.Pos
+	}
+	return fl.List[0].Pos()
+}
+func (fl *FieldList) End() lexer.Pos {
+	if fl.Closing.Pos.IsValid() || len(fl.List) == 0 {
+		return fl.Closing.EndPos()
+	}
+	return fl.List[len(fl.List)-1].End()
+}
+
+func (ft *FuncType) Pos() lexer.Pos {
+	if ft.Token.Pos.IsValid() || ft.Params == nil {
+		return ft.Token.Pos
+	}
+	return ft.Params.Pos()
+}
+func (ft *FuncType) End() lexer.Pos {
+	if ft.Results != nil {
+		return ft.Results.End()
+	}
+	if ft.Params != nil {
+		return ft.Params.End()
+	}
+	return ft.Token.EndPos()
+}
+
+func (e *Ellipsis) Pos() lexer.Pos { return e.Token.Pos }
+func (e *Ellipsis) End() lexer.Pos { return endOr(e.Elt, e.Token) }
+
+func (se *StarExpr) Pos() lexer.Pos { return se.Token.Pos }
+func (se *StarExpr) End() lexer.Pos { return endOr(se.X, se.Token) }
+
+func (at *ArrayType) Pos() lexer.Pos { return at.Token.Pos }
+func (at *ArrayType) End() lexer.Pos { return endOr(at.Elt, at.Token) }
+
+func (mt *MapType) Pos() lexer.Pos { return mt.Token.Pos }
+func (mt *MapType) End() lexer.Pos { return endOr(mt.Value, mt.Token) }
+
+func (ct *ChanType) Pos() lexer.Pos { return ct.Token.Pos }
+func (ct *ChanType) End() lexer.Pos { return endOr(ct.Value, ct.Token) }
+
+func (st *StructType) Pos() lexer.Pos { return st.Token.Pos }
+func (st *StructType) End() lexer.Pos { return st.Fields.End() }
+
+func (it *InterfaceType) Pos() lexer.Pos { return it.Token.Pos }
+func (it *InterfaceType) End() lexer.Pos { return it.Methods.End() }
+
+// ------- Statements -------- //
+
+func (p *Program) Pos() lexer.Pos {
+	if len(p.Statements) > 0 {
+		return p.Statements[0].Pos()
+	}
+	return lexer.NoPos
+}
+func (p *Program) End() lexer.Pos {
+	if n := len(p.Statements); n > 0 {
+		return p.Statements[n-1].End()
+	}
+	return lexer.NoPos
+}
+
+func (bs *BlockStatement) Pos() lexer.Pos { return bs.Token.Pos }
+func (bs *BlockStatement) End() lexer.Pos { return bs.Rbrace.EndPos() }
+
+func (es *ExprStmt) Pos() lexer.Pos { return posOr(es.X, es.Token) }
+func (es *ExprStmt) End() lexer.Pos { return endOr(es.X, es.Token) }
+
+func (as *AssignStmt) Pos() lexer.Pos { return posOr(firstExpr(as.Lhs), as.Token) }
+func (as *AssignStmt) End() lexer.Pos { return endOr(lastExpr(as.Rhs), as.Token) }
+
+func (bs *BranchStmt) Pos() lexer.Pos { return bs.Token.Pos }
+func (bs *BranchStmt) End() lexer.Pos {
+	if bs.Label != nil {
+		return bs.Label.End()
+	}
+	return bs.Token.EndPos()
+}
+
+func (ls *LabeledStmt) Pos() lexer.Pos { return ls.Label.Pos() }
+func (ls *LabeledStmt) End() lexer.Pos { return endOr(ls.Stmt, ls.Token) }
+
+func (cc *CaseClause) Pos() lexer.Pos { return cc.Token.Pos }
+func (cc *CaseClause) End() lexer.Pos { return endOr(lastStmt(cc.Body), cc.Colon) }
+
+func (ss *SwitchStmt) Pos() lexer.Pos { return ss.Token.Pos }
+func (ss *SwitchStmt) End() lexer.Pos { return ss.Rbrace.EndPos() }
+
+func (ts *TypeSwitchStmt) Pos() lexer.Pos { return ts.Token.Pos }
+func (ts *TypeSwitchStmt) End() lexer.Pos { return ts.Rbrace.EndPos() }
+
+func (rs *ReturnStmt) Pos() lexer.Pos { return rs.Token.Pos }
+func (rs *ReturnStmt) End() lexer.Pos { return endOr(lastExpr(rs.Results), rs.Token) }
+
+func (ds *DeclStmt) Pos() lexer.Pos { return ds.Decl.Pos() }
+func (ds *DeclStmt) End() lexer.Pos { return ds.Decl.End() }
+
+func (ids *IncDecStmt) Pos() lexer.Pos { return posOr(ids.X, ids.Token) }
+func (ids *IncDecStmt) End() lexer.Pos { return ids.Token.EndPos() }
+
+func (is *IfStmt) Pos() lexer.Pos { return is.Token.Pos }
+func (is *IfStmt) End() lexer.Pos {
+	if is.Else != nil {
+		return is.Else.End()
+	}
+	return is.Body.End()
+}
+
+func (fs *ForStmt) Pos() lexer.Pos { return fs.Token.Pos }
+func (fs *ForStmt) End() lexer.Pos { return fs.Body.End() }
+
+func (rs *RangeStmt) Pos() lexer.Pos { return rs.Token.Pos }
+func (rs *RangeStmt) End() lexer.Pos { return rs.Body.End() }
+
+func (gs *GoStmt) Pos() lexer.Pos { return gs.Token.Pos }
+func (gs *GoStmt) End() lexer.Pos { return gs.Call.End() }
+
+func (ds *DeferStmt) Pos() lexer.Pos { return ds.Token.Pos }
+func (ds *DeferStmt) End() lexer.Pos { return ds.Call.End() }
+
+func (ss *SendStmt) Pos() lexer.Pos { return posOr(ss.Chan, ss.Token) }
+func (ss *SendStmt) End() lexer.Pos { return endOr(ss.Value, ss.Token) }
+
+func (cc *CommClause) Pos() lexer.Pos { return cc.Token.Pos }
+func (cc *CommClause) End() lexer.Pos { return endOr(lastStmt(cc.Body), cc.Colon) }
+
+func (ss *SelectStmt) Pos() lexer.Pos { return ss.Token.Pos }
+func (ss *SelectStmt) End() lexer.Pos { return ss.Rbrace.EndPos() }
+
+// ------- Declarations -------- //
+
+func (f *File) Pos() lexer.Pos { return f.Token.Pos }
+func (f *File) End() lexer.Pos {
+	if n := len(f.Decls); n > 0 {
+		return f.Decls[n-1].End()
+	}
+	if f.Name != nil {
+		return f.Name.End()
+	}
+	return f.Token.EndPos()
+}
+
+func (gd *GenDecl) Pos() lexer.Pos { return gd.Token.Pos }
+func (gd *GenDecl) End() lexer.Pos {
+	if gd.Rparen.Pos.IsValid() || len(gd.Specs) == 0 {
+		return gd.Rparen.EndPos()
+	}
+	return gd.Specs[len(gd.Specs)-1].End()
+}
+
+func (is *ImportSpec) Pos() lexer.Pos {
+	if is.Name != nil {
+		return is.Name.Pos()
+	}
+	return is.Path.Pos()
+}
+func (is *ImportSpec) End() lexer.Pos { return is.Path.End() }
+
+func (vs *ValueSpec) Pos() lexer.Pos { return vs.Names[0].Pos() }
+func (vs *ValueSpec) End() lexer.Pos {
+	if n := len(vs.Values); n > 0 {
+		return vs.Values[n-1].End()
+	}
+	if vs.Type != nil {
+		return vs.Type.End()
+	}
+	return vs.Names[len(vs.Names)-1].End()
+}
+
+func (ts *TypeSpec) Pos() lexer.Pos { return ts.Name.Pos() }
+func (ts *TypeSpec) End() lexer.Pos { return endOr(ts.Type, ts.Name.Token) }
+
+func (fd *FuncDecl) Pos() lexer.Pos { return fd.Token.Pos }
+func (fd *FuncDecl) End() lexer.Pos {
+	if fd.Body != nil {
+		return fd.Body.End()
+	}
+	return fd.Type.End()
+}
+
+// ------- Comments -------- //
+
+func (c *Comment) Pos() lexer.Pos { return c.Token.Pos }
+func (c *Comment) End() lexer.Pos { return c.Token.EndPos() }
+
+func (g *CommentGroup) Pos() lexer.Pos { return g.List[0].Pos() }
+func (g *CommentGroup) End() lexer.Pos { return g.List[len(g.List)-1].End() }
+
+// ------- helpers -------- //
+
+// posOr returns the position of n, or that of tok if n is missing.
+func posOr(n Node, tok lexer.Token) lexer.Pos {
+	if n == nil {
+		return tok.Pos
+	}
+	return n.Pos()
+}
+
+// endOr returns the end of n, or that of tok if n is missing.
+func endOr(n Node, tok lexer.Token) lexer.Pos {
+	if n == nil {
+		return tok.EndPos()
+	}
+	return n.End()
+}
+
+func firstExpr(list []Expression) Node {
+	if len(list) == 0 {
+		return nil
+	}
+	return list[0]
+}
+
+func lastExpr(list []Expression) Node {
+	if len(list) == 0 {
+		return nil
+	}
+	return list[len(list)-1]
+}
+
+func lastStmt(list []Statement) Node {
+	if len(list) == 0 {
+		return nil
+	}
+	return list[len(list)-1]
+}
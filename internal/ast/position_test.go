@@ -0,0 +1,98 @@
+package ast_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+	parser "github.com/mohit-bhandari45/Compiler-GO.git/internal/parser"
+)
+
+const positionSrc = `package p
+
+var (
+	a = []int{
+		1,
+		2,
+	}
+	b = m[k]
+)
+
+func (r *T) f(x int) (int, error) {
+	y := g(x,
+		1)
+	z := s[1:2]
+	w := v.(int)
+	_ = (y)
+	return -z + w, nil
+}
+`
+
+// TestPositions checks the source range of nodes spanning several lines and
+// of nodes ending in a closing token, decoded through a FileSet holding
+// more than one file.
+func TestPositions(t *testing.T) {
+	fset := lexer.NewFileSet()
+	fset.AddFile("a.go", "package p\n")
+	f := fset.AddFile("b.go", positionSrc)
+	p := parser.New(lexer.NewInFile(f, positionSrc, 0))
+	file := p.ParseFile()
+	if errs := p.Errors(); len(errs) > 0 {
+		t.Fatalf("parse errors: %v", errs)
+	}
+
+	// text returns the source of n with its decoded range.
+	text := func(n ast.Node) string {
+		start, end := fset.Position(n.Pos()), fset.Position(n.End())
+		return fmt.Sprintf("%s-%d:%d %s", start, end.Line, end.Column, positionSrc[start.Offset:end.Offset])
+	}
+	funcSrc := strings.TrimSuffix(positionSrc[strings.Index(positionSrc, "func"):], "\n")
+
+	seen := map[string]bool{}
+	var stack []ast.Node
+	ast.Inspect(file, func(n ast.Node) bool {
+		if n == nil {
+			stack = stack[:len(stack)-1]
+			return false
+		}
+		if !n.Pos().IsValid() || n.End() < n.Pos() {
+			t.Errorf("%T has range [%d, %d)", n, n.Pos(), n.End())
+		} else if len(stack) > 0 {
+			parent := stack[len(stack)-1]
+			if n.Pos() < parent.Pos() || n.End() > parent.End() {
+				t.Errorf("%s lies outside its parent %s", text(n), text(parent))
+			}
+		}
+		seen[reflect.TypeOf(n).Elem().Name()+" "+text(n)] = true
+		stack = append(stack, n)
+		return true
+	})
+
+	for _, want := range []string{
+		"File b.go:1:1-18:2 " + strings.TrimSuffix(positionSrc, "\n"),
+		"GenDecl b.go:3:1-9:2 var (\n\ta = []int{\n\t\t1,\n\t\t2,\n\t}\n\tb = m[k]\n)",
+		"CompositeLit b.go:4:6-7:3 []int{\n\t\t1,\n\t\t2,\n\t}",
+		"IndexExpr b.go:8:6-8:10 m[k]",
+		"FuncDecl b.go:11:1-18:2 " + funcSrc,
+		"FieldList b.go:11:6-11:12 (r *T)",
+		"StarExpr b.go:11:9-11:11 *T",
+		"FieldList b.go:11:14-11:21 (x int)",
+		"FieldList b.go:11:22-11:34 (int, error)",
+		"BlockStatement b.go:11:35-18:2 " + funcSrc[strings.Index(funcSrc, "{"):],
+		"AssignStmt b.go:12:2-13:5 y := g(x,\n\t\t1)",
+		"CallExpr b.go:12:7-13:5 g(x,\n\t\t1)",
+		"SliceExpr b.go:14:7-14:13 s[1:2]",
+		"TypeAssertExpr b.go:15:7-15:14 v.(int)",
+		"ParenExpr b.go:16:6-16:9 (y)",
+		"ReturnStmt b.go:17:2-17:20 return -z + w, nil",
+		"InfixExpression b.go:17:9-17:15 -z + w",
+		"PrefixExpression b.go:17:9-17:11 -z",
+	} {
+		if !seen[want] {
+			t.Errorf("no node %q", want)
+		}
+	}
+}
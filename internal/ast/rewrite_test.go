@@ -0,0 +1,81 @@
+package ast
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestApplyEditsEverySlice checks the Cursor operations on every
+// slice-valued field of every node type: in a slice [a, b, c], a is
+// replaced by r with x inserted before it, b is deleted and y is inserted
+// after c, giving [x, r, c, y]. The inserted and replacing nodes must not be
+// walked.
+func TestApplyEditsEverySlice(t *testing.T) {
+	for _, nt := range sortedNodeTypes(t) {
+		for i := 0; i < nt.NumField(); i++ {
+			sf := nt.Field(i)
+			name := nt.Name() + "." + sf.Name
+			if notWalked[name] || sf.Type.Kind() != reflect.Slice || !newChild(sf.Type.Elem()).IsValid() {
+				continue
+			}
+			t.Run(name, func(t *testing.T) {
+				elem := sf.Type.Elem()
+				newNode := func() Node { return newChild(elem).Interface().(Node) }
+				a, b, c := newNode(), newNode(), newNode()
+				x, r, y := newNode(), newNode(), newNode()
+
+				root := reflect.New(nt)
+				list := root.Elem().Field(i)
+				for _, n := range []Node{a, b, c} {
+					list.Set(reflect.Append(list, reflect.ValueOf(n)))
+				}
+
+				var visited []Node
+				rootNode := root.Interface().(Node)
+				Apply(rootNode, func(cur *Cursor) bool {
+					if cur.Node() == rootNode {
+						return true
+					}
+					if cur.Parent() != rootNode || cur.Name() != sf.Name || cur.Node() == nil {
+						return false
+					}
+					visited = append(visited, cur.Node())
+					switch cur.Node() {
+					case a:
+						cur.Replace(r)
+						cur.InsertBefore(x)
+					case b:
+						cur.Delete()
+					case c:
+						cur.InsertAfter(y)
+					}
+					return false
+				}, nil)
+
+				if want := []Node{a, b, c}; !sameNodes(visited, want) {
+					t.Errorf("visited %d nodes, want a, b, c", len(visited))
+				}
+				var got []Node
+				for j := 0; j < list.Len(); j++ {
+					got = append(got, list.Index(j).Interface().(Node))
+				}
+				if want := []Node{x, r, c, y}; !sameNodes(got, want) {
+					t.Errorf("got %d nodes, want x, r, c, y", len(got))
+				}
+			})
+		}
+	}
+}
+
+// sameNodes reports whether a and b hold the same nodes in the same order.
+func sameNodes(a, b []Node) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,548 @@
+package ast
+
+import (
+	"strings"
+
+	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+)
+
+// ExprStmt represents an expression used as a statement, such as a bare call.
+type ExprStmt struct {
+	Token lexer.Token // The first token of the expression
+	X     Expression  // The expression
+}
+
+// Marks this node as a Statement (required by the Statement interface)
+func (es *ExprStmt) statementNode() {}
+
+// Returns the literal value of the token as it appeared in the source code
+func (es *ExprStmt) TokenLiteral() string {
+	return es.Token.Literal
+}
+
+// Returns a string representation of the expression statement
+func (es *ExprStmt) String() string {
+	if es.X == nil {
+		return ""
+	}
+	return es.X.String()
+}
+
+// ---------------------------------------------------------------------------- //
+
+// AssignStmt represents an assignment or a short variable declaration,
+// such as x = 5 or a, b := f().
+type AssignStmt struct {
+	Token lexer.Token  // The assignment token (`=` or `:=`)
+	Lhs   []Expression // The left-hand side operands
+	Rhs   []Expression // The right-hand side values
+}
+
+// Marks this node as a Statement (required by the Statement interface)
+func (as *AssignStmt) statementNode() {}
+
+// Returns the literal value of the token as it appeared in the source code
+func (as *AssignStmt) TokenLiteral() string {
+	return as.Token.Literal
+}
+
+// Returns a string representation of the assignment
+// Example: a, b := 1, 2
+func (as *AssignStmt) String() string {
+	return joinExprs(as.Lhs) + " " + as.Token.Literal + " " + joinExprs(as.Rhs)
+}
+
+// ---------------------------------------------------------------------------- //
+
+// BranchStmt represents a statement that transfers control: break,
+// continue, goto or fallthrough.
+type BranchStmt struct {
+	Token lexer.Token // The keyword token (BREAK, CONTINUE, GOTO or FALLTHROUGH)
+	Label *Identifier // The target label; nil if none
+}
+
+// Marks this node as a Statement (required by the Statement interface)
+func (bs *BranchStmt) statementNode() {}
+
+// Returns the literal value of the token as it appeared in the source code
+func (bs *BranchStmt) TokenLiteral() string {
+	return bs.Token.Literal
+}
+
+// Returns a string representation of the branch statement
+func (bs *BranchStmt) String() string {
+	if bs.Label != nil {
+		return bs.Token.Literal + " " + bs.Label.String()
+	}
+	return bs.Token.Literal
+}
+
+// ---------------------------------------------------------------------------- //
+
+// LabeledStmt represents a statement preceded by a label, such as
+// outer: for { ... }. Stmt is nil when the label directly precedes the
+// closing brace of a block.
+type LabeledStmt struct {
+	Token lexer.Token // The token corresponding to `:`
+	Label *Identifier // The label name
+	Stmt  Statement   // The labeled statement
+}
+
+// Marks this node as a Statement (required by the Statement interface)
+func (ls *LabeledStmt) statementNode() {}
+
+// Returns the literal value of the token as it appeared in the source code
+func (ls *LabeledStmt) TokenLiteral() string {
+	return ls.Token.Literal
+}
+
+// Returns a string representation of the labeled statement
+func (ls *LabeledStmt) String() string {
+	if ls.Stmt == nil {
+		return ls.Label.String() + ":"
+	}
+	return ls.Label.String() + ": " + ls.Stmt.String()
+}
+
+// ---------------------------------------------------------------------------- //
+
+// CaseClause represents a single `case x, y:` or `default:` clause of a switch.
+// In a type switch the List holds types rather than values.
+type CaseClause struct {
+	Token lexer.Token  // The token corresponding to `case` or `default`
+	List  []Expression // The case values or types; nil for default
+	Colon lexer.Token  // The colon ending the case or default
+	Body  []Statement  // The statements of the clause
+}
+
+// Marks this node as a Statement (required by the Statement interface)
+func (cc *CaseClause) statementNode() {}
+
+// Returns the literal value of the token as it appeared in the source code
+func (cc *CaseClause) TokenLiteral() string {
+	return cc.Token.Literal
+}
+
+// Returns a string representation of the case clause
+// Example: case 1, 2: x = 3
+func (cc *CaseClause) String() string {
+	var out strings.Builder
+	if cc.List == nil {
+		out.WriteString("default:")
+	} else {
+		out.WriteString("case " + joinExprs(cc.List) + ":")
+	}
+	for _, s := range cc.Body {
+		out.WriteString(" " + s.String() + ";")
+	}
+	return out.String()
+}
+
+// ---------------------------------------------------------------------------- //
+
+// SwitchStmt represents an expression switch, such as
+// switch x := f(); x { case 1: ... } or the tagless switch { case x > 0: ... }.
+type SwitchStmt struct {
+	Token  lexer.Token   // The token corresponding to `switch`
+	Init   Statement     // The optional init statement; or nil
+	Tag    Expression    // The switch expression; nil for a tagless switch
+	Cases  []*CaseClause // The case clauses in source order
+	Rbrace lexer.Token   // The closing brace
+}
+
+// Marks this node as a Statement (required by the Statement interface)
+func (ss *SwitchStmt) statementNode() {}
+
+// Returns the literal value of the token as it appeared in the source code
+func (ss *SwitchStmt) TokenLiteral() string {
+	return ss.Token.Literal
+}
+
+// Returns a string representation of the switch statement
+func (ss *SwitchStmt) String() string {
+	var out strings.Builder
+	out.WriteString("switch ")
+	if ss.Init != nil {
+		out.WriteString(ss.Init.String() + "; ")
+	}
+	if ss.Tag != nil {
+		out.WriteString(ss.Tag.String() + " ")
+	}
+	writeCases(&out, ss.Cases)
+	return out.String()
+}
+
+// ---------------------------------------------------------------------------- //
+
+// TypeSwitchStmt represents a type switch, such as
+// switch v := x.(type) { case int: ... }.
+type TypeSwitchStmt struct {
+	Token   lexer.Token   // The token corresponding to `switch`
+	Init    Statement     // The optional init statement; or nil
+	Binding *Identifier   // The v in v := x.(type); or nil
+	X       Expression    // The expression whose dynamic type is switched on
+	Cases   []*CaseClause // The case clauses in source order
+	Rbrace  lexer.Token   // The closing brace
+}
+
+// Marks this node as a Statement (required by the Statement interface)
+func (ts *TypeSwitchStmt) statementNode() {}
+
+// Returns the literal value of the token as it appeared in the source code
+func (ts *TypeSwitchStmt) TokenLiteral() string {
+	return ts.Token.Literal
+}
+
+// Returns a string representation of the type switch
+func (ts *TypeSwitchStmt) String() string {
+	var out strings.Builder
+	out.WriteString("switch ")
+	if ts.Init != nil {
+		out.WriteString(ts.Init.String() + "; ")
+	}
+	if ts.Binding != nil {
+		out.WriteString(ts.Binding.String() + " := ")
+	}
+	out.WriteString(ts.X.String() + ".(type) ")
+	writeCases(&out, ts.Cases)
+	return out.String()
+}
+
+// ---------------------------------------------------------------------------- //
+
+// ReturnStmt represents a return statement with zero or more results,
+// such as return or return x, err.
+type ReturnStmt struct {
+	Token   lexer.Token  // The token corresponding to `return`
+	Results []Expression // The returned values; or nil
+}
+
+// Marks this node as a Statement (required by the Statement interface)
+func (rs *ReturnStmt) statementNode() {}
+
+// Returns the literal value of the token as it appeared in the source code
+func (rs *ReturnStmt) TokenLiteral() string {
+	return rs.Token.Literal
+}
+
+// Returns a string representation of the return statement
+func (rs *ReturnStmt) String() string {
+	if len(rs.Results) == 0 {
+		return "return"
+	}
+	return "return " + joinExprs(rs.Results)
+}
+
+// ---------------------------------------------------------------------------- //
+
+// DeclStmt represents a const or var declaration inside a function body.
+type DeclStmt struct {
+	Decl *GenDecl // The declaration
+}
+
+// Marks this node as a Statement (required by the Statement interface)
+func (ds *DeclStmt) statementNode() {}
+
+// Returns the literal value of the declaration keyword as it appeared in the source code
+func (ds *DeclStmt) TokenLiteral() string {
+	return ds.Decl.TokenLiteral()
+}
+
+// Returns a string representation of the declaration
+func (ds *DeclStmt) String() string {
+	return ds.Decl.String()
+}
+
+// ---------------------------------------------------------------------------- //
+
+// IncDecStmt represents an increment or decrement statement, such as i++.
+type IncDecStmt struct {
+	Token lexer.Token // The operator token (INC or DEC)
+	X     Expression  // The operand
+}
+
+// Marks this node as a Statement (required by the Statement interface)
+func (ids *IncDecStmt) statementNode() {}
+
+// Returns the literal value of the token as it appeared in the source code
+func (ids *IncDecStmt) TokenLiteral() string {
+	return ids.Token.Literal
+}
+
+// Returns a string representation of the statement
+func (ids *IncDecStmt) String() string {
+	return ids.X.String() + ids.Token.Literal
+}
+
+// ---------------------------------------------------------------------------- //
+
+// IfStmt represents an if statement, such as
+// if x := f(); x > 0 { ... } else { ... }.
+type IfStmt struct {
+	Token lexer.Token     // The token corresponding to `if`
+	Init  Statement       // The optional init statement; or nil
+	Cond  Expression      // The condition
+	Body  *BlockStatement // The block executed when Cond is true
+	Else  Statement       // The else branch (*BlockStatement or *IfStmt); or nil
+}
+
+// Marks this node as a Statement (required by the Statement interface)
+func (is *IfStmt) statementNode() {}
+
+// Returns the literal value of the token as it appeared in the source code
+func (is *IfStmt) TokenLiteral() string {
+	return is.Token.Literal
+}
+
+// Returns a string representation of the if statement
+func (is *IfStmt) String() string {
+	var out strings.Builder
+	out.WriteString("if ")
+	if is.Init != nil {
+		out.WriteString(is.Init.String() + "; ")
+	}
+	if is.Cond != nil {
+		out.WriteString(is.Cond.String())
+	}
+	out.WriteString(" { " + is.Body.String() + " }")
+	if is.Else != nil {
+		if _, ok := is.Else.(*IfStmt); ok {
+			out.WriteString(" else " + is.Else.String())
+		} else {
+			out.WriteString(" else { " + is.Else.String() + " }")
+		}
+	}
+	return out.String()
+}
+
+// ---------------------------------------------------------------------------- //
+
+// ForStmt represents a three-clause, condition-only or infinite for loop,
+// such as for i := 0; i < n; i++ { ... }.
+type ForStmt struct {
+	Token lexer.Token     // The token corresponding to `for`
+	Init  Statement       // The init statement; or nil
+	Cond  Expression      // The loop condition; nil for an infinite loop
+	Post  Statement       // The post statement; or nil
+	Body  *BlockStatement // The loop body
+}
+
+// Marks this node as a Statement (required by the Statement interface)
+func (fs *ForStmt) statementNode() {}
+
+// Returns the literal value of the token as it appeared in the source code
+func (fs *ForStmt) TokenLiteral() string {
+	return fs.Token.Literal
+}
+
+// Returns a string representation of the for loop
+func (fs *ForStmt) String() string {
+	var out strings.Builder
+	out.WriteString("for ")
+	if fs.Init != nil || fs.Post != nil {
+		if fs.Init != nil {
+			out.WriteString(fs.Init.String())
+		}
+		out.WriteString("; ")
+		if fs.Cond != nil {
+			out.WriteString(fs.Cond.String())
+		}
+		out.WriteString("; ")
+		if fs.Post != nil {
+			out.WriteString(fs.Post.String())
+		}
+		out.WriteString(" ")
+	} else if fs.Cond != nil {
+		out.WriteString(fs.Cond.String() + " ")
+	}
+	out.WriteString("{ " + fs.Body.String() + " }")
+	return out.String()
+}
+
+// ---------------------------------------------------------------------------- //
+
+// RangeStmt represents a for loop with a range clause, such as
+// for k, v := range m { ... }.
+type RangeStmt struct {
+	Token lexer.Token     // The token corresponding to `for`
+	Key   Expression      // The first iteration variable; or nil
+	Value Expression      // The second iteration variable; or nil
+	Tok   lexer.Token     // The assignment token (`:=` or `=`); zero value if Key is nil
+	X     Expression      // The value being ranged over
+	Body  *BlockStatement // The loop body
+}
+
+// Marks this node as a Statement (required by the Statement interface)
+func (rs *RangeStmt) statementNode() {}
+
+// Returns the literal value of the token as it appeared in the source code
+func (rs *RangeStmt) TokenLiteral() string {
+	return rs.Token.Literal
+}
+
+// Returns a string representation of the range loop
+func (rs *RangeStmt) String() string {
+	var out strings.Builder
+	out.WriteString("for ")
+	if rs.Key != nil {
+		out.WriteString(rs.Key.String())
+		if rs.Value != nil {
+			out.WriteString(", " + rs.Value.String())
+		}
+		out.WriteString(" " + rs.Tok.Literal + " ")
+	}
+	out.WriteString("range " + rs.X.String() + " { " + rs.Body.String() + " }")
+	return out.String()
+}
+
+// ---------------------------------------------------------------------------- //
+
+// GoStmt represents a go statement, such as go worker(ch).
+type GoStmt struct {
+	Token lexer.Token // The token corresponding to `go`
+	Call  *CallExpr   // The function call run in a new goroutine
+}
+
+// Marks this node as a Statement (required by the Statement interface)
+func (gs *GoStmt) statementNode() {}
+
+// Returns the literal value of the token as it appeared in the source code
+func (gs *GoStmt) TokenLiteral() string {
+	return gs.Token.Literal
+}
+
+// Returns a string representation of the go statement
+func (gs *GoStmt) String() string {
+	return "go " + gs.Call.String()
+}
+
+// ---------------------------------------------------------------------------- //
+
+// DeferStmt represents a defer statement, such as defer f.Close().
+type DeferStmt struct {
+	Token lexer.Token // The token corresponding to `defer`
+	Call  *CallExpr   // The deferred function call
+}
+
+// Marks this node as a Statement (required by the Statement interface)
+func (ds *DeferStmt) statementNode() {}
+
+// Returns the literal value of the token as it appeared in the source code
+func (ds *DeferStmt) TokenLiteral() string {
+	return ds.Token.Literal
+}
+
+// Returns a string representation of the defer statement
+func (ds *DeferStmt) String() string {
+	return "defer " + ds.Call.String()
+}
+
+// ---------------------------------------------------------------------------- //
+
+// SendStmt represents a channel send, such as ch <- v.
+type SendStmt struct {
+	Token lexer.Token // The token corresponding to `<-`
+	Chan  Expression  // The channel
+	Value Expression  // The value sent
+}
+
+// Marks this node as a Statement (required by the Statement interface)
+func (ss *SendStmt) statementNode() {}
+
+// Returns the literal value of the token as it appeared in the source code
+func (ss *SendStmt) TokenLiteral() string {
+	return ss.Token.Literal
+}
+
+// Returns a string representation of the send statement
+func (ss *SendStmt) String() string {
+	value := ""
+	if ss.Value != nil {
+		value = ss.Value.String()
+	}
+	return ss.Chan.String() + " <- " + value
+}
+
+// ---------------------------------------------------------------------------- //
+
+// CommClause represents a single case of a select statement. Comm is a
+// *SendStmt (case ch <- v:), an *ExprStmt receiving from a channel
+// (case <-ch:), an *AssignStmt assigning a received value
+// (case v, ok := <-ch:), or nil for default.
+type CommClause struct {
+	Token lexer.Token // The token corresponding to `case` or `default`
+	Comm  Statement   // The send or receive operation; nil for default
+	Colon lexer.Token // The colon ending the case or default
+	Body  []Statement // The statements of the clause
+}
+
+// Marks this node as a Statement (required by the Statement interface)
+func (cc *CommClause) statementNode() {}
+
+// Returns the literal value of the token as it appeared in the source code
+func (cc *CommClause) TokenLiteral() string {
+	return cc.Token.Literal
+}
+
+// Returns a string representation of the comm clause
+func (cc *CommClause) String() string {
+	var out strings.Builder
+	if cc.Comm == nil {
+		out.WriteString("default:")
+	} else {
+		out.WriteString("case " + cc.Comm.String() + ":")
+	}
+	for _, s := range cc.Body {
+		out.WriteString(" " + s.String() + ";")
+	}
+	return out.String()
+}
+
+// ---------------------------------------------------------------------------- //
+
+// SelectStmt represents a select statement over channel operations.
+type SelectStmt struct {
+	Token  lexer.Token   // The token corresponding to `select`
+	Cases  []*CommClause // The comm clauses in source order
+	Rbrace lexer.Token   // The closing brace
+}
+
+// Marks this node as a Statement (required by the Statement interface)
+func (ss *SelectStmt) statementNode() {}
+
+// Returns the literal value of the token as it appeared in the source code
+func (ss *SelectStmt) TokenLiteral() string {
+	return ss.Token.Literal
+}
+
+// Returns a string representation of the select statement
+func (ss *SelectStmt) String() string {
+	var out strings.Builder
+	out.WriteString("select {")
+	for _, c := range ss.Cases {
+		out.WriteString(" " + c.String())
+	}
+	out.WriteString(" }")
+	return out.String()
+}
+
+// ---------------------------------------------------------------------------- //
+
+// helpers
+func joinExprs(list []Expression) string {
+	parts := make([]string, len(list))
+	for i, e := range list {
+		if e != nil {
+			parts[i] = e.String()
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func writeCases(out *strings.Builder, cases []*CaseClause) {
+	out.WriteString("{")
+	for _, c := range cases {
+		out.WriteString(" " + c.String())
+	}
+	out.WriteString(" }")
+}
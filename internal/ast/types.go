@@ -0,0 +1,340 @@
+package ast
+
+import (
+	"strings"
+
+	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+)
+
+// ------- Type expressions -------- //
+
+// Field represents a parameter, result, struct field or interface element,
+// or a group of them sharing a type, such as a, b int. Names is empty for
+// unnamed parameters, embedded fields and interface type elements.
+type Field struct {
+	Doc     *CommentGroup  // The associated documentation of a struct field or interface element; or nil
+	Names   []*Identifier  // The parameter, field or method names; or empty
+	Type    Expression     // The type (an *Ellipsis for a variadic parameter, a *FuncType for a method)
+	Tag     *StringLiteral // The struct field tag; or nil
+	Comment *CommentGroup  // The trailing comment on the same line; or nil
+}
+
+// Returns the literal value of the first token as it appeared in the source code
+func (f *Field) TokenLiteral() string {
+	if len(f.Names) > 0 {
+		return f.Names[0].TokenLiteral()
+	}
+	return f.Type.TokenLiteral()
+}
+
+// Returns a string representation of the field
+// Example: a, b int
+func (f *Field) String() string {
+	names := make([]string, len(f.Names))
+	for i, n := range f.Names {
+		names[i] = n.String()
+	}
+	out := f.Type.String()
+	if len(names) > 0 {
+		out = strings.Join(names, ", ") + " " + out
+	}
+	if f.Tag != nil {
+		out += " " + f.Tag.TokenLiteral()
+	}
+	return out
+}
+
+// ---------------------------------------------------------------------------- //
+
+// FieldList represents a parenthesized parameter or result list, a bracketed
+// type parameter list, or the braced fields of a struct or elements of an
+// interface.
+type FieldList struct {
+	Token   lexer.Token // The opening parenthesis, bracket or brace; zero for a single unnamed result
+	List    []*Field    // The fields in source order
+	Closing lexer.Token // The closing parenthesis, bracket or brace; zero for a single unnamed result
+}
+
+// Returns the literal value of the token as it appeared in the source code
+func (fl *FieldList) TokenLiteral() string {
+	return fl.Token.Literal
+}
+
+// Returns a string representation of the field list
+// Example: (a, b int, c string)
+func (fl *FieldList) String() string {
+	parts := make([]string, len(fl.List))
+	for i, f := range fl.List {
+		parts[i] = f.String()
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+// bracketed renders a type parameter list, such as [K comparable, V any].
+func (fl *FieldList) bracketed() string {
+	s := fl.String()
+	return "[" + s[1:len(s)-1] + "]"
+}
+
+// NumFields returns the number of parameters or results described by the list,
+// counting each name of a grouped field separately.
+func (fl *FieldList) NumFields() int {
+	n := 0
+	if fl != nil {
+		for _, f := range fl.List {
+			if len(f.Names) == 0 {
+				n++
+			} else {
+				n += len(f.Names)
+			}
+		}
+	}
+	return n
+}
+
+// ---------------------------------------------------------------------------- //
+
+// FuncType represents a function signature: its parameters and results.
+type FuncType struct {
+	Token   lexer.Token // The token corresponding to `func`
+	Params  *FieldList  // The parameters
+	Results *FieldList  // The results; or nil
+}
+
+// Marks this node as an Expression (required by the Expression interface)
+func (ft *FuncType) expressionNode() {}
+
+// Returns the literal value of the token as it appeared in the source code
+func (ft *FuncType) TokenLiteral() string {
+	return ft.Token.Literal
+}
+
+// Returns a string representation of the function type
+// Example: func(a int) (string, error)
+func (ft *FuncType) String() string {
+	return "func" + ft.signature()
+}
+
+// signature renders the parameters and results without the func keyword.
+func (ft *FuncType) signature() string {
+	out := ft.Params.String()
+	if ft.Results == nil {
+		return out
+	}
+	if len(ft.Results.List) == 1 && len(ft.Results.List[0].Names) == 0 {
+		return out + " " + ft.Results.List[0].Type.String()
+	}
+	return out + " " + ft.Results.String()
+}
+
+// ---------------------------------------------------------------------------- //
+
+// Ellipsis represents the ...T type of a variadic parameter.
+type Ellipsis struct {
+	Token lexer.Token // The token corresponding to `...`
+	Elt   Expression  // The element type
+}
+
+// Marks this node as an Expression (required by the Expression interface)
+func (e *Ellipsis) expressionNode() {}
+
+// Returns the literal value of the token as it appeared in the source code
+func (e *Ellipsis) TokenLiteral() string {
+	return e.Token.Literal
+}
+
+// Returns a string representation of the variadic type
+func (e *Ellipsis) String() string {
+	if e.Elt == nil {
+		return "..."
+	}
+	return "..." + e.Elt.String()
+}
+
+// ---------------------------------------------------------------------------- //
+
+// StarExpr represents a pointer type *T, or the pointer indirection *p
+// when used in an expression.
+type StarExpr struct {
+	Token lexer.Token // The token corresponding to `*`
+	X     Expression  // The pointed-to type or the pointer being dereferenced
+}
+
+// Marks this node as an Expression (required by the Expression interface)
+func (se *StarExpr) expressionNode() {}
+
+// Returns the literal value of the token as it appeared in the source code
+func (se *StarExpr) TokenLiteral() string {
+	return se.Token.Literal
+}
+
+// Returns a string representation of the pointer type or indirection
+func (se *StarExpr) String() string {
+	if se.X == nil {
+		return "*"
+	}
+	return "*" + se.X.String()
+}
+
+// ---------------------------------------------------------------------------- //
+
+// ArrayType represents an array type [N]T, the [...]T of an array literal,
+// or a slice type []T.
+type ArrayType struct {
+	Token lexer.Token // The token corresponding to `[`
+	Len   Expression  // The length; an *Ellipsis for [...]T; nil for a slice
+	Elt   Expression  // The element type
+}
+
+// Marks this node as an Expression (required by the Expression interface)
+func (at *ArrayType) expressionNode() {}
+
+// Returns the literal value of the token as it appeared in the source code
+func (at *ArrayType) TokenLiteral() string {
+	return at.Token.Literal
+}
+
+// Returns a string representation of the array or slice type
+func (at *ArrayType) String() string {
+	length := ""
+	if at.Len != nil {
+		length = at.Len.String()
+	}
+	elt := ""
+	if at.Elt != nil {
+		elt = at.Elt.String()
+	}
+	return "[" + length + "]" + elt
+}
+
+// ---------------------------------------------------------------------------- //
+
+// MapType represents a map type map[K]V.
+type MapType struct {
+	Token lexer.Token // The token corresponding to `map`
+	Key   Expression  // The key type
+	Value Expression  // The element type
+}
+
+// Marks this node as an Expression (required by the Expression interface)
+func (mt *MapType) expressionNode() {}
+
+// Returns the literal value of the token as it appeared in the source code
+func (mt *MapType) TokenLiteral() string {
+	return mt.Token.Literal
+}
+
+// Returns a string representation of the map type
+func (mt *MapType) String() string {
+	key, value := "", ""
+	if mt.Key != nil {
+		key = mt.Key.String()
+	}
+	if mt.Value != nil {
+		value = mt.Value.String()
+	}
+	return "map[" + key + "]" + value
+}
+
+// ---------------------------------------------------------------------------- //
+
+// ChanDir is the direction of a channel type: SEND, RECV or both.
+type ChanDir int
+
+const (
+	SEND ChanDir = 1 << iota // chan<- T
+	RECV                     // <-chan T
+)
+
+// ChanType represents a channel type: chan T, chan<- T or <-chan T.
+type ChanType struct {
+	Token lexer.Token // The first token: `chan`, or `<-` for a receive-only channel
+	Dir   ChanDir     // The direction; SEND|RECV for a bidirectional channel
+	Value Expression  // The element type
+}
+
+// Marks this node as an Expression (required by the Expression interface)
+func (ct *ChanType) expressionNode() {}
+
+// Returns the literal value of the token as it appeared in the source code
+func (ct *ChanType) TokenLiteral() string {
+	return ct.Token.Literal
+}
+
+// Returns a string representation of the channel type
+func (ct *ChanType) String() string {
+	value := ""
+	if ct.Value != nil {
+		value = ct.Value.String()
+	}
+	switch ct.Dir {
+	case SEND:
+		return "chan<- " + value
+	case RECV:
+		return "<-chan " + value
+	}
+	return "chan " + value
+}
+
+// ---------------------------------------------------------------------------- //
+
+// StructType represents a struct type, such as
+// struct { X, Y int; *Node; Name string `json:"name"` }.
+type StructType struct {
+	Token  lexer.Token // The token corresponding to `struct`
+	Fields *FieldList  // The fields, including embedded ones
+}
+
+// Marks this node as an Expression (required by the Expression interface)
+func (st *StructType) expressionNode() {}
+
+// Returns the literal value of the token as it appeared in the source code
+func (st *StructType) TokenLiteral() string {
+	return st.Token.Literal
+}
+
+// Returns a string representation of the struct type
+func (st *StructType) String() string {
+	return "struct" + st.Fields.braced()
+}
+
+// ---------------------------------------------------------------------------- //
+
+// InterfaceType represents an interface type. Each element is a method
+// (a named Field with a *FuncType), an embedded interface, or a type-set
+// union such as ~int | ~string (a tree of `|` InfixExpressions over types
+// and `~` PrefixExpressions).
+type InterfaceType struct {
+	Token   lexer.Token // The token corresponding to `interface`
+	Methods *FieldList  // The methods and embedded elements
+}
+
+// Marks this node as an Expression (required by the Expression interface)
+func (it *InterfaceType) expressionNode() {}
+
+// Returns the literal value of the token as it appeared in the source code
+func (it *InterfaceType) TokenLiteral() string {
+	return it.Token.Literal
+}
+
+// Returns a string representation of the interface type
+func (it *InterfaceType) String() string {
+	return "interface" + it.Methods.braced()
+}
+
+// braced renders the fields of a struct or the elements of an interface
+// inside braces, writing methods as Name(params) results.
+func (fl *FieldList) braced() string {
+	if fl == nil || len(fl.List) == 0 {
+		return "{}"
+	}
+	parts := make([]string, len(fl.List))
+	for i, f := range fl.List {
+		if ft, ok := f.Type.(*FuncType); ok && len(f.Names) == 1 {
+			parts[i] = f.Names[0].String() + ft.signature()
+		} else {
+			parts[i] = f.String()
+		}
+	}
+	return "{ " + strings.Join(parts, "; ") + " }"
+}
@@ -0,0 +1,360 @@
+// Copyright 2009 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE-GO file.
+//
+// Adapted from go/ast.
+
+package ast
+
+import "fmt"
+
+// A Visitor's Visit method is invoked for each node encountered by Walk.
+// If the result visitor w is not nil, Walk visits each of the children
+// of node with the visitor w, followed by a call of w.Visit(nil).
+type Visitor interface {
+	Visit(node Node) (w Visitor)
+}
+
+// Walk traverses an AST in depth-first order: it starts by calling
+// v.Visit(node); node must not be nil. If the visitor w returned by
+// v.Visit(node) is not nil, Walk is invoked recursively with visitor w for
+// each of the non-nil children of node, followed by a call of w.Visit(nil).
+//
+// Children are visited in source order. File.Imports is not walked, as the
+// import specs are already reached through File.Decls, and neither is
+// File.Comments. Walk panics on a node type it does not know, so a node type
+// added to the package without a case here is caught by the first traversal
+// that meets it.
+func Walk(v Visitor, node Node) {
+	if v = v.Visit(node); v == nil {
+		return
+	}
+
+	switch n := node.(type) {
+	// Comments
+	case *Comment:
+		// nothing to do
+
+	case *CommentGroup:
+		for _, c := range n.List {
+			Walk(v, c)
+		}
+
+	// Expressions
+	case *Identifier, *IntegerLiteral, *FloatLiteral, *StringLiteral, *RuneLiteral:
+		// nothing to do
+
+	case *PrefixExpression:
+		walkExpr(v, n.Right)
+
+	case *InfixExpression:
+		walkExpr(v, n.Left)
+		walkExpr(v, n.Right)
+
+	case *ParenExpr:
+		walkExpr(v, n.X)
+
+	case *TypeAssertExpr:
+		walkExpr(v, n.X)
+		walkExpr(v, n.Type)
+
+	case *FuncLit:
+		if n.Type != nil {
+			Walk(v, n.Type)
+		}
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+
+	case *CallExpr:
+		walkExpr(v, n.Fun)
+		walkExprList(v, n.Args)
+
+	case *IndexExpr:
+		walkExpr(v, n.X)
+		walkExpr(v, n.Index)
+
+	case *IndexListExpr:
+		walkExpr(v, n.X)
+		walkExprList(v, n.Indices)
+
+	case *SelectorExpr:
+		walkExpr(v, n.X)
+		walkIdent(v, n.Sel)
+
+	case *SliceExpr:
+		walkExpr(v, n.X)
+		walkExpr(v, n.Low)
+		walkExpr(v, n.High)
+		walkExpr(v, n.Max)
+
+	case *CompositeLit:
+		walkExpr(v, n.Type)
+		walkExprList(v, n.Elts)
+
+	case *KeyValueExpr:
+		walkExpr(v, n.Key)
+		walkExpr(v, n.Value)
+
+	// Types
+	case *Field:
+		walkComments(v, n.Doc)
+		walkIdentList(v, n.Names)
+		walkExpr(v, n.Type)
+		if n.Tag != nil {
+			Walk(v, n.Tag)
+		}
+		walkComments(v, n.Comment)
+
+	case *FieldList:
+		for _, f := range n.List {
+			Walk(v, f)
+		}
+
+	case *FuncType:
+		walkFields(v, n.Params)
+		walkFields(v, n.Results)
+
+	case *Ellipsis:
+		walkExpr(v, n.Elt)
+
+	case *StarExpr:
+		walkExpr(v, n.X)
+
+	case *ArrayType:
+		walkExpr(v, n.Len)
+		walkExpr(v, n.Elt)
+
+	case *MapType:
+		walkExpr(v, n.Key)
+		walkExpr(v, n.Value)
+
+	case *ChanType:
+		walkExpr(v, n.Value)
+
+	case *StructType:
+		walkFields(v, n.Fields)
+
+	case *InterfaceType:
+		walkFields(v, n.Methods)
+
+	// Statements
+	case *Program:
+		walkStmtList(v, n.Statements)
+
+	case *BlockStatement:
+		walkStmtList(v, n.Statements)
+
+	case *ExprStmt:
+		walkExpr(v, n.X)
+
+	case *AssignStmt:
+		walkExprList(v, n.Lhs)
+		walkExprList(v, n.Rhs)
+
+	case *BranchStmt:
+		walkIdent(v, n.Label)
+
+	case *LabeledStmt:
+		walkIdent(v, n.Label)
+		walkStmt(v, n.Stmt)
+
+	case *CaseClause:
+		walkExprList(v, n.List)
+		walkStmtList(v, n.Body)
+
+	case *SwitchStmt:
+		walkStmt(v, n.Init)
+		walkExpr(v, n.Tag)
+		for _, c := range n.Cases {
+			Walk(v, c)
+		}
+
+	case *TypeSwitchStmt:
+		walkStmt(v, n.Init)
+		walkIdent(v, n.Binding)
+		walkExpr(v, n.X)
+		for _, c := range n.Cases {
+			Walk(v, c)
+		}
+
+	case *ReturnStmt:
+		walkExprList(v, n.Results)
+
+	case *DeclStmt:
+		if n.Decl != nil {
+			Walk(v, n.Decl)
+		}
+
+	case *IncDecStmt:
+		walkExpr(v, n.X)
+
+	case *IfStmt:
+		walkStmt(v, n.Init)
+		walkExpr(v, n.Cond)
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+		walkStmt(v, n.Else)
+
+	case *ForStmt:
+		walkStmt(v, n.Init)
+		walkExpr(v, n.Cond)
+		walkStmt(v, n.Post)
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+
+	case *RangeStmt:
+		walkExpr(v, n.Key)
+		walkExpr(v, n.Value)
+		walkExpr(v, n.X)
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+
+	case *GoStmt:
+		if n.Call != nil {
+			Walk(v, n.Call)
+		}
+
+	case *DeferStmt:
+		if n.Call != nil {
+			Walk(v, n.Call)
+		}
+
+	case *SendStmt:
+		walkExpr(v, n.Chan)
+		walkExpr(v, n.Value)
+
+	case *CommClause:
+		walkStmt(v, n.Comm)
+		walkStmtList(v, n.Body)
+
+	case *SelectStmt:
+		for _, c := range n.Cases {
+			Walk(v, c)
+		}
+
+	// Declarations
+	case *File:
+		walkComments(v, n.Doc)
+		walkIdent(v, n.Name)
+		for _, d := range n.Decls {
+			Walk(v, d)
+		}
+
+	case *GenDecl:
+		walkComments(v, n.Doc)
+		for _, s := range n.Specs {
+			Walk(v, s)
+		}
+
+	case *ImportSpec:
+		walkComments(v, n.Doc)
+		walkIdent(v, n.Name)
+		if n.Path != nil {
+			Walk(v, n.Path)
+		}
+		walkComments(v, n.Comment)
+
+	case *ValueSpec:
+		walkComments(v, n.Doc)
+		walkIdentList(v, n.Names)
+		walkExpr(v, n.Type)
+		walkExprList(v, n.Values)
+		walkComments(v, n.Comment)
+
+	case *TypeSpec:
+		walkComments(v, n.Doc)
+		walkIdent(v, n.Name)
+		walkFields(v, n.TypeParams)
+		walkExpr(v, n.Type)
+		walkComments(v, n.Comment)
+
+	case *FuncDecl:
+		walkComments(v, n.Doc)
+		walkFields(v, n.Recv)
+		walkIdent(v, n.Name)
+		walkFields(v, n.TypeParams)
+		if n.Type != nil {
+			Walk(v, n.Type)
+		}
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+
+	default:
+		panic(fmt.Sprintf("ast.Walk: unexpected node type %T", n))
+	}
+
+	v.Visit(nil)
+}
+
+// Helpers that skip the optional children, so that the cases of Walk read
+// as the list of a node's fields.
+
+func walkExpr(v Visitor, x Expression) {
+	if x != nil {
+		Walk(v, x)
+	}
+}
+
+func walkStmt(v Visitor, s Statement) {
+	if s != nil {
+		Walk(v, s)
+	}
+}
+
+func walkIdent(v Visitor, id *Identifier) {
+	if id != nil {
+		Walk(v, id)
+	}
+}
+
+func walkFields(v Visitor, fl *FieldList) {
+	if fl != nil {
+		Walk(v, fl)
+	}
+}
+
+func walkComments(v Visitor, g *CommentGroup) {
+	if g != nil {
+		Walk(v, g)
+	}
+}
+
+func walkExprList(v Visitor, list []Expression) {
+	for _, x := range list {
+		walkExpr(v, x)
+	}
+}
+
+func walkStmtList(v Visitor, list []Statement) {
+	for _, s := range list {
+		walkStmt(v, s)
+	}
+}
+
+func walkIdentList(v Visitor, list []*Identifier) {
+	for _, id := range list {
+		walkIdent(v, id)
+	}
+}
+
+type inspector func(Node) bool
+
+func (f inspector) Visit(node Node) Visitor {
+	if f(node) {
+		return f
+	}
+	return nil
+}
+
+// Inspect traverses an AST in depth-first order: it starts by calling
+// f(node); node must not be nil. If f returns true, Inspect invokes f
+// recursively for each of the non-nil children of node, followed by a
+// call of f(nil).
+func Inspect(node Node, f func(Node) bool) {
+	Walk(inspector(f), node)
+}
@@ -0,0 +1,203 @@
+package ast
+
+import (
+	goast "go/ast"
+	"go/parser"
+	"go/token"
+	"maps"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// notWalked lists the node-valued fields Walk skips on purpose.
+var notWalked = map[string]bool{
+	"File.Imports":     true, // reached through File.Decls
+	"File.Comments":    true,
+	"FuncLit.FreeVars": true, // references to declaring identifiers
+}
+
+// sortedNodeTypes returns the node types of the package, sorted by name.
+// The set of types is found by parsing the package's source, so that a node
+// type missing from nodeTypes is reported rather than left untested.
+func sortedNodeTypes(t *testing.T) []reflect.Type {
+	t.Helper()
+	names := sourceNodeTypes(t)
+	var types []reflect.Type
+	for _, name := range names {
+		nt, ok := nodeTypes[name]
+		if !ok {
+			t.Errorf("node type %s is missing from nodeTypes", name)
+			continue
+		}
+		types = append(types, nt)
+	}
+	for name := range nodeTypes {
+		if !slices.Contains(names, name) {
+			t.Errorf("nodeTypes has %s, which is not a node type", name)
+		}
+	}
+	return types
+}
+
+// sourceNodeTypes parses the package's source and returns the names of the
+// exported struct types with all the methods of the Node interface, sorted.
+func sourceNodeTypes(t *testing.T) []string {
+	t.Helper()
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fset := token.NewFileSet()
+	structs := make(map[string]bool)
+	methods := make(map[string]map[string]bool) // receiver type name -> method names
+	var nodeMethods []string
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, name, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, decl := range f.Decls {
+			switch d := decl.(type) {
+			case *goast.GenDecl:
+				for _, spec := range d.Specs {
+					ts, ok := spec.(*goast.TypeSpec)
+					if !ok {
+						continue
+					}
+					switch typ := ts.Type.(type) {
+					case *goast.StructType:
+						structs[ts.Name.Name] = ts.Name.IsExported()
+					case *goast.InterfaceType:
+						if ts.Name.Name == "Node" {
+							for _, m := range typ.Methods.List {
+								for _, id := range m.Names {
+									nodeMethods = append(nodeMethods, id.Name)
+								}
+							}
+						}
+					}
+				}
+			case *goast.FuncDecl:
+				if d.Recv == nil || len(d.Recv.List) == 0 {
+					continue
+				}
+				recv := d.Recv.List[0].Type
+				if star, ok := recv.(*goast.StarExpr); ok {
+					recv = star.X
+				}
+				if id, ok := recv.(*goast.Ident); ok {
+					if methods[id.Name] == nil {
+						methods[id.Name] = make(map[string]bool)
+					}
+					methods[id.Name][d.Name.Name] = true
+				}
+			}
+		}
+	}
+	if len(nodeMethods) == 0 {
+		t.Fatal("no Node interface in the package source")
+	}
+
+	var names []string
+	for name, exported := range structs {
+		if exported && !slices.ContainsFunc(nodeMethods, func(m string) bool { return !methods[name][m] }) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// newChild returns a new node assignable to a field of type t, or nil if t
+// does not hold nodes.
+func newChild(t reflect.Type) reflect.Value {
+	if t.Kind() == reflect.Pointer && t.Implements(nodeType) {
+		return reflect.New(t.Elem())
+	}
+	if t.Kind() == reflect.Interface && t.Implements(nodeType) {
+		for _, name := range slices.Sorted(maps.Keys(nodeTypes)) {
+			if nt := nodeTypes[name]; reflect.PointerTo(nt).Implements(t) {
+				return reflect.New(nt)
+			}
+		}
+	}
+	return reflect.Value{}
+}
+
+// fillChildren sets every node-valued field of the struct v, and every
+// slice of nodes to one element, to a new node, and returns the new nodes
+// by field name.
+func fillChildren(v reflect.Value) map[Node]string {
+	children := make(map[Node]string)
+	for i := 0; i < v.NumField(); i++ {
+		f, sf := v.Field(i), v.Type().Field(i)
+		name := v.Type().Name() + "." + sf.Name
+		if notWalked[name] {
+			continue
+		}
+		switch {
+		case newChild(sf.Type).IsValid():
+			child := newChild(sf.Type)
+			f.Set(child)
+			children[child.Interface().(Node)] = name
+		case sf.Type.Kind() == reflect.Slice && newChild(sf.Type.Elem()).IsValid():
+			child := newChild(sf.Type.Elem())
+			f.Set(reflect.Append(f, child))
+			children[child.Interface().(Node)] = name
+		}
+	}
+	return children
+}
+
+// childVisitor records the children of the node it is first called with.
+type childVisitor struct {
+	root    bool
+	visited map[Node]int
+}
+
+func (v *childVisitor) Visit(node Node) Visitor {
+	if v.root {
+		return &childVisitor{visited: v.visited}
+	}
+	if node != nil {
+		v.visited[node]++
+	}
+	return nil
+}
+
+// TestWalkCoversAllNodes checks that Walk knows every node type, and visits
+// every node-valued field of each exactly once.
+func TestWalkCoversAllNodes(t *testing.T) {
+	for _, nt := range sortedNodeTypes(t) {
+		t.Run(nt.Name(), func(t *testing.T) {
+			v := reflect.New(nt)
+			children := fillChildren(v.Elem())
+			visited := make(map[Node]int)
+			func() {
+				defer func() {
+					if err := recover(); err != nil {
+						t.Fatalf("Walk panics: %v", err)
+					}
+				}()
+				Walk(&childVisitor{root: true, visited: visited}, v.Interface().(Node))
+			}()
+			for child, field := range children {
+				if n := visited[child]; n != 1 {
+					t.Errorf("%s visited %d times, want 1", field, n)
+				}
+			}
+			for child := range visited {
+				if _, ok := children[child]; !ok {
+					t.Errorf("visited %T, which is not a child", child)
+				}
+			}
+		})
+	}
+}
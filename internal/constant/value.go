@@ -0,0 +1,581 @@
+// Copyright 2013 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE-GO file.
+//
+// Adapted from go/constant.
+
+// Package constant implements the exact values of Go constants: booleans,
+// strings, and integer, floating-point and complex numbers of arbitrary
+// precision. Integers are held as big.Int and floating-point numbers as
+// big.Rat, so that arithmetic on untyped constants loses nothing; a value is
+// only rounded when it is converted to a sized type, which is the business
+// of the type checker.
+//
+// The operators are those of the syntax tree, spelled as in
+// ast.InfixExpression and ast.PrefixExpression.
+package constant
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Kind is the kind of a Value.
+type Kind int
+
+const (
+	Unknown Kind = iota // the value of an invalid or unrepresentable constant
+	Bool
+	String
+	Int
+	Float
+	Complex
+)
+
+var kindString = [...]string{
+	Unknown: "Unknown",
+	Bool:    "Bool",
+	String:  "String",
+	Int:     "Int",
+	Float:   "Float",
+	Complex: "Complex",
+}
+
+func (k Kind) String() string {
+	if 0 <= k && int(k) < len(kindString) {
+		return kindString[k]
+	}
+	return "Kind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// A Value is the exact value of a constant. Values are immutable.
+type Value interface {
+	// Kind returns the kind of the value.
+	Kind() Kind
+
+	// String returns a short form of the value, for error messages:
+	// floating-point numbers are rounded to 6 digits and long strings
+	// are cut short.
+	String() string
+
+	// ExactString returns the exact value; a floating-point number that
+	// is not an integer is written as a fraction p/q.
+	ExactString() string
+
+	implementsValue()
+}
+
+type (
+	unknownVal struct{}
+	boolVal    bool
+	stringVal  string
+	intVal     struct{ val *big.Int }
+	floatVal   struct{ val *big.Rat }
+	complexVal struct{ re, im *big.Rat }
+)
+
+func (unknownVal) Kind() Kind { return Unknown }
+func (boolVal) Kind() Kind    { return Bool }
+func (stringVal) Kind() Kind  { return String }
+func (intVal) Kind() Kind     { return Int }
+func (floatVal) Kind() Kind   { return Float }
+func (complexVal) Kind() Kind { return Complex }
+
+func (unknownVal) String() string { return "unknown" }
+func (x boolVal) String() string  { return strconv.FormatBool(bool(x)) }
+func (x intVal) String() string   { return x.val.String() }
+func (x floatVal) String() string { return ratString(x.val) }
+
+// maxLen is the length, in runes, beyond which String cuts a string value
+// short.
+const maxLen = 72
+
+func (x stringVal) String() string {
+	s := strconv.Quote(string(x))
+	if utf8.RuneCountInString(s) > maxLen {
+		// keep the first maxLen-3 runes, dropping the closing quote
+		i := 0
+		for n := 0; n < maxLen-3; n++ {
+			_, size := utf8.DecodeRuneInString(s[i:])
+			i += size
+		}
+		s = s[:i] + "..."
+	}
+	return s
+}
+
+func (x complexVal) String() string {
+	return fmt.Sprintf("(%s + %si)", ratString(x.re), ratString(x.im))
+}
+
+// ratString formats x as a float with 6 significant digits, or as many as
+// it takes to show that x is not an integer.
+func ratString(x *big.Rat) string {
+	if f, _ := x.Float64(); !math.IsInf(f, 0) && (f == 0) == (x.Sign() == 0) {
+		s := strconv.FormatFloat(f, 'g', 6, 64)
+		if !x.IsInt() && !strings.ContainsAny(s, ".e") {
+			s = strconv.FormatFloat(f, 'g', -1, 64)
+		}
+		return s
+	}
+	// beyond the range of float64
+	return new(big.Float).SetPrec(512).SetRat(x).Text('g', 6)
+}
+
+func (unknownVal) ExactString() string { return "unknown" }
+func (x boolVal) ExactString() string  { return x.String() }
+func (x stringVal) ExactString() string {
+	return strconv.Quote(string(x))
+}
+func (x intVal) ExactString() string   { return x.val.String() }
+func (x floatVal) ExactString() string { return x.val.RatString() }
+func (x complexVal) ExactString() string {
+	return fmt.Sprintf("(%s + %si)", x.re.RatString(), x.im.RatString())
+}
+
+func (unknownVal) implementsValue() {}
+func (boolVal) implementsValue()    {}
+func (stringVal) implementsValue()  {}
+func (intVal) implementsValue()     {}
+func (floatVal) implementsValue()   {}
+func (complexVal) implementsValue() {}
+
+// ----------------------------------------------------------------------------
+// Constructors
+
+// MakeUnknown returns the Unknown value.
+func MakeUnknown() Value { return unknownVal{} }
+
+// MakeBool returns the Bool value for b.
+func MakeBool(b bool) Value { return boolVal(b) }
+
+// MakeString returns the String value for s.
+func MakeString(s string) Value { return stringVal(s) }
+
+// MakeInt64 returns the Int value for x.
+func MakeInt64(x int64) Value { return intVal{big.NewInt(x)} }
+
+// MakeUint64 returns the Int value for x.
+func MakeUint64(x uint64) Value { return intVal{new(big.Int).SetUint64(x)} }
+
+// MakeInt returns the Int value for x, which it copies.
+func MakeInt(x *big.Int) Value { return intVal{new(big.Int).Set(x)} }
+
+// MakeRat returns the Float value for x, which it copies. The value is a
+// Float even if x is an integer.
+func MakeRat(x *big.Rat) Value { return floatVal{new(big.Rat).Set(x)} }
+
+// MakeFloat64 returns the Float value for x, or Unknown if x is an infinity
+// or NaN.
+func MakeFloat64(x float64) Value {
+	if math.IsInf(x, 0) || math.IsNaN(x) {
+		return unknownVal{}
+	}
+	return floatVal{new(big.Rat).SetFloat64(x)}
+}
+
+// MakeComplex returns the Complex value re + im*i, for Int or Float values
+// re and im, or Unknown if either is not one.
+func MakeComplex(re, im Value) Value {
+	r, ok1 := toRat(re)
+	i, ok2 := toRat(im)
+	if !ok1 || !ok2 {
+		return unknownVal{}
+	}
+	return complexVal{r, i}
+}
+
+// toRat returns the value of an Int or Float x as a new big.Rat.
+func toRat(x Value) (*big.Rat, bool) {
+	switch x := x.(type) {
+	case intVal:
+		return new(big.Rat).SetInt(x.val), true
+	case floatVal:
+		return new(big.Rat).Set(x.val), true
+	}
+	return nil, false
+}
+
+// ----------------------------------------------------------------------------
+// Accessors
+
+// BoolVal returns the Go boolean value of x, which must be a Bool or
+// Unknown; Unknown gives false.
+func BoolVal(x Value) bool {
+	switch x := x.(type) {
+	case boolVal:
+		return bool(x)
+	case unknownVal:
+		return false
+	}
+	panic(fmt.Sprintf("%v is not a Bool", x))
+}
+
+// StringVal returns the Go string value of x, which must be a String or
+// Unknown; Unknown gives "".
+func StringVal(x Value) string {
+	switch x := x.(type) {
+	case stringVal:
+		return string(x)
+	case unknownVal:
+		return ""
+	}
+	panic(fmt.Sprintf("%v is not a String", x))
+}
+
+// Int64Val returns the Go int64 value of the Int x and whether it is
+// exact; if it is not, the result is undefined.
+func Int64Val(x Value) (int64, bool) {
+	if x, ok := x.(intVal); ok {
+		return x.val.Int64(), x.val.IsInt64()
+	}
+	return 0, false
+}
+
+// Uint64Val returns the Go uint64 value of the Int x and whether it is
+// exact; if it is not, the result is undefined.
+func Uint64Val(x Value) (uint64, bool) {
+	if x, ok := x.(intVal); ok {
+		return x.val.Uint64(), x.val.IsUint64()
+	}
+	return 0, false
+}
+
+// Float64Val returns the nearest Go float64 value of the Int or Float x and
+// whether it is exact. A value too large for a float64 gives an infinity.
+func Float64Val(x Value) (float64, bool) {
+	r, ok := toRat(x)
+	if !ok {
+		return 0, false
+	}
+	return r.Float64()
+}
+
+// Float32Val is like Float64Val, for float32.
+func Float32Val(x Value) (float32, bool) {
+	r, ok := toRat(x)
+	if !ok {
+		return 0, false
+	}
+	return r.Float32()
+}
+
+// Sign returns -1, 0 or 1 as x is negative, zero or positive. A Complex
+// value has the sign of its real part, or of its imaginary part if the real
+// part is zero. Unknown values have sign 1, so that they are not taken for
+// a zero divisor.
+func Sign(x Value) int {
+	switch x := x.(type) {
+	case intVal:
+		return x.val.Sign()
+	case floatVal:
+		return x.val.Sign()
+	case complexVal:
+		if s := x.re.Sign(); s != 0 {
+			return s
+		}
+		return x.im.Sign()
+	case unknownVal:
+		return 1
+	}
+	panic(fmt.Sprintf("%v is not numeric", x))
+}
+
+// BitLen returns the number of bits needed for the absolute value of the
+// Int x, or 0 for other values.
+func BitLen(x Value) int {
+	if x, ok := x.(intVal); ok {
+		return x.val.BitLen()
+	}
+	return 0
+}
+
+// Real returns the real part of the numeric x as an Int or Float, or
+// Unknown if x is not numeric.
+func Real(x Value) Value {
+	switch x := x.(type) {
+	case intVal, floatVal:
+		return x
+	case complexVal:
+		return floatVal{x.re}
+	}
+	return unknownVal{}
+}
+
+// Imag returns the imaginary part of the numeric x as an Int or Float, or
+// Unknown if x is not numeric.
+func Imag(x Value) Value {
+	switch x := x.(type) {
+	case intVal:
+		return intVal{new(big.Int)}
+	case floatVal:
+		return floatVal{new(big.Rat)}
+	case complexVal:
+		return floatVal{x.im}
+	}
+	return unknownVal{}
+}
+
+// ----------------------------------------------------------------------------
+// Conversions
+
+// ToInt returns x as an Int if it is numeric and an integer, and Unknown
+// otherwise.
+func ToInt(x Value) Value {
+	switch x := x.(type) {
+	case intVal:
+		return x
+	case floatVal:
+		if x.val.IsInt() {
+			return intVal{new(big.Int).Set(x.val.Num())}
+		}
+	case complexVal:
+		if x.im.Sign() == 0 {
+			return ToInt(floatVal{x.re})
+		}
+	}
+	return unknownVal{}
+}
+
+// ToFloat returns x as a Float if it is numeric with no imaginary part, and
+// Unknown otherwise.
+func ToFloat(x Value) Value {
+	switch x := x.(type) {
+	case intVal:
+		return floatVal{new(big.Rat).SetInt(x.val)}
+	case floatVal:
+		return x
+	case complexVal:
+		if x.im.Sign() == 0 {
+			return floatVal{x.re}
+		}
+	}
+	return unknownVal{}
+}
+
+// ToComplex returns x as a Complex if it is numeric, and Unknown otherwise.
+func ToComplex(x Value) Value {
+	switch x := x.(type) {
+	case intVal, floatVal:
+		re, _ := toRat(x)
+		return complexVal{re, new(big.Rat)}
+	case complexVal:
+		return x
+	}
+	return unknownVal{}
+}
+
+// ----------------------------------------------------------------------------
+// Operations
+
+// match converts the numeric values x and y to the larger of their kinds,
+// so that an Int meets a Float as a Float.
+func match(x, y Value) (Value, Value) {
+	switch kx, ky := x.Kind(), y.Kind(); {
+	case kx == ky || kx < Int || ky < Int:
+		return x, y
+	case kx == Complex || ky == Complex:
+		return ToComplex(x), ToComplex(y)
+	default:
+		return ToFloat(x), ToFloat(y)
+	}
+}
+
+// UnaryOp returns the result of the prefix operation op x, where op is "-"
+// or "+" for a numeric x and "!" for a Bool. An Unknown x gives Unknown.
+func UnaryOp(op string, x Value) Value {
+	switch x := x.(type) {
+	case unknownVal:
+		return x
+	case boolVal:
+		if op == "!" {
+			return !x
+		}
+	case intVal:
+		switch op {
+		case "+":
+			return x
+		case "-":
+			return intVal{new(big.Int).Neg(x.val)}
+		}
+	case floatVal:
+		switch op {
+		case "+":
+			return x
+		case "-":
+			return floatVal{new(big.Rat).Neg(x.val)}
+		}
+	case complexVal:
+		switch op {
+		case "+":
+			return x
+		case "-":
+			return complexVal{new(big.Rat).Neg(x.re), new(big.Rat).Neg(x.im)}
+		}
+	}
+	panic(fmt.Sprintf("invalid unary operation %s%v", op, x))
+}
+
+// BinaryOp returns the result of the infix operation x op y, where op is an
+// arithmetic operator: + - * / for numbers, & and | for integers, and + for
+// strings. The numbers may be of different kinds; an Int meets a Float as a
+// Float. The quotient of two Ints is truncated toward zero, as in integer
+// division. If either operand is Unknown, the result is Unknown.
+//
+// The caller must make sure that a divisor is not zero.
+func BinaryOp(x Value, op string, y Value) Value {
+	x, y = match(x, y)
+	switch x := x.(type) {
+	case unknownVal:
+		return x
+
+	case stringVal:
+		if y, ok := y.(stringVal); ok && op == "+" {
+			return x + y
+		}
+
+	case intVal:
+		y, ok := y.(intVal)
+		if !ok {
+			break
+		}
+		z := new(big.Int)
+		switch op {
+		case "+":
+			return intVal{z.Add(x.val, y.val)}
+		case "-":
+			return intVal{z.Sub(x.val, y.val)}
+		case "*":
+			return intVal{z.Mul(x.val, y.val)}
+		case "/":
+			return intVal{z.Quo(x.val, y.val)}
+		case "&":
+			return intVal{z.And(x.val, y.val)}
+		case "|":
+			return intVal{z.Or(x.val, y.val)}
+		}
+
+	case floatVal:
+		y, ok := y.(floatVal)
+		if !ok {
+			break
+		}
+		z := new(big.Rat)
+		switch op {
+		case "+":
+			return floatVal{z.Add(x.val, y.val)}
+		case "-":
+			return floatVal{z.Sub(x.val, y.val)}
+		case "*":
+			return floatVal{z.Mul(x.val, y.val)}
+		case "/":
+			return floatVal{z.Quo(x.val, y.val)}
+		}
+
+	case complexVal:
+		y, ok := y.(complexVal)
+		if !ok {
+			break
+		}
+		a, b, c, d := x.re, x.im, y.re, y.im
+		switch op {
+		case "+":
+			return complexVal{new(big.Rat).Add(a, c), new(big.Rat).Add(b, d)}
+		case "-":
+			return complexVal{new(big.Rat).Sub(a, c), new(big.Rat).Sub(b, d)}
+		case "*":
+			// (a+bi)(c+di) = (ac-bd) + (bc+ad)i
+			ac, bd := new(big.Rat).Mul(a, c), new(big.Rat).Mul(b, d)
+			bc, ad := new(big.Rat).Mul(b, c), new(big.Rat).Mul(a, d)
+			return complexVal{ac.Sub(ac, bd), bc.Add(bc, ad)}
+		case "/":
+			// (a+bi)/(c+di) = ((ac+bd) + (bc-ad)i) / (cc+dd)
+			ac, bd := new(big.Rat).Mul(a, c), new(big.Rat).Mul(b, d)
+			bc, ad := new(big.Rat).Mul(b, c), new(big.Rat).Mul(a, d)
+			cc, dd := new(big.Rat).Mul(c, c), new(big.Rat).Mul(d, d)
+			s := cc.Add(cc, dd)
+			re, im := ac.Add(ac, bd), bc.Sub(bc, ad)
+			return complexVal{re.Quo(re, s), im.Quo(im, s)}
+		}
+	}
+	if _, ok := y.(unknownVal); ok {
+		return y
+	}
+	panic(fmt.Sprintf("invalid binary operation %v %s %v", x, op, y))
+}
+
+// Compare returns the result of the comparison x op y, where op is one of
+// == != < <= > >=. Numbers of different kinds are compared by value;
+// booleans and complex numbers can only be compared for equality. A
+// comparison with an Unknown operand is false.
+func Compare(x Value, op string, y Value) bool {
+	x, y = match(x, y)
+	switch x := x.(type) {
+	case unknownVal:
+		return false
+
+	case boolVal:
+		if y, ok := y.(boolVal); ok {
+			switch op {
+			case "==":
+				return x == y
+			case "!=":
+				return x != y
+			}
+		}
+
+	case stringVal:
+		if y, ok := y.(stringVal); ok {
+			return cmpResult(op, strings.Compare(string(x), string(y)))
+		}
+
+	case intVal:
+		if y, ok := y.(intVal); ok {
+			return cmpResult(op, x.val.Cmp(y.val))
+		}
+
+	case floatVal:
+		if y, ok := y.(floatVal); ok {
+			return cmpResult(op, x.val.Cmp(y.val))
+		}
+
+	case complexVal:
+		if y, ok := y.(complexVal); ok {
+			eq := x.re.Cmp(y.re) == 0 && x.im.Cmp(y.im) == 0
+			switch op {
+			case "==":
+				return eq
+			case "!=":
+				return !eq
+			}
+		}
+	}
+	if _, ok := y.(unknownVal); ok {
+		return false
+	}
+	panic(fmt.Sprintf("invalid comparison %v %s %v", x, op, y))
+}
+
+// cmpResult returns the result of a comparison op whose operands compare as
+// c: -1, 0 or 1.
+func cmpResult(op string, c int) bool {
+	switch op {
+	case "==":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	}
+	panic("invalid comparison operator " + op)
+}
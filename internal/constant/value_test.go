@@ -0,0 +1,218 @@
+package constant
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+// rat returns the Float value a/b.
+func rat(a, b int64) Value { return MakeRat(big.NewRat(a, b)) }
+
+// huge returns the Int value 2**n.
+func huge(n uint) Value { return MakeInt(new(big.Int).Lsh(big.NewInt(1), n)) }
+
+func TestBinaryOp(t *testing.T) {
+	tests := []struct {
+		x     Value
+		op    string
+		y     Value
+		kind  Kind
+		exact string
+	}{
+		// same kinds
+		{MakeInt64(7), "+", MakeInt64(2), Int, "9"},
+		{MakeInt64(7), "-", MakeInt64(9), Int, "-2"},
+		{MakeInt64(7), "*", MakeInt64(-2), Int, "-14"},
+		{MakeInt64(7), "/", MakeInt64(2), Int, "3"},
+		{MakeInt64(-7), "/", MakeInt64(2), Int, "-3"}, // truncated toward zero
+		{MakeInt64(6), "&", MakeInt64(3), Int, "2"},
+		{MakeInt64(6), "|", MakeInt64(3), Int, "7"},
+		{rat(1, 3), "+", rat(1, 6), Float, "1/2"},
+		{rat(1, 3), "/", rat(2, 3), Float, "1/2"},
+		{MakeString("ab"), "+", MakeString("c"), String, `"abc"`},
+
+		// an Int meets a Float as a Float, and anything meets a Complex as a Complex
+		{MakeInt64(1), "/", rat(2, 1), Float, "1/2"},
+		{rat(1, 2), "+", MakeInt64(1), Float, "3/2"},
+		{MakeInt64(1), "+", MakeComplex(MakeInt64(0), MakeInt64(1)), Complex, "(1 + 1i)"},
+		{rat(1, 2), "*", MakeComplex(MakeInt64(0), MakeInt64(2)), Complex, "(0 + 1i)"},
+		{MakeComplex(MakeInt64(1), MakeInt64(1)), "*", MakeComplex(MakeInt64(1), MakeInt64(-1)), Complex, "(2 + 0i)"},
+		{MakeComplex(MakeInt64(1), MakeInt64(0)), "/", MakeComplex(MakeInt64(0), MakeInt64(1)), Complex, "(0 + -1i)"},
+
+		// no overflow: the values are exact
+		{huge(100), "*", huge(100), Int, huge(200).ExactString()},
+		{huge(64), "-", MakeInt64(1), Int, "18446744073709551615"},
+		{rat(1, 3), "*", MakeInt64(3), Float, "1"},
+
+		// Unknown operands
+		{MakeUnknown(), "+", MakeInt64(1), Unknown, "unknown"},
+		{MakeInt64(1), "/", MakeUnknown(), Unknown, "unknown"},
+		{MakeString("a"), "+", MakeUnknown(), Unknown, "unknown"},
+	}
+	for _, tt := range tests {
+		z := BinaryOp(tt.x, tt.op, tt.y)
+		if z.Kind() != tt.kind || z.ExactString() != tt.exact {
+			t.Errorf("%s %s %s = %s %s, want %s %s", tt.x, tt.op, tt.y, z.Kind(), z.ExactString(), tt.kind, tt.exact)
+		}
+	}
+}
+
+// TestDivisionByZero checks that Sign finds the zero divisors the caller
+// must reject, and that BinaryOp panics on them.
+func TestDivisionByZero(t *testing.T) {
+	zeros := []Value{MakeInt64(0), rat(0, 1), MakeComplex(MakeInt64(0), MakeInt64(0))}
+	for _, y := range zeros {
+		if Sign(y) != 0 {
+			t.Errorf("Sign(%s) = %d, want 0", y, Sign(y))
+		}
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("1 / %s %s did not panic", y.Kind(), y)
+				}
+			}()
+			BinaryOp(MakeInt64(1), "/", y)
+		}()
+	}
+	if s := Sign(MakeUnknown()); s == 0 {
+		t.Error("Sign(unknown) = 0, want an unknown divisor not taken for zero")
+	}
+	if s := Sign(MakeComplex(MakeInt64(0), MakeInt64(-1))); s != -1 {
+		t.Errorf("Sign(-1i) = %d, want -1", s)
+	}
+}
+
+func TestAccessorsExact(t *testing.T) {
+	if v, ok := Int64Val(MakeInt64(math.MinInt64)); !ok || v != math.MinInt64 {
+		t.Errorf("Int64Val(MinInt64) = %d, %v", v, ok)
+	}
+	if _, ok := Int64Val(huge(63)); ok {
+		t.Error("Int64Val(1<<63) is exact, want overflow")
+	}
+	if v, ok := Uint64Val(BinaryOp(huge(64), "-", MakeInt64(1))); !ok || v != math.MaxUint64 {
+		t.Errorf("Uint64Val(1<<64-1) = %d, %v", v, ok)
+	}
+	if _, ok := Uint64Val(MakeInt64(-1)); ok {
+		t.Error("Uint64Val(-1) is exact")
+	}
+	if _, ok := Int64Val(rat(1, 1)); ok {
+		t.Error("Int64Val of a Float is exact")
+	}
+	if f, _ := Float64Val(huge(2000)); !math.IsInf(f, 1) {
+		t.Errorf("Float64Val(1<<2000) = %g, want +Inf", f)
+	}
+	if f, ok := Float64Val(rat(1, 3)); ok || f != 1.0/3 {
+		t.Errorf("Float64Val(1/3) = %g, %v, want the nearest float64, inexact", f, ok)
+	}
+	if f, ok := Float32Val(rat(1, 4)); !ok || f != 0.25 {
+		t.Errorf("Float32Val(1/4) = %g, %v", f, ok)
+	}
+	if BitLen(huge(100)) != 101 {
+		t.Errorf("BitLen(1<<100) = %d, want 101", BitLen(huge(100)))
+	}
+	for _, x := range []float64{math.Inf(1), math.Inf(-1), math.NaN()} {
+		if v := MakeFloat64(x); v.Kind() != Unknown {
+			t.Errorf("MakeFloat64(%g) = %s %s, want unknown", x, v.Kind(), v)
+		}
+	}
+}
+
+func TestConversions(t *testing.T) {
+	tests := []struct {
+		conv  func(Value) Value
+		name  string
+		x     Value
+		exact string
+	}{
+		{ToInt, "ToInt", rat(4, 2), "2"},
+		{ToInt, "ToInt", rat(1, 2), "unknown"},
+		{ToInt, "ToInt", MakeComplex(MakeInt64(3), MakeInt64(0)), "3"},
+		{ToInt, "ToInt", MakeComplex(MakeInt64(3), MakeInt64(1)), "unknown"},
+		{ToInt, "ToInt", MakeString("3"), "unknown"},
+		{ToFloat, "ToFloat", MakeInt64(3), "3"},
+		{ToFloat, "ToFloat", MakeComplex(rat(1, 2), MakeInt64(0)), "1/2"},
+		{ToFloat, "ToFloat", MakeComplex(MakeInt64(0), MakeInt64(1)), "unknown"},
+		{ToComplex, "ToComplex", MakeInt64(3), "(3 + 0i)"},
+		{ToComplex, "ToComplex", MakeBool(true), "unknown"},
+		{Real, "Real", MakeComplex(MakeInt64(1), MakeInt64(2)), "1"},
+		{Imag, "Imag", MakeComplex(MakeInt64(1), MakeInt64(2)), "2"},
+		{Imag, "Imag", MakeInt64(5), "0"},
+	}
+	for _, tt := range tests {
+		if got := tt.conv(tt.x).ExactString(); got != tt.exact {
+			t.Errorf("%s(%s) = %s, want %s", tt.name, tt.x, got, tt.exact)
+		}
+	}
+}
+
+func TestUnaryOp(t *testing.T) {
+	tests := []struct {
+		op    string
+		x     Value
+		exact string
+	}{
+		{"-", MakeInt64(3), "-3"},
+		{"+", MakeInt64(3), "3"},
+		{"-", rat(1, 2), "-1/2"},
+		{"-", MakeComplex(MakeInt64(1), MakeInt64(-2)), "(-1 + 2i)"},
+		{"!", MakeBool(true), "false"},
+		{"-", MakeUnknown(), "unknown"},
+	}
+	for _, tt := range tests {
+		if got := UnaryOp(tt.op, tt.x).ExactString(); got != tt.exact {
+			t.Errorf("%s%s = %s, want %s", tt.op, tt.x, got, tt.exact)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		x    Value
+		op   string
+		y    Value
+		want bool
+	}{
+		{MakeString("a"), "<", MakeString("b"), true},
+		{MakeString("ab"), "<", MakeString("a"), false},
+		{MakeString("ab"), ">=", MakeString("a"), true},
+		{MakeString(""), "==", MakeString(""), true},
+		{MakeString("é"), ">", MakeString("z"), true}, // byte-wise
+		{MakeInt64(1), "<", rat(3, 2), true},
+		{rat(2, 1), "==", MakeInt64(2), true},
+		{huge(100), ">", huge(99), true},
+		{MakeInt64(1), "==", MakeComplex(MakeInt64(1), MakeInt64(0)), true},
+		{MakeComplex(MakeInt64(1), MakeInt64(1)), "!=", MakeComplex(MakeInt64(1), MakeInt64(0)), true},
+		{MakeBool(true), "!=", MakeBool(false), true},
+		{MakeUnknown(), "==", MakeInt64(1), false},
+		{MakeInt64(1), "!=", MakeUnknown(), false},
+	}
+	for _, tt := range tests {
+		if got := Compare(tt.x, tt.op, tt.y); got != tt.want {
+			t.Errorf("%s %s %s = %v, want %v", tt.x, tt.op, tt.y, got, tt.want)
+		}
+	}
+}
+
+// TestInvalidOperations checks that operations the type checker must
+// reject panic rather than give a value.
+func TestInvalidOperations(t *testing.T) {
+	ops := []func(){
+		func() { BinaryOp(MakeString("a"), "-", MakeString("b")) },
+		func() { BinaryOp(rat(1, 2), "&", rat(1, 2)) },
+		func() { BinaryOp(MakeInt64(1), "+", MakeString("a")) },
+		func() { Compare(MakeBool(true), "<", MakeBool(false)) },
+		func() { Compare(MakeComplex(MakeInt64(1), MakeInt64(0)), "<", MakeInt64(2)) },
+		func() { UnaryOp("!", MakeInt64(1)) },
+	}
+	for i, op := range ops {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("operation %d did not panic", i)
+				}
+			}()
+			op()
+		}()
+	}
+}
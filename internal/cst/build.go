@@ -0,0 +1,171 @@
+package cst
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+	parser "github.com/mohit-bhandari45/Compiler-GO.git/internal/parser"
+)
+
+// span is the byte range of a node reported by the parser.
+type span struct {
+	node       ast.Node
+	start, end int
+	order      int // report order; a node is reported after the nodes inside it
+}
+
+// Parse builds the concrete syntax tree of a source file. The tokens and
+// trivia come from the lexer in ScanComments mode; the nesting of the tokens
+// into nodes comes from the byte ranges the parser reports for the ast
+// nodes it builds. It also returns the parser's errors.
+func Parse(src string) (*Tree, []string) {
+	var spans []span
+	p := parser.New(lexer.NewWithMode(src, lexer.ScanComments))
+	p.SetNodeHook(func(node ast.Node, start, end int) {
+		spans = append(spans, span{node: node, start: start, end: end, order: len(spans)})
+	})
+	file := p.ParseFile()
+
+	tokens := greenTokens(src)
+	t := &Tree{nodes: map[*GreenNode]ast.Node{}, file: file}
+	t.root = t.nest(tokens, spans)
+	t.nodes[t.root] = file
+	return t, p.Errors()
+}
+
+// positioned is a green token with the offset of its text in the source.
+type positioned struct {
+	green  *GreenToken
+	offset int
+}
+
+// greenTokens lexes src and attaches the whitespace and comments between
+// the tokens as trivia. The last token is EOF, whose leading trivia is the
+// end of the file.
+func greenTokens(src string) []positioned {
+	l := lexer.NewWithMode(src, lexer.ScanComments)
+	comments := map[int]string{}
+	var tokens []positioned
+	var prev *GreenToken
+	end := 0
+	for {
+		tok := l.NextToken()
+		if tok.Type == lexer.COMMENT {
+			comments[tok.Offset] = tok.Literal
+			continue
+		}
+		text := tok.Literal
+		if tok.Implicit() || tok.Type == lexer.EOF {
+			text = ""
+		}
+		g := &GreenToken{Kind: tok.Type, Text: text}
+		trivia := splitTrivia(src[end:tok.Offset], end, comments)
+		if prev != nil {
+			n := 0
+			for n < len(trivia) && trivia[n].Kind != Newline {
+				n++
+			}
+			prev.Trailing, trivia = trivia[:n], trivia[n:]
+		}
+		if len(trivia) > 0 {
+			g.Leading = trivia
+		}
+		tokens = append(tokens, positioned{green: g, offset: tok.Offset})
+		if tok.Type == lexer.EOF {
+			return tokens
+		}
+		// a token never extends past the source, but a lexer bug must not
+		// make the slicing above panic
+		prev, end = g, min(tok.End(), len(src))
+	}
+}
+
+// splitTrivia splits the text between two tokens, which starts at offset
+// base, into whitespace, newlines and the comments found by the lexer.
+func splitTrivia(gap string, base int, comments map[int]string) []Trivia {
+	var list []Trivia
+	for i := 0; i < len(gap); {
+		if c, ok := comments[base+i]; ok {
+			list = append(list, Trivia{Kind: Comment, Text: c})
+			i += len(c)
+			continue
+		}
+		if gap[i] == '\n' {
+			list = append(list, Trivia{Kind: Newline, Text: "\n"})
+			i++
+			continue
+		}
+		j := i + 1
+		for j < len(gap) && gap[j] != '\n' && comments[base+j] == "" {
+			j++
+		}
+		list = append(list, Trivia{Kind: Whitespace, Text: gap[i:j]})
+		i = j
+	}
+	return list
+}
+
+// frame is a node under construction.
+type frame struct {
+	node     ast.Node
+	end      int
+	children []GreenElement
+}
+
+// nest distributes the tokens over the nodes described by spans. A token
+// belongs to the innermost span containing its offset; spans that would
+// cross an enclosing span are dropped.
+func (t *Tree) nest(tokens []positioned, spans []span) *GreenNode {
+	sort.SliceStable(spans, func(i, j int) bool {
+		a, b := spans[i], spans[j]
+		if a.start != b.start {
+			return a.start < b.start
+		}
+		if a.end != b.end {
+			return a.end > b.end
+		}
+		return a.order > b.order
+	})
+
+	root := &frame{end: -1}
+	stack := []*frame{root}
+	pop := func() {
+		f := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		g := NewNode(kindOf(f.node), f.children...)
+		t.nodes[g] = f.node
+		top := stack[len(stack)-1]
+		top.children = append(top.children, g)
+	}
+
+	seen := map[ast.Node]bool{}
+	next := 0
+	for _, tok := range tokens {
+		for len(stack) > 1 && tok.offset >= stack[len(stack)-1].end {
+			pop()
+		}
+		for ; next < len(spans) && spans[next].start <= tok.offset; next++ {
+			s := spans[next]
+			top := stack[len(stack)-1]
+			if seen[s.node] || s.end <= tok.offset || (top != root && s.end > top.end) {
+				continue
+			}
+			seen[s.node] = true
+			stack = append(stack, &frame{node: s.node, end: s.end})
+		}
+		top := stack[len(stack)-1]
+		top.children = append(top.children, tok.green)
+	}
+	for len(stack) > 1 {
+		pop()
+	}
+	return NewNode(File, root.children...)
+}
+
+// kindOf returns the kind of the node built for n: its ast type name.
+func kindOf(n ast.Node) Kind {
+	return Kind(strings.TrimPrefix(fmt.Sprintf("%T", n), "*ast."))
+}
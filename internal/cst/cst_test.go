@@ -0,0 +1,170 @@
+package cst
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+)
+
+var roundTripTests = []struct {
+	name, src string
+}{
+	{"empty file", ""},
+	{"package only", "package p"},
+	{"no final newline", "package p\n\nvar x = 1"},
+	{"blank lines and indentation", "\n\n  package p\n\n\n\tfunc f() {\n\t\treturn\n\t}\n"},
+	{"trailing whitespace", "package p   \n\nvar x = 1 \t\n"},
+	{"comments", "// doc\npackage p // line\n\n/* block\n   comment */\nfunc f( /* a */ x int) {} // end\n// last"},
+	{"crlf", "package p\r\n\r\nfunc f() {\r\n\tx := 1\r\n\t_ = x\r\n}\r\n"},
+	{"unterminated string", "package p\n\nvar s = \"abc\n"},
+	{"unterminated comment", "package p\n/* open"},
+	{"syntax error", "package p\n\nfunc f( {\n"},
+	{"non-ascii identifier", "package p\nvar π = 1\n"},
+	{"invalid utf-8", "package p\n\xb9\nvar x = 1\n"},
+	{"nul byte", "package p\n\x00var x = 1\n"},
+	{"nul byte at end", "package p\x00"},
+}
+
+// TestRoundTrip checks that a parsed tree prints back as its source byte for
+// byte, and that its tokens cover the source without gaps.
+func TestRoundTrip(t *testing.T) {
+	for _, tt := range roundTripTests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, _ := Parse(tt.src)
+			if got := tree.String(); got != tt.src {
+				t.Errorf("String() = %q, want %q", got, tt.src)
+			}
+			end := 0
+			for _, tok := range tree.Root().Tokens() {
+				if tok.Offset() != end {
+					t.Errorf("token %q at %d, want %d", tok.Text(), tok.Offset(), end)
+				}
+				if got, want := tok.Text(), tt.src[tok.Offset():tok.End()]; got != want {
+					t.Errorf("token text %q, want %q", got, want)
+				}
+				end = tok.End()
+			}
+			if end != len(tt.src) {
+				t.Errorf("tokens end at %d, want %d", end, len(tt.src))
+			}
+		})
+	}
+}
+
+// TestTrivia checks how the text between tokens is split: a token's trailing
+// trivia runs up to the end of its line, and the next token's leading trivia
+// starts with the line feed.
+func TestTrivia(t *testing.T) {
+	src := "package p // line\n\n/* doc */ var x\r\n"
+	tree, _ := Parse(src)
+	var tokens []*GreenToken
+	for _, tok := range tree.Root().Tokens() {
+		tokens = append(tokens, tok.Green())
+	}
+
+	want := []struct {
+		kind              lexer.TokenType
+		text              string
+		leading, trailing []Trivia
+	}{
+		{lexer.PACKAGE, "package", nil, []Trivia{{Whitespace, " "}}},
+		{lexer.IDENT, "p", nil, []Trivia{{Whitespace, " "}}},
+		{lexer.SEMICOLON, "", nil, []Trivia{{Comment, "// line"}}}, // implicit, before the comment
+		{lexer.VAR, "var", []Trivia{{Newline, "\n"}, {Newline, "\n"}, {Comment, "/* doc */"}, {Whitespace, " "}}, []Trivia{{Whitespace, " "}}},
+		{lexer.IDENT, "x", nil, []Trivia{{Whitespace, "\r"}}},
+		{lexer.SEMICOLON, "", nil, nil},
+		{lexer.EOF, "", []Trivia{{Newline, "\n"}}, nil},
+	}
+	if len(tokens) != len(want) {
+		for _, g := range tokens {
+			t.Logf("%s %q %q %q", g.Kind, g.Text, g.Leading, g.Trailing)
+		}
+		t.Fatalf("got %d tokens, want %d", len(tokens), len(want))
+	}
+	for i, w := range want {
+		g := tokens[i]
+		if g.Kind != w.kind || g.Text != w.text || !sameTrivia(g.Leading, w.leading) || !sameTrivia(g.Trailing, w.trailing) {
+			t.Errorf("token %d = %s %q %q %q, want %s %q %q %q", i, g.Kind, g.Text, g.Leading, g.Trailing, w.kind, w.text, w.leading, w.trailing)
+		}
+	}
+}
+
+// sameTrivia reports whether a and b hold the same trivia, treating nil and
+// empty lists alike.
+func sameTrivia(a, b []Trivia) bool {
+	return len(a) == 0 && len(b) == 0 || reflect.DeepEqual(a, b)
+}
+
+// TestReplace checks that an edit gives a new tree with the new text, whose
+// ast view is parsed again with its comments, and leaves the original tree
+// unchanged.
+func TestReplace(t *testing.T) {
+	src := "package p\n\n// answer\nvar x = 41 // not yet\n\nfunc f() {}\n"
+	tree, _ := Parse(src)
+
+	var lit *Token
+	for _, tok := range tree.Root().Tokens() {
+		if tok.Kind() == lexer.INT {
+			lit = tok
+		}
+	}
+	if lit == nil {
+		t.Fatal("no integer literal")
+	}
+	g := NewToken(lexer.INT, "42")
+	g.Leading, g.Trailing = lit.Green().Leading, lit.Green().Trailing
+	edited := lit.Replace(g)
+
+	want := strings.Replace(src, "41", "42", 1)
+	if got := edited.String(); got != want {
+		t.Errorf("edited String() = %q, want %q", got, want)
+	}
+	if got := tree.String(); got != src {
+		t.Errorf("original String() = %q, want %q", got, src)
+	}
+
+	// the declaration of f is outside the edited path, and shared
+	oldChildren, newChildren := tree.Root().Green().Children, edited.Root().Green().Children
+	if len(oldChildren) != len(newChildren) {
+		t.Fatalf("edited root has %d children, want %d", len(newChildren), len(oldChildren))
+	}
+	shared := 0
+	for i := range oldChildren {
+		if oldChildren[i] == newChildren[i] {
+			shared++
+		}
+	}
+	if shared != len(oldChildren)-1 {
+		t.Errorf("%d of %d root children shared, want all but the edited one", shared, len(oldChildren))
+	}
+
+	// the ast view of the edited tree is parsed from its text
+	file := edited.File()
+	var value *ast.IntegerLiteral
+	ast.Inspect(file, func(n ast.Node) bool {
+		if l, ok := n.(*ast.IntegerLiteral); ok {
+			value = l
+		}
+		return true
+	})
+	if value == nil || value.Int.Int64() != 42 {
+		t.Errorf("reparsed literal = %v, want 42", value)
+	}
+	if len(file.Decls) != 2 {
+		t.Errorf("reparsed file has %d declarations, want 2", len(file.Decls))
+	}
+	if got := tree.File().Decls[0]; got == file.Decls[0] {
+		t.Error("original and edited trees share an ast view")
+	}
+	if len(file.Comments) != 2 {
+		t.Errorf("reparsed file has %d comments, want 2", len(file.Comments))
+	}
+	for _, n := range edited.Root().Children() {
+		if n, ok := n.(*Node); ok && n.AST() != nil {
+			t.Errorf("edited %s node has the ast view %T, want none", n.Kind(), n.AST())
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package cst
+
+import (
+	"strings"
+
+	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+)
+
+// The CST is split into two layers, following the design of Roslyn and
+// rust-analyzer. Green nodes are immutable and know only their kind,
+// children and width, so unchanged subtrees can be shared between versions
+// of a tree after an edit. Red nodes (Node and Token in red.go) are created
+// on demand while navigating and add the parent and absolute offset.
+
+// Kind is the kind of a node: the name of the ast node type it was parsed
+// as, such as "FuncDecl" or "CallExpr".
+type Kind string
+
+// File is the kind of the root node of every tree.
+const File Kind = "File"
+
+// TriviaKind classifies the source text between tokens.
+type TriviaKind int
+
+const (
+	Whitespace TriviaKind = iota // spaces, tabs and carriage returns
+	Newline                      // a single line feed
+	Comment                      // a // or /* */ comment
+)
+
+// Trivia is a run of whitespace, a newline or a comment attached to a token.
+type Trivia struct {
+	Kind TriviaKind
+	Text string
+}
+
+// GreenElement is a *GreenNode or a *GreenToken.
+type GreenElement interface {
+	// Width returns the length of the element's text, including trivia.
+	Width() int
+	writeTo(out *strings.Builder)
+}
+
+// ------- Green tokens -------- //
+
+// GreenToken is a token together with the trivia around it. Leading trivia
+// is everything between the previous token's trailing trivia and the token;
+// trailing trivia runs up to, but not including, the next newline.
+type GreenToken struct {
+	Kind     lexer.TokenType
+	Text     string // the token as written; empty for implicit semicolons and EOF
+	Leading  []Trivia
+	Trailing []Trivia
+}
+
+// NewToken returns a green token of the given kind and text without trivia.
+func NewToken(kind lexer.TokenType, text string) *GreenToken {
+	return &GreenToken{Kind: kind, Text: text}
+}
+
+// Width returns the length of the token text including its trivia.
+func (t *GreenToken) Width() int {
+	return triviaWidth(t.Leading) + len(t.Text) + triviaWidth(t.Trailing)
+}
+
+func (t *GreenToken) writeTo(out *strings.Builder) {
+	for _, tr := range t.Leading {
+		out.WriteString(tr.Text)
+	}
+	out.WriteString(t.Text)
+	for _, tr := range t.Trailing {
+		out.WriteString(tr.Text)
+	}
+}
+
+func triviaWidth(list []Trivia) int {
+	n := 0
+	for _, tr := range list {
+		n += len(tr.Text)
+	}
+	return n
+}
+
+// ------- Green nodes -------- //
+
+// GreenNode is an immutable interior node of the tree.
+type GreenNode struct {
+	Kind     Kind
+	Children []GreenElement
+	width    int
+}
+
+// NewNode returns a green node of the given kind with the given children.
+// The children must not be modified afterwards.
+func NewNode(kind Kind, children ...GreenElement) *GreenNode {
+	n := &GreenNode{Kind: kind, Children: children}
+	for _, c := range children {
+		n.width += c.Width()
+	}
+	return n
+}
+
+// Width returns the length of the node text including all trivia.
+func (n *GreenNode) Width() int {
+	return n.width
+}
+
+func (n *GreenNode) writeTo(out *strings.Builder) {
+	for _, c := range n.Children {
+		c.writeTo(out)
+	}
+}
+
+// String returns the source text of the node, including all trivia.
+func (n *GreenNode) String() string {
+	var out strings.Builder
+	n.writeTo(&out)
+	return out.String()
+}
+
+// withChild returns a copy of n with the child at index i replaced.
+func (n *GreenNode) withChild(i int, child GreenElement) *GreenNode {
+	children := make([]GreenElement, len(n.Children))
+	copy(children, n.Children)
+	children[i] = child
+	return NewNode(n.Kind, children...)
+}
@@ -0,0 +1,204 @@
+package cst
+
+import (
+	"strings"
+
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+	parser "github.com/mohit-bhandari45/Compiler-GO.git/internal/parser"
+)
+
+// Tree is a concrete syntax tree for one source file.
+type Tree struct {
+	root  *GreenNode
+	nodes map[*GreenNode]ast.Node // the ast view of the green nodes built by Parse; nil after an edit
+	file  *ast.File               // the ast view of the whole tree; nil after an edit until File is called
+}
+
+// Root returns the red root node of the tree, of kind File.
+func (t *Tree) Root() *Node {
+	return &Node{tree: t, green: t.root}
+}
+
+// String returns the source text of the tree. For a tree returned by Parse
+// it is identical to the parsed source.
+func (t *Tree) String() string {
+	return t.root.String()
+}
+
+// File returns the ast view of the tree. After an edit the view is derived
+// again by parsing the tree's text, comments included.
+func (t *Tree) File() *ast.File {
+	if t.file == nil {
+		t.file = parser.New(lexer.NewWithMode(t.String(), lexer.ScanComments)).ParseFile()
+	}
+	return t.file
+}
+
+// Element is a *Node or a *Token.
+type Element interface {
+	// Parent returns the enclosing node; nil for the root.
+	Parent() *Node
+	// Offset returns the byte offset of the element's text, including leading trivia.
+	Offset() int
+	// End returns the byte offset just past the element's text, including trailing trivia.
+	End() int
+	// Text returns the element's text, including trivia.
+	Text() string
+}
+
+// ------- Red nodes -------- //
+
+// Node is a view of a green node at a position in a tree.
+type Node struct {
+	tree   *Tree
+	green  *GreenNode
+	parent *Node
+	index  int // position among the parent's children
+	offset int
+}
+
+// Kind returns the kind of the node.
+func (n *Node) Kind() Kind {
+	return n.green.Kind
+}
+
+// Green returns the green node underlying n.
+func (n *Node) Green() *GreenNode {
+	return n.green
+}
+
+// Parent returns the enclosing node; nil for the root.
+func (n *Node) Parent() *Node {
+	return n.parent
+}
+
+// Offset returns the byte offset of the node, including the leading trivia of its first token.
+func (n *Node) Offset() int {
+	return n.offset
+}
+
+// End returns the byte offset just past the node, including the trailing trivia of its last token.
+func (n *Node) End() int {
+	return n.offset + n.green.Width()
+}
+
+// Text returns the source text of the node, including trivia.
+func (n *Node) Text() string {
+	return n.green.String()
+}
+
+// AST returns the ast node this node was parsed as. In an edited tree only
+// the root has an ast view, the File of the edited tree; AST returns nil
+// for every other node, since the nodes of the original tree no longer
+// describe the edited text.
+func (n *Node) AST() ast.Node {
+	if n.parent == nil {
+		return n.tree.File()
+	}
+	return n.tree.nodes[n.green]
+}
+
+// Children returns the child nodes and tokens of n in source order.
+func (n *Node) Children() []Element {
+	children := make([]Element, len(n.green.Children))
+	offset := n.offset
+	for i, c := range n.green.Children {
+		switch c := c.(type) {
+		case *GreenNode:
+			children[i] = &Node{tree: n.tree, green: c, parent: n, index: i, offset: offset}
+		case *GreenToken:
+			children[i] = &Token{tree: n.tree, green: c, parent: n, index: i, offset: offset}
+		}
+		offset += c.Width()
+	}
+	return children
+}
+
+// Tokens returns all tokens under n in source order.
+func (n *Node) Tokens() []*Token {
+	var tokens []*Token
+	for _, c := range n.Children() {
+		switch c := c.(type) {
+		case *Node:
+			tokens = append(tokens, c.Tokens()...)
+		case *Token:
+			tokens = append(tokens, c)
+		}
+	}
+	return tokens
+}
+
+// Replace returns a new tree in which n is replaced by g. The original tree
+// is unchanged, and every subtree outside the path from n to the root is
+// shared with it.
+func (n *Node) Replace(g *GreenNode) *Tree {
+	return n.tree.replace(n.parent, n.index, g)
+}
+
+// ------- Red tokens -------- //
+
+// Token is a view of a green token at a position in a tree.
+type Token struct {
+	tree   *Tree
+	green  *GreenToken
+	parent *Node
+	index  int
+	offset int
+}
+
+// Kind returns the token type.
+func (t *Token) Kind() lexer.TokenType {
+	return t.green.Kind
+}
+
+// Green returns the green token underlying t.
+func (t *Token) Green() *GreenToken {
+	return t.green
+}
+
+// Parent returns the node containing the token.
+func (t *Token) Parent() *Node {
+	return t.parent
+}
+
+// Offset returns the byte offset of the token, including its leading trivia.
+func (t *Token) Offset() int {
+	return t.offset
+}
+
+// End returns the byte offset just past the token, including its trailing trivia.
+func (t *Token) End() int {
+	return t.offset + t.green.Width()
+}
+
+// Text returns the token text together with its trivia.
+func (t *Token) Text() string {
+	var out strings.Builder
+	t.green.writeTo(&out)
+	return out.String()
+}
+
+// TextOffset returns the byte offset of the token itself, after its leading trivia.
+func (t *Token) TextOffset() int {
+	return t.offset + triviaWidth(t.green.Leading)
+}
+
+// Replace returns a new tree in which t is replaced by g.
+func (t *Token) Replace(g *GreenToken) *Tree {
+	return t.tree.replace(t.parent, t.index, g)
+}
+
+// replace rebuilds the green nodes from parent up to the root with the
+// child at index replaced by g.
+func (t *Tree) replace(parent *Node, index int, g GreenElement) *Tree {
+	for parent != nil {
+		g = parent.green.withChild(index, g)
+		parent, index = parent.parent, parent.index
+	}
+	root, ok := g.(*GreenNode)
+	if !ok {
+		root = NewNode(File, g)
+	}
+	return &Tree{root: root}
+}
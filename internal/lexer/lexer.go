@@ -1,23 +1,58 @@
 package lexer
 
+import "strings"
+
+// Mode controls optional lexer behavior.
+type Mode uint
+
+const (
+	// ScanComments makes NextToken return comments as COMMENT tokens
+	// instead of skipping them.
+	ScanComments Mode = 1 << iota
+)
+
 // Lexer implementation
 type Lexer struct {
 	input        string
+	mode         Mode
 	position     int  // current char index
 	readPosition int  // next char index
 	ch           byte // current char under examination
+	line         int  // 1-based line of ch
+	base         int  // position of the first byte of input
+	insertSemi   bool // a newline before the next token becomes a semicolon
 }
 
 // New creates a new Lexer for the given input source.
 func New(input string) *Lexer {
-	l := &Lexer{input: input}
+	return NewWithMode(input, 0)
+}
+
+// NewWithMode creates a new Lexer for the given input source and mode.
+// Token positions start at 1, as if the input were the first file of a
+// FileSet.
+func NewWithMode(input string, mode Mode) *Lexer {
+	l := &Lexer{input: input, mode: mode, line: 1, base: 1}
 	l.readChar()
 	return l
 }
 
+// NewInFile creates a new Lexer for the source of file, which must have been
+// added to a FileSet with the same source. Token positions are positions in
+// that FileSet.
+func NewInFile(file *File, input string, mode Mode) *Lexer {
+	l := NewWithMode(input, mode)
+	l.base = file.Base()
+	return l
+}
+
 // readChar advances the lexer by one byte (stores into l.ch).
-// Uses 0 as EOF sentinel.
+// At the end of input l.ch is 0; since the input may hold NUL bytes, use
+// atEOF to tell the two apart.
 func (l *Lexer) readChar() {
+	if l.ch == '\n' {
+		l.line++
+	}
 	if l.readPosition >= len(l.input) {
 		l.ch = 0
 	} else {
@@ -27,6 +62,11 @@ func (l *Lexer) readChar() {
 	l.readPosition++
 }
 
+// atEOF reports whether the lexer is past the last byte of input.
+func (l *Lexer) atEOF() bool {
+	return l.position >= len(l.input)
+}
+
 func (l *Lexer) peekChar() byte {
 	if l.readPosition >= len(l.input) {
 		return 0
@@ -34,19 +74,39 @@ func (l *Lexer) peekChar() byte {
 	return l.input[l.readPosition]
 }
 
+// NextToken returns the next token in the input.
+// Like Go, it inserts a SEMICOLON (with literal "\n") when a line ends after
+// an identifier, a literal, one of the keywords break, continue, fallthrough
+// or return, `++`, `--`, or a closing ')', ']' or '}'.
+// In ScanComments mode a comment that ends such a line is returned after
+// the inserted semicolon, so it follows the statement it trails.
 func (l *Lexer) NextToken() Token {
-	var tok Token
-
-	l.skipWhitespace()
+	if l.skipTrivia() {
+		l.insertSemi = false
+		tok := Token{Type: SEMICOLON, Literal: "\n", Line: l.line, Offset: l.position, Pos: Pos(l.base + l.position)}
+		if l.ch == '\n' {
+			l.readChar()
+		}
+		return tok
+	}
 
-	// handle comments
-	if l.ch == '/' && l.peekChar() == '/' {
-		l.skipLineComment()
-		l.skipWhitespace()
+	line, offset := l.line, l.position
+	if l.ch == '/' && (l.peekChar() == '/' || l.peekChar() == '*') {
+		// only reached in ScanComments mode; comments leave insertSemi alone
+		return Token{Type: COMMENT, Literal: l.readComment(), Line: line, Offset: offset, Pos: Pos(l.base + offset)}
 	}
-	if l.ch == '/' && l.peekChar() == '*' {
-		l.skipBlockComment()
-		l.skipWhitespace()
+	tok := l.readToken()
+	tok.Line, tok.Offset, tok.Pos = line, offset, Pos(l.base+offset)
+	l.insertSemi = endsLine(tok.Type)
+	return tok
+}
+
+// readToken scans the token starting at the current char.
+func (l *Lexer) readToken() Token {
+	var tok Token
+	if l.atEOF() {
+		tok.Type = EOF
+		return tok
 	}
 
 	switch l.ch {
@@ -59,9 +119,25 @@ func (l *Lexer) NextToken() Token {
 			tok = newToken(ASSIGN, l.ch)
 		}
 	case '+':
-		tok = newToken(PLUS, l.ch)
+		if l.peekChar() == '+' {
+			l.readChar()
+			tok = Token{Type: INC, Literal: "++"}
+		} else {
+			tok = newToken(PLUS, l.ch)
+		}
 	case '-':
-		tok = newToken(MINUS, l.ch)
+		if l.peekChar() == '-' {
+			l.readChar()
+			tok = Token{Type: DEC, Literal: "--"}
+		} else {
+			tok = newToken(MINUS, l.ch)
+		}
+	case '&':
+		tok = newToken(AMPERSAND, l.ch)
+	case '|':
+		tok = newToken(PIPE, l.ch)
+	case '~':
+		tok = newToken(TILDE, l.ch)
 	case '!':
 		if l.peekChar() == '=' {
 			ch := l.ch
@@ -79,6 +155,10 @@ func (l *Lexer) NextToken() Token {
 			ch := l.ch
 			l.readChar()
 			tok = Token{Type: LTE, Literal: string(ch) + string(l.ch)}
+		} else if l.peekChar() == '-' {
+			ch := l.ch
+			l.readChar()
+			tok = Token{Type: ARROW, Literal: string(ch) + string(l.ch)}
 		} else {
 			tok = newToken(LT, l.ch)
 		}
@@ -95,7 +175,26 @@ func (l *Lexer) NextToken() Token {
 	case ';':
 		tok = newToken(SEMICOLON, l.ch)
 	case ':':
-		tok = newToken(COLON, l.ch)
+		if l.peekChar() == '=' {
+			ch := l.ch
+			l.readChar()
+			tok = Token{Type: DEFINE, Literal: string(ch) + string(l.ch)}
+		} else {
+			tok = newToken(COLON, l.ch)
+		}
+	case '.':
+		if isDigit(l.peekChar()) {
+			lit, typ := l.readNumber()
+			tok.Type = typ
+			tok.Literal = lit
+			return tok
+		} else if l.peekChar() == '.' && l.readPosition+1 < len(l.input) && l.input[l.readPosition+1] == '.' {
+			l.readChar()
+			l.readChar()
+			tok = Token{Type: ELLIPSIS, Literal: "..."}
+		} else {
+			tok = newToken(PERIOD, l.ch)
+		}
 	case '(':
 		tok = newToken(LPAREN, l.ch)
 	case ')':
@@ -113,9 +212,14 @@ func (l *Lexer) NextToken() Token {
 		tok.Type = STRING
 		tok.Literal = l.readString()
 		return tok
-	case 0:
-		tok.Literal = ""
-		tok.Type = EOF
+	case '`':
+		tok.Type = STRING
+		tok.Literal = l.readRawString()
+		return tok
+	case '\'':
+		tok.Type = CHAR
+		tok.Literal = l.readRune()
+		return tok
 	default:
 		if isLetter(l.ch) {
 			lit := l.readIdentifier()
@@ -128,7 +232,8 @@ func (l *Lexer) NextToken() Token {
 			tok.Literal = lit
 			return tok
 		} else {
-			tok = newToken(ILLEGAL, l.ch)
+			// the raw byte, which need not be valid UTF-8 on its own
+			tok = Token{Type: ILLEGAL, Literal: l.input[l.position:l.readPosition]}
 		}
 	}
 
@@ -140,6 +245,18 @@ func newToken(tt TokenType, ch byte) Token {
 	return Token{Type: tt, Literal: string(ch)}
 }
 
+// endsLine reports whether a newline directly after a token of type tt
+// terminates the statement.
+func endsLine(tt TokenType) bool {
+	switch tt {
+	case IDENT, INT, FLOAT, STRING, CHAR,
+		BREAK, CONTINUE, FALLTHROUGH, RETURN,
+		RPAREN, RBRACKET, RBRACE, INC, DEC:
+		return true
+	}
+	return false
+}
+
 func isLetter(ch byte) bool {
 	return (ch >= 'a' && ch <= 'z') ||
 		(ch >= 'A' && ch <= 'Z') ||
@@ -162,33 +279,53 @@ func (l *Lexer) readIdentifier() string {
 }
 
 // readNumber reads an integer or float and returns the literal and the token type.
+// It accepts everything that may belong to a Go number literal (prefixes such
+// as 0x, digit separators, exponents) without checking it; the parser reports
+// malformed literals when it computes their values.
 func (l *Lexer) readNumber() (string, TokenType) {
 	start := l.position
-	hasDot := false
+	typ := INT
+	exp := byte('e') // exponent letter: 'p' for hexadecimal numbers
 
-	for isDigit(l.ch) || (l.ch == '.' && !hasDot && isDigit(l.peekChar())) {
-		if l.ch == '.' {
-			hasDot = true
+	if l.ch == '0' && (l.peekChar() == 'x' || l.peekChar() == 'X') {
+		exp = 'p'
+	}
+	for isLetter(l.ch) || isDigit(l.ch) || l.ch == '.' {
+		switch lower(l.ch) {
+		case '.':
+			if l.peekChar() == '.' {
+				// 1...: the dot starts an ellipsis; can't follow a number anyway
+				return l.input[start:l.position], typ
+			}
+			typ = FLOAT
+		case exp:
+			typ = FLOAT
+			if p := l.peekChar(); p == '+' || p == '-' {
+				l.readChar()
+			}
 		}
 		l.readChar()
 	}
-	if hasDot {
-		return l.input[start:l.position], FLOAT
-	}
-	return l.input[start:l.position], INT
+	return l.input[start:l.position], typ
+}
+
+// lower returns the lower-case form of an ASCII letter.
+func lower(ch byte) byte {
+	return ('a' - 'A') | ch
 }
 
 // readString reads a double-quoted string, supports basic escapes like \" and \\.
+// The literal keeps its quotes, so interpreted and raw strings stay distinguishable.
 // It advances the lexer to the char after the closing quote.
 func (l *Lexer) readString() string {
 	// current l.ch == '"'
+	start := l.position
 	// consume opening quote
 	l.readChar()
-	start := l.position
 
-	for l.ch != '"' && l.ch != 0 {
+	for l.ch != '"' && !l.atEOF() {
 		// handle escape by skipping next char (keeps literal as-is)
-		if l.ch == '\\' && l.peekChar() != 0 {
+		if l.ch == '\\' && l.readPosition < len(l.input) {
 			l.readChar() // skip backslash
 			l.readChar() // skip escaped char
 			continue
@@ -196,20 +333,115 @@ func (l *Lexer) readString() string {
 		l.readChar()
 	}
 
-	lit := l.input[start:l.position]
-
 	// consume closing quote if present
 	if l.ch == '"' {
 		l.readChar()
 	}
 
-	return lit
+	return l.input[start:l.position]
 }
 
-func (l *Lexer) skipWhitespace() {
-	for l.ch == ' ' || l.ch == '\t' || l.ch == '\n' || l.ch == '\r' {
+// readRune reads a single-quoted rune literal, skipping escapes like \' and
+// \\. As with strings, the literal keeps its quotes and is not decoded; a
+// literal that runs into the end of the line stops there.
+func (l *Lexer) readRune() string {
+	// current l.ch == '\''
+	start := l.position
+	l.readChar()
+
+	for l.ch != '\'' && l.ch != '\n' && !l.atEOF() {
+		if l.ch == '\\' && l.readPosition < len(l.input) {
+			l.readChar() // skip backslash
+		}
+		l.readChar()
+	}
+
+	// consume closing quote if present
+	if l.ch == '\'' {
 		l.readChar()
 	}
+
+	return l.input[start:l.position]
+}
+
+// readRawString reads a back-quoted raw string, which may span lines and has
+// no escapes. The literal keeps its back quotes.
+func (l *Lexer) readRawString() string {
+	// current l.ch == '`'
+	start := l.position
+	l.readChar()
+	for l.ch != '`' && !l.atEOF() {
+		l.readChar()
+	}
+	if l.ch == '`' {
+		l.readChar()
+	}
+	return l.input[start:l.position]
+}
+
+// skipTrivia skips whitespace and comments. It reports whether a newline (or
+// the end of input) was reached while a semicolon is pending; the caller
+// consumes the newline and emits the semicolon instead. In ScanComments mode
+// it stops at the first comment.
+func (l *Lexer) skipTrivia() bool {
+	for {
+		switch {
+		case l.ch == '\n' && l.insertSemi:
+			return true
+		case l.ch == ' ' || l.ch == '\t' || l.ch == '\n' || l.ch == '\r':
+			l.readChar()
+		case l.mode&ScanComments != 0 && l.ch == '/' && (l.peekChar() == '/' || l.peekChar() == '*'):
+			return l.insertSemi && l.commentEndsLine()
+		case l.ch == '/' && l.peekChar() == '/':
+			// stops before the newline, so the check above still sees it
+			l.skipLineComment()
+		case l.ch == '/' && l.peekChar() == '*':
+			// a block comment spanning lines acts like a newline
+			if l.skipBlockComment() && l.insertSemi {
+				return true
+			}
+		case l.atEOF():
+			return l.insertSemi
+		default:
+			return false
+		}
+	}
+}
+
+// readComment reads a // or /* */ comment and returns its text, including
+// the comment markers but not the newline that ends a line comment.
+func (l *Lexer) readComment() string {
+	start := l.position
+	if l.peekChar() == '/' {
+		l.skipLineComment()
+	} else {
+		l.skipBlockComment()
+	}
+	return l.input[start:l.position]
+}
+
+// commentEndsLine reports whether the comments starting at the current char
+// run to the end of the line, in which case a pending semicolon is inserted
+// before them.
+func (l *Lexer) commentEndsLine() bool {
+	i := l.position
+	for i < len(l.input) {
+		switch {
+		case l.input[i] == ' ' || l.input[i] == '\t' || l.input[i] == '\r':
+			i++
+		case strings.HasPrefix(l.input[i:], "//"), l.input[i] == '\n':
+			return true
+		case strings.HasPrefix(l.input[i:], "/*"):
+			end := strings.Index(l.input[i+2:], "*/")
+			if end < 0 || strings.Contains(l.input[i:i+2+end], "\n") {
+				return true
+			}
+			i += end + 4
+		default:
+			return false
+		}
+	}
+	return true
 }
 
 func (l *Lexer) skipLineComment() {
@@ -217,22 +449,28 @@ func (l *Lexer) skipLineComment() {
 	// Advance until newline or EOF
 	l.readChar() // move to second '/'
 	l.readChar() // move past second '/'
-	for l.ch != '\n' && l.ch != 0 {
+	for l.ch != '\n' && !l.atEOF() {
 		l.readChar()
 	}
 }
 
-func (l *Lexer) skipBlockComment() {
+// skipBlockComment skips a /* */ comment and reports whether it contained a newline.
+func (l *Lexer) skipBlockComment() bool {
 	// assumes l.ch == '/' and peekChar() == '*'
 	l.readChar() // move to '*'
 	l.readChar() // move past '*'
-	for l.ch != 0 {
+	newline := false
+	for !l.atEOF() {
 		if l.ch == '*' && l.peekChar() == '/' {
 			l.readChar() // move to '/'
 			l.readChar() // move past '/'
-			return
+			return newline
+		}
+		if l.ch == '\n' {
+			newline = true
 		}
 		l.readChar()
 	}
 	// EOF reached before end of comment -> simply return (unterminated block comment will result in EOF token later)
+	return newline
 }
\ No newline at end of file
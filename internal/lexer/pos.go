@@ -0,0 +1,153 @@
+package lexer
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Pos is a compact source position: the base of a File in its FileSet plus
+// a byte offset into the file. The zero value, NoPos, means no position.
+type Pos int
+
+// NoPos is the zero value of Pos; it is never a valid position.
+const NoPos Pos = 0
+
+// IsValid reports whether the position is valid.
+func (p Pos) IsValid() bool {
+	return p != NoPos
+}
+
+// Position is a decoded source position.
+type Position struct {
+	Filename string // the file name, if any
+	Offset   int    // byte offset, starting at 0
+	Line     int    // line number, starting at 1
+	Column   int    // column number, starting at 1 (a byte count)
+}
+
+// IsValid reports whether the position is valid.
+func (pos Position) IsValid() bool {
+	return pos.Line > 0
+}
+
+// String returns the position as file:line:col, line:col if the file has no
+// name, or "-" for an invalid position.
+func (pos Position) String() string {
+	if !pos.IsValid() {
+		if pos.Filename != "" {
+			return pos.Filename
+		}
+		return "-"
+	}
+	if pos.Filename == "" {
+		return fmt.Sprintf("%d:%d", pos.Line, pos.Column)
+	}
+	return fmt.Sprintf("%s:%d:%d", pos.Filename, pos.Line, pos.Column)
+}
+
+// ------- File -------- //
+
+// File holds the line table of one source file in a FileSet. Its positions
+// are the range [Base, Base+Size].
+type File struct {
+	name  string
+	base  int
+	size  int
+	lines []int // offsets of the first character of each line
+}
+
+// Name returns the file name given to AddFile.
+func (f *File) Name() string {
+	return f.name
+}
+
+// Base returns the position of the file's first byte.
+func (f *File) Base() int {
+	return f.base
+}
+
+// Size returns the length of the file in bytes.
+func (f *File) Size() int {
+	return f.size
+}
+
+// LineCount returns the number of lines in the file.
+func (f *File) LineCount() int {
+	return len(f.lines)
+}
+
+// Pos returns the position of the byte at offset, which must be in [0, Size].
+func (f *File) Pos(offset int) Pos {
+	if offset < 0 || offset > f.size {
+		panic(fmt.Sprintf("invalid file offset %d (should be <= %d)", offset, f.size))
+	}
+	return Pos(f.base + offset)
+}
+
+// Offset returns the byte offset of p, which must belong to the file.
+func (f *File) Offset(p Pos) int {
+	if int(p) < f.base || int(p) > f.base+f.size {
+		panic(fmt.Sprintf("invalid Pos value %d (should be in [%d, %d])", p, f.base, f.base+f.size))
+	}
+	return int(p) - f.base
+}
+
+// Line returns the line number of p.
+func (f *File) Line(p Pos) int {
+	return f.Position(p).Line
+}
+
+// Position returns the decoded position of p, which must belong to the file.
+func (f *File) Position(p Pos) Position {
+	offset := f.Offset(p)
+	i := sort.SearchInts(f.lines, offset+1) - 1
+	return Position{Filename: f.name, Offset: offset, Line: i + 1, Column: offset - f.lines[i] + 1}
+}
+
+// ------- FileSet -------- //
+
+// FileSet maps the positions of many files to file names, lines and columns.
+// Every file gets its own range of positions, so a single Pos identifies
+// both the file and the offset within it.
+type FileSet struct {
+	base  int
+	files []*File
+}
+
+// NewFileSet returns an empty file set.
+func NewFileSet() *FileSet {
+	return &FileSet{base: 1}
+}
+
+// AddFile adds a file with the given name and source to the set and returns
+// it. The file's positions follow those of the previously added file.
+func (s *FileSet) AddFile(filename, src string) *File {
+	f := &File{name: filename, base: s.base, size: len(src), lines: []int{0}}
+	for i := 0; i < len(src); i++ {
+		if src[i] == '\n' {
+			f.lines = append(f.lines, i+1)
+		}
+	}
+	s.files = append(s.files, f)
+	// the extra byte keeps the end of file position distinct from the next file's base
+	s.base += len(src) + 1
+	return f
+}
+
+// File returns the file containing p, or nil if there is none.
+func (s *FileSet) File(p Pos) *File {
+	i := sort.Search(len(s.files), func(i int) bool { return s.files[i].base > int(p) }) - 1
+	if i < 0 || int(p) > s.files[i].base+s.files[i].size {
+		return nil
+	}
+	return s.files[i]
+}
+
+// Position returns the decoded position of p, or the zero Position if p is
+// not in any file of the set.
+func (s *FileSet) Position(p Pos) Position {
+	if f := s.File(p); f != nil {
+		return f.Position(p)
+	}
+	return Position{}
+}
@@ -0,0 +1,85 @@
+package lexer
+
+import "testing"
+
+func TestFileSet(t *testing.T) {
+	fset := NewFileSet()
+	a := fset.AddFile("a.go", "ab\ncd\n")
+	b := fset.AddFile("b.go", "\nx")
+	if a.Base() != 1 || b.Base() != a.Base()+a.Size()+1 {
+		t.Fatalf("bases %d and %d, want 1 and %d", a.Base(), b.Base(), a.Base()+a.Size()+1)
+	}
+	if a.LineCount() != 3 || b.LineCount() != 2 {
+		t.Errorf("line counts %d and %d, want 3 and 2", a.LineCount(), b.LineCount())
+	}
+
+	tests := []struct {
+		pos  Pos
+		want string
+	}{
+		{NoPos, "-"},
+		{a.Pos(0), "a.go:1:1"},
+		{a.Pos(2), "a.go:1:3"}, // the newline
+		{a.Pos(3), "a.go:2:1"},
+		{a.Pos(5), "a.go:2:3"},
+		{a.Pos(6), "a.go:3:1"}, // the end of the file
+		{b.Pos(0), "b.go:1:1"},
+		{b.Pos(1), "b.go:2:1"},
+		{b.Pos(2), "b.go:2:2"},
+		{b.Pos(2) + 1, "-"}, // past every file
+	}
+	for _, tt := range tests {
+		if got := fset.Position(tt.pos).String(); got != tt.want {
+			t.Errorf("Position(%d) = %s, want %s", tt.pos, got, tt.want)
+		}
+	}
+
+	if f := fset.File(a.Pos(6)); f != a {
+		t.Error("the end of a.go is not in a.go")
+	}
+	if f := fset.File(NoPos); f != nil {
+		t.Errorf("File(NoPos) = %s, want nil", f.Name())
+	}
+	if got := a.Offset(a.Pos(4)); got != 4 {
+		t.Errorf("Offset(Pos(4)) = %d", got)
+	}
+	if got := (Position{Line: 2, Column: 5}).String(); got != "2:5" {
+		t.Errorf("unnamed position = %s, want 2:5", got)
+	}
+}
+
+func TestTokenPositions(t *testing.T) {
+	fset := NewFileSet()
+	fset.AddFile("a.go", "package a\n")
+	src := "x := `a\nb` // c\ny\n"
+	file := fset.AddFile("b.go", src)
+	l := NewInFile(file, src, ScanComments)
+
+	tests := []struct {
+		typ        TokenType
+		start, end string
+	}{
+		{IDENT, "b.go:1:1", "b.go:1:2"},
+		{DEFINE, "b.go:1:3", "b.go:1:5"},
+		{STRING, "b.go:1:6", "b.go:2:3"},    // raw strings may span lines
+		{SEMICOLON, "b.go:2:4", "b.go:2:4"}, // implicit semicolons have no width and come before a line comment
+		{COMMENT, "b.go:2:4", "b.go:2:8"},
+		{IDENT, "b.go:3:1", "b.go:3:2"},
+		{SEMICOLON, "b.go:3:2", "b.go:3:2"},
+		{EOF, "b.go:4:1", "b.go:4:1"},
+	}
+	for _, tt := range tests {
+		tok := l.NextToken()
+		start, end := fset.Position(tok.Pos), fset.Position(tok.EndPos())
+		if tok.Type != tt.typ || start.String() != tt.start || end.String() != tt.end {
+			t.Errorf("%s %q at %s-%s, want %s at %s-%s", tok.Type, tok.Literal, start, end, tt.typ, tt.start, tt.end)
+		}
+		if start.Offset != tok.Offset || start.Line != tok.Line {
+			t.Errorf("%s %q: decoded offset %d line %d, token has %d and %d", tok.Type, tok.Literal, start.Offset, start.Line, tok.Offset, tok.Line)
+		}
+	}
+
+	if (Token{}).EndPos() != NoPos {
+		t.Error("a missing token has an end position")
+	}
+}
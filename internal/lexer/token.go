@@ -6,37 +6,51 @@ type TokenType string;
 type Token struct {
 	Type    TokenType
 	Literal string
+	Line    int // 1-based line of the token's first character
+	Offset  int // byte offset of the token's first character
+	Pos     Pos // position of the token's first character; NoPos for a missing token
 }
 
 const (
 	// Special
 	ILLEGAL TokenType = "illegal"
 	EOF     TokenType = "eof"
+	COMMENT TokenType = "comment" // only produced in ScanComments mode
 
 	// Identifiers + literals
 	IDENT  TokenType = "ident"  // for int, int8, string, a, name ...etc
-	INT    TokenType = "int"  // for 34, 45, 23 ...etc
-	FLOAT  TokenType = "float"  // for 12.3, 52.3 ..etc
-	STRING TokenType = "string"  // for "mohit", "right" ...etc
+	INT    TokenType = "int"  // for 34, 0x2A, 1_000 ...etc
+	FLOAT  TokenType = "float"  // for 12.3, .5, 1e9, 0x1p-2 ..etc
+	STRING TokenType = "string"  // for "mohit", `raw` ...etc (the literal keeps its quotes)
+	CHAR   TokenType = "char"  // for 'a', '\n' ...etc (the literal keeps its quotes)
 
 	// Operators
-	ASSIGN   TokenType = "="
-	PLUS     TokenType = "+"
-	MINUS    TokenType = "-"
-	BANG     TokenType = "!"
-	ASTERISK TokenType = "*"
-	SLASH    TokenType = "/"
-	LT       TokenType = "<"
-	GT       TokenType = ">"
-	EQ       TokenType = "=="
-	NOT_EQ   TokenType = "!="
-	LTE      TokenType = "<="
-	GTE      TokenType = ">="
+	ASSIGN    TokenType = "="
+	PLUS      TokenType = "+"
+	MINUS     TokenType = "-"
+	BANG      TokenType = "!"
+	ASTERISK  TokenType = "*"
+	SLASH     TokenType = "/"
+	AMPERSAND TokenType = "&"
+	PIPE      TokenType = "|"
+	TILDE     TokenType = "~"
+	ARROW     TokenType = "<-"
+	INC       TokenType = "++"
+	DEC       TokenType = "--"
+	LT        TokenType = "<"
+	GT        TokenType = ">"
+	EQ        TokenType = "=="
+	NOT_EQ    TokenType = "!="
+	LTE       TokenType = "<="
+	GTE       TokenType = ">="
+	DEFINE    TokenType = ":="
 
 	// Delimiters
 	COMMA     TokenType = ","
 	SEMICOLON TokenType = ";"
 	COLON     TokenType = ":"
+	PERIOD    TokenType = "."
+	ELLIPSIS  TokenType = "..."
 	LPAREN    TokenType = "("
 	RPAREN    TokenType = ")"
 	LBRACE    TokenType = "{"
@@ -110,4 +124,27 @@ func LookUpIdent(ident string) TokenType {
 	}
 
 	return IDENT
-}
\ No newline at end of file
+}
+// Implicit reports whether the token is a semicolon inserted at a line break
+// or at the end of input rather than written in the source.
+func (t Token) Implicit() bool {
+	return t.Type == SEMICOLON && t.Literal == "\n"
+}
+
+// End returns the byte offset just past the token. Implicit semicolons have
+// no width.
+func (t Token) End() int {
+	if t.Implicit() {
+		return t.Offset
+	}
+	return t.Offset + len(t.Literal)
+}
+
+// EndPos returns the position just past the token, or NoPos for a missing
+// token.
+func (t Token) EndPos() Pos {
+	if !t.Pos.IsValid() {
+		return NoPos
+	}
+	return t.Pos + Pos(t.End()-t.Offset)
+}
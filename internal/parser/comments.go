@@ -0,0 +1,72 @@
+package parser
+
+import (
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+)
+
+// Comments only reach the parser when the lexer runs in ScanComments mode.
+// nextToken groups them as they arrive, much as go/parser does: a group that
+// starts on the line of the current token and is followed by a line break is
+// the current token's line comment, and a group that ends on the line of the
+// next token or directly above it is that token's lead comment, so that
+// /* doc */ X int documents X. A spec takes the line comment of the
+// semicolon that ends it, and the comment trailing the opening parenthesis
+// of a declaration group documents its first spec.
+
+// consumeComments reads the comments that follow the current token, leaving
+// p.peekToken on the next real token.
+func (p *Parser) consumeComments() {
+	var group *ast.CommentGroup
+	endLine := 0
+	if p.peekToken.Line == p.curToken.Line {
+		group, endLine = p.consumeCommentGroup(0)
+		if p.peekToken.Line != endLine || p.peekTokenIs(lexer.EOF) {
+			p.lineComment = group
+		}
+	}
+
+	endLine = -1
+	for p.peekTokenIs(lexer.COMMENT) {
+		group, endLine = p.consumeCommentGroup(1)
+	}
+	if endLine == p.peekToken.Line || endLine+1 == p.peekToken.Line {
+		p.peekDoc = group
+	}
+}
+
+// setSpecComments sets the doc and line comments of spec, leaving the ones
+// given as nil alone.
+func setSpecComments(spec ast.Spec, doc, comment *ast.CommentGroup) {
+	switch s := spec.(type) {
+	case *ast.ImportSpec:
+		s.Doc, s.Comment = orGroup(doc, s.Doc), orGroup(comment, s.Comment)
+	case *ast.ValueSpec:
+		s.Doc, s.Comment = orGroup(doc, s.Doc), orGroup(comment, s.Comment)
+	case *ast.TypeSpec:
+		s.Doc, s.Comment = orGroup(doc, s.Doc), orGroup(comment, s.Comment)
+	}
+}
+
+// orGroup returns g, or def if g is nil.
+func orGroup(g, def *ast.CommentGroup) *ast.CommentGroup {
+	if g != nil {
+		return g
+	}
+	return def
+}
+
+// consumeCommentGroup reads comments as long as each starts at most n lines
+// after the previous one ends, and returns the group and its last line.
+func (p *Parser) consumeCommentGroup(n int) (*ast.CommentGroup, int) {
+	group := &ast.CommentGroup{}
+	endLine := p.peekToken.Line
+	for p.peekTokenIs(lexer.COMMENT) && p.peekToken.Line <= endLine+n {
+		c := &ast.Comment{Token: p.peekToken}
+		group.List = append(group.List, c)
+		endLine = c.EndLine()
+		p.peekToken = p.l.NextToken()
+	}
+	p.comments = append(p.comments, group)
+	return group, endLine
+}
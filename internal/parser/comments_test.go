@@ -0,0 +1,119 @@
+package parser
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+)
+
+// commentsOf returns the doc and line comments of the declarations, specs
+// and fields of file that have any, one per entry as "name doc|comment".
+func commentsOf(file *ast.File) []string {
+	var list []string
+	add := func(name string, doc, comment *ast.CommentGroup) {
+		if doc != nil || comment != nil {
+			list = append(list, fmt.Sprintf("%s %s|%s", name, groupText(doc), groupText(comment)))
+		}
+	}
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.GenDecl:
+			add(n.Token.Literal, n.Doc, nil)
+		case *ast.FuncDecl:
+			add(n.Name.Value, n.Doc, nil)
+		case *ast.ImportSpec:
+			add(n.Path.Value, n.Doc, n.Comment)
+		case *ast.ValueSpec:
+			add(n.Names[0].Value, n.Doc, n.Comment)
+		case *ast.TypeSpec:
+			add(n.Name.Value, n.Doc, n.Comment)
+		case *ast.Field:
+			if len(n.Names) > 0 {
+				add(n.Names[0].Value, n.Doc, n.Comment)
+			}
+		}
+		return true
+	})
+	return list
+}
+
+// groupText returns the comments of g joined by spaces, markers included.
+func groupText(g *ast.CommentGroup) string {
+	if g == nil {
+		return ""
+	}
+	var texts []string
+	for _, c := range g.List {
+		texts = append(texts, c.Token.Literal)
+	}
+	return strings.Join(texts, " ")
+}
+
+func TestComments(t *testing.T) {
+	tests := []struct {
+		name, src string
+		want      []string
+	}{
+		{
+			"grouped values",
+			"package p\nvar (\n\t// A doc\n\tA = 1 // A line\n\tB = 2\n)\n",
+			[]string{"A // A doc|// A line"},
+		},
+		{
+			"doc after the parenthesis",
+			"package p\nvar ( // A doc\n\tA = 1 // A line\n)\n",
+			[]string{"A // A doc|// A line"},
+		},
+		{
+			"ungrouped value",
+			"package p\n\n// d\nvar x = 1 // c\n",
+			[]string{"var // d|", "x |// c"},
+		},
+		{
+			"local value",
+			"package p\nfunc f() {\n\t// d\n\tconst x = 1 // c\n\t_ = x\n}\n",
+			[]string{"const // d|", "x |// c"},
+		},
+		{
+			"types",
+			"package p\n\ntype (\n\t// T doc\n\tT int /* T line */\n\tU = T\n)\n",
+			[]string{"T // T doc|/* T line */"},
+		},
+		{
+			"imports",
+			"package p\n\nimport (\n\t// fmt doc\n\t\"fmt\" // fmt line\n\n\t\"os\"\n)\n",
+			[]string{"fmt // fmt doc|// fmt line"},
+		},
+		{
+			"fields",
+			"package p\n\ntype S struct {\n\t// X doc\n\tX int // X line\n\t/* Y doc */ Y int\n}\n",
+			[]string{"X // X doc|// X line", "Y /* Y doc */|"},
+		},
+		{
+			"no comment across lines",
+			"package p\n\nvar (\n\tA = 1\n\n\t// free\n\n\tB = 2\n)\n",
+			nil,
+		},
+		{
+			"function",
+			"package p\n\n/* f doc */ func f() {}\n",
+			[]string{"f /* f doc */|"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(lexer.NewWithMode(tt.src, lexer.ScanComments))
+			file := p.ParseFile()
+			if errs := p.Errors(); len(errs) > 0 {
+				t.Fatalf("parse errors: %v", errs)
+			}
+			if got := commentsOf(file); !slices.Equal(got, tt.want) {
+				t.Errorf("comments = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,403 @@
+package parser
+
+import (
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+)
+
+// specParseFn parses one spec of a GenDecl. index is the position of the spec
+// within a parenthesized group (0 for an ungrouped declaration).
+type specParseFn func(keyword lexer.TokenType, index int) ast.Spec
+
+// ParseFile parses a complete source file: the package clause, the imports
+// and the top-level declarations. In ImportsOnly mode it stops after the
+// imports.
+func (p *Parser) ParseFile() *ast.File {
+	file := &ast.File{Doc: p.curDoc, Token: p.curToken}
+	if !p.curTokenIs(lexer.PACKAGE) {
+		p.errorf("expected package, got %s instead", p.curToken.Type)
+		return file
+	}
+	if !p.expectPeek(lexer.IDENT) {
+		return file
+	}
+	file.Name = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+	p.expectDeclarationEnd()
+	p.nextToken()
+
+	for p.curTokenIs(lexer.IMPORT) {
+		file.Decls = append(file.Decls, p.parseGenDecl(p.parseImportSpec))
+		p.expectDeclarationEnd()
+		p.nextToken()
+	}
+
+	for p.mode&ImportsOnly == 0 && !p.curTokenIs(lexer.EOF) {
+		if decl := p.parseDeclaration(); decl != nil {
+			file.Decls = append(file.Decls, decl)
+		}
+		p.expectDeclarationEnd()
+		p.nextToken()
+	}
+
+	for _, decl := range file.Decls {
+		if gd, ok := decl.(*ast.GenDecl); ok && gd.Token.Type == lexer.IMPORT {
+			for _, spec := range gd.Specs {
+				file.Imports = append(file.Imports, spec.(*ast.ImportSpec))
+			}
+		}
+	}
+	file.Comments = p.comments
+	return file
+}
+
+// expectDeclarationEnd consumes the semicolon that terminates a top-level declaration.
+func (p *Parser) expectDeclarationEnd() {
+	switch p.peekToken.Type {
+	case lexer.SEMICOLON:
+		p.nextToken()
+	case lexer.EOF:
+	default:
+		p.errorf("expected ; after top level declaration, got %s instead", p.peekToken.Type)
+	}
+}
+
+// parseDeclaration parses a top-level declaration starting at the current token.
+func (p *Parser) parseDeclaration() ast.Declaration {
+	switch p.curToken.Type {
+	case lexer.FUNC:
+		doc := p.curDoc
+		if decl := p.parseFuncDecl(); decl != nil {
+			decl.Doc = doc
+			return decl
+		}
+		return nil
+	case lexer.VAR, lexer.CONST:
+		return p.parseGenDecl(p.parseValueSpec)
+	case lexer.TYPE:
+		return p.parseGenDecl(p.parseTypeSpec)
+	case lexer.IMPORT:
+		p.errorf("imports must appear before other declarations")
+		return p.parseGenDecl(p.parseImportSpec)
+	}
+
+	p.errorf("non-declaration statement outside function body")
+	for !p.peekTokenIs(lexer.SEMICOLON) && !p.peekTokenIs(lexer.EOF) {
+		p.nextToken()
+	}
+	return nil
+}
+
+// parseGenDecl parses `keyword spec` or `keyword ( spec; spec; ... )`.
+func (p *Parser) parseGenDecl(fn specParseFn) (decl *ast.GenDecl) {
+	defer deferNode(p, p.curToken.Offset, &decl)
+	decl = &ast.GenDecl{Doc: p.curDoc, Token: p.curToken}
+	keyword := p.curToken.Type
+
+	if !p.peekTokenIs(lexer.LPAREN) {
+		p.nextToken()
+		start, doc := p.curToken.Offset, p.curDoc
+		if spec := fn(keyword, 0); spec != nil {
+			p.reportNode(spec, start)
+			setSpecComments(spec, doc, nil)
+			p.lineSpec = spec
+			decl.Specs = append(decl.Specs, spec)
+		}
+		return decl
+	}
+
+	p.nextToken()
+	decl.Lparen = p.curToken
+	lparenComment := p.lineComment
+	p.nextToken()
+	if p.curDoc == nil {
+		p.curDoc = lparenComment
+	}
+	for i := 0; !p.curTokenIs(lexer.RPAREN) && !p.curTokenIs(lexer.EOF); i++ {
+		start, doc := p.curToken.Offset, p.curDoc
+		if spec := fn(keyword, i); spec != nil {
+			p.reportNode(spec, start)
+			setSpecComments(spec, doc, nil)
+			p.lineSpec = spec
+			decl.Specs = append(decl.Specs, spec)
+		}
+		if p.peekTokenIs(lexer.SEMICOLON) {
+			p.nextToken()
+		} else if !p.peekTokenIs(lexer.RPAREN) {
+			p.errorf("expected ; or ) in %s declaration, got %s instead", keyword, p.peekToken.Type)
+		}
+		p.nextToken()
+	}
+	decl.Rparen = p.curToken
+	return decl
+}
+
+// parseImportSpec parses `"path"`, `name "path"`, `. "path"` or `_ "path"`.
+func (p *Parser) parseImportSpec(keyword lexer.TokenType, index int) ast.Spec {
+	spec := &ast.ImportSpec{}
+	switch p.curToken.Type {
+	case lexer.IDENT, lexer.PERIOD:
+		spec.Name = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+		if !p.expectPeek(lexer.STRING) {
+			return nil
+		}
+	case lexer.STRING:
+	default:
+		p.errorf("missing import path, got %s instead", p.curToken.Type)
+		return nil
+	}
+	spec.Path = p.stringLiteral()
+	if spec.Path.Value == "" {
+		p.errorf("invalid import path: %s", spec.Path.String())
+	}
+	return spec
+}
+
+// parseValueSpec parses `names [Type] [= values]` of a const or var declaration.
+func (p *Parser) parseValueSpec(keyword lexer.TokenType, index int) ast.Spec {
+	if !p.curTokenIs(lexer.IDENT) {
+		p.errorf("expected name in %s declaration, got %s instead", keyword, p.curToken.Type)
+		return nil
+	}
+	spec := &ast.ValueSpec{Names: p.parseIdentifierList()}
+
+	if !p.peekTokenIs(lexer.ASSIGN) && !p.peekTokenIs(lexer.SEMICOLON) && !p.peekTokenIs(lexer.RPAREN) {
+		p.nextToken()
+		spec.Type = p.parseType()
+	}
+	if p.peekTokenIs(lexer.ASSIGN) {
+		p.nextToken()
+		p.nextToken()
+		spec.Values = p.parseExpressionList()
+	}
+
+	if keyword == lexer.CONST {
+		p.declareNonVars(spec.Names...)
+	} else {
+		p.declare(spec.Names...)
+	}
+
+	switch {
+	case keyword == lexer.VAR && spec.Type == nil && spec.Values == nil:
+		p.errorf("missing type or initializer in var declaration of %s", spec.Names[0].Value)
+	case keyword == lexer.CONST && index == 0 && spec.Values == nil:
+		p.errorf("missing init expr for const declaration of %s", spec.Names[0].Value)
+	case keyword == lexer.CONST && spec.Type != nil && spec.Values == nil:
+		p.errorf("const declaration of %s has a type but no values", spec.Names[0].Value)
+	}
+	return spec
+}
+
+// parseTypeSpec parses `Name [TypeParams] Type` or the alias `Name [TypeParams] = Type`.
+//
+// After the name, a `[` starts either an array type or a type parameter list.
+// As in the Go spec, `[P C]` with a constraint that cannot continue an
+// expression is a type parameter list, while `[N]`, `[N * M]` or `[P *C]`
+// is parsed as an array length; a trailing comma (`[P *C,]`) forces the type
+// parameter reading.
+func (p *Parser) parseTypeSpec(keyword lexer.TokenType, index int) ast.Spec {
+	if !p.curTokenIs(lexer.IDENT) {
+		p.errorf("expected type name, got %s instead", p.curToken.Type)
+		return nil
+	}
+	spec := &ast.TypeSpec{Name: &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}}
+	p.declareNonVars(spec.Name)
+
+	if p.peekTokenIs(lexer.LBRACKET) {
+		p.nextToken()
+		if !p.peekTokenIs(lexer.RBRACKET) {
+			tparams, array := p.parseArrayLenOrTypeParams()
+			if array != nil {
+				spec.Type = array
+				return spec
+			}
+			spec.TypeParams = tparams
+		} else {
+			spec.Type = p.parseType()
+			return spec
+		}
+	}
+
+	if p.peekTokenIs(lexer.ASSIGN) {
+		p.nextToken()
+		spec.Assign = p.curToken
+	}
+	p.nextToken()
+	spec.Type = p.parseType()
+	return spec
+}
+
+// parseArrayLenOrTypeParams resolves the `[` after a type name, returning
+// either the type parameter list or the complete array type.
+func (p *Parser) parseArrayLenOrTypeParams() (*ast.FieldList, *ast.ArrayType) {
+	lbrack := p.curToken
+	p.nextToken()
+	list := &ast.FieldList{Token: lbrack}
+
+	if p.curTokenIs(lexer.IDENT) && isTypeParamStart(p.peekToken.Type) {
+		return p.parseTypeParams(list), nil
+	}
+
+	p.exprLev++
+	x := p.parseExpression(LOWEST)
+	p.exprLev--
+
+	// [P *C,] is a type parameter P constrained by *C
+	if infix, ok := x.(*ast.InfixExpression); ok && infix.Operator == "*" && p.peekTokenIs(lexer.COMMA) {
+		if name, ok := infix.Left.(*ast.Identifier); ok {
+			constraint := &ast.StarExpr{Token: infix.Token, X: infix.Right}
+			list.List = append(list.List, &ast.Field{Names: []*ast.Identifier{name}, Type: constraint})
+			p.nextToken()
+			p.nextToken()
+			return p.parseTypeParams(list), nil
+		}
+	}
+
+	if !p.expectPeek(lexer.RBRACKET) {
+		return nil, nil
+	}
+	array := &ast.ArrayType{Token: lbrack, Len: x}
+	p.nextToken()
+	array.Elt = p.parseType()
+	return nil, array
+}
+
+// isTypeParamStart reports whether a token following a name inside `[`
+// makes the name a type parameter rather than the start of an expression.
+func isTypeParamStart(tt lexer.TokenType) bool {
+	switch tt {
+	case lexer.IDENT, lexer.COMMA, lexer.TILDE, lexer.LBRACKET, lexer.FUNC,
+		lexer.MAP, lexer.CHAN, lexer.ARROW, lexer.STRUCT, lexer.INTERFACE:
+		return true
+	}
+	return false
+}
+
+// parseTypeParams parses the entries of a type parameter list up to the
+// closing bracket, such as K comparable, V any or K, V any. Entries already
+// parsed by the caller are kept at the front of list.
+func (p *Parser) parseTypeParams(list *ast.FieldList) *ast.FieldList {
+	var pending []*ast.Identifier
+	for !p.curTokenIs(lexer.RBRACKET) && !p.curTokenIs(lexer.EOF) {
+		if !p.curTokenIs(lexer.IDENT) {
+			p.errorf("expected type parameter name, got %s instead", p.curToken.Type)
+			return list
+		}
+		name := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+		if p.peekTokenIs(lexer.COMMA) || p.peekTokenIs(lexer.RBRACKET) {
+			pending = append(pending, name)
+		} else {
+			p.nextToken()
+			field := &ast.Field{Names: append(pending, name), Type: p.parseTypeElem()}
+			list.List = append(list.List, field)
+			pending = nil
+		}
+
+		if p.peekTokenIs(lexer.COMMA) {
+			p.nextToken()
+			p.nextToken()
+			continue
+		}
+		if !p.expectPeek(lexer.RBRACKET) {
+			return list
+		}
+		break
+	}
+	if p.curTokenIs(lexer.RBRACKET) {
+		list.Closing = p.curToken
+	}
+
+	if len(pending) > 0 {
+		p.errorf("missing type constraint")
+	}
+	if len(list.List) == 0 {
+		p.errorf("empty type parameter list")
+	}
+	p.declareTypeParams(list)
+	return list
+}
+
+// parseIdentifierList parses `a, b, c` starting at the current identifier.
+func (p *Parser) parseIdentifierList() []*ast.Identifier {
+	list := []*ast.Identifier{{Token: p.curToken, Value: p.curToken.Literal}}
+	for p.peekTokenIs(lexer.COMMA) {
+		p.nextToken()
+		if !p.expectPeek(lexer.IDENT) {
+			break
+		}
+		list = append(list, &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal})
+	}
+	return list
+}
+
+// parseFuncDecl parses `func [(recv)] Name[TypeParams](params) results { body }`.
+// The body may be omitted for functions implemented outside Go.
+func (p *Parser) parseFuncDecl() (decl *ast.FuncDecl) {
+	defer deferNode(p, p.curToken.Offset, &decl)
+	decl = &ast.FuncDecl{Token: p.curToken}
+	p.openScope(nil)
+	defer p.closeScope()
+
+	if p.peekTokenIs(lexer.LPAREN) {
+		p.nextToken()
+		decl.Recv = p.parseReceiver()
+	}
+	if !p.expectPeek(lexer.IDENT) {
+		return nil
+	}
+	decl.Name = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+
+	if p.peekTokenIs(lexer.LBRACKET) {
+		p.nextToken()
+		decl.TypeParams = &ast.FieldList{Token: p.curToken}
+		p.nextToken()
+		p.parseTypeParams(decl.TypeParams)
+		if decl.Recv != nil {
+			p.errorf("method must have no type parameters")
+		}
+	}
+
+	if !p.expectPeek(lexer.LPAREN) {
+		return nil
+	}
+	decl.Type = p.parseSignature(decl.Token)
+	p.declareFields(decl.Recv)
+	p.declareFields(decl.Type.Params)
+	p.declareFields(decl.Type.Results)
+
+	if p.peekTokenIs(lexer.LBRACE) {
+		p.nextToken()
+		if p.mode&SkipFuncBodies != 0 {
+			decl.Body = p.skipFuncBody(decl)
+		} else {
+			decl.Body = p.parseFuncBody()
+		}
+	}
+	return decl
+}
+
+// parseFuncBody parses the body of a function declaration or literal, with
+// the parameters already declared, and checks its labels.
+func (p *Parser) parseFuncBody() *ast.BlockStatement {
+	body := p.parseBlockStatement()
+	p.checkLabels(body.Statements)
+	return body
+}
+
+// parseReceiver parses the receiver list of a method, such as (s *Stack) or
+// (l *List[T]), and checks that it declares exactly one receiver of the form
+// [*]T or [*]T[params].
+func (p *Parser) parseReceiver() *ast.FieldList {
+	recv := p.parseParameters(false)
+	switch {
+	case recv.NumFields() == 0:
+		p.errorf("method has no receiver")
+	case recv.NumFields() > 1:
+		p.errorf("method has multiple receivers")
+	default:
+		typ := recv.List[0].Type
+		if name, _, _ := (&ast.FuncDecl{Recv: recv}).RecvBase(); name == nil && typ != nil {
+			p.errorf("invalid receiver type %s", typ.String())
+		}
+	}
+	return recv
+}
@@ -0,0 +1,230 @@
+package parser
+
+import (
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+)
+
+// Labels are scoped to the function body that declares them and, unlike
+// other names, may be used before they are declared. The checks therefore
+// run once the whole body has been parsed: checkLabels walks the statements,
+// records labels, gotos and variable declarations per block, and resolves
+// the jumps at the end.
+
+// labelBlock is a statement list. index is the position, in the outer
+// block, of the statement that contains it.
+type labelBlock struct {
+	outer *labelBlock
+	index int
+	vars  []int // positions of the variable declarations in this block
+}
+
+type label struct {
+	stmt  *ast.LabeledStmt
+	block *labelBlock
+	index int
+	used  bool
+}
+
+// jump is a goto, or a labeled break or continue, awaiting resolution.
+type jump struct {
+	stmt  *ast.BranchStmt
+	block *labelBlock
+	index int
+	valid bool // for break and continue: the label names an enclosing statement
+}
+
+// branchContext describes the statements enclosing a branch.
+type branchContext struct {
+	breakOK        bool     // inside a for, switch or select
+	continueOK     bool     // inside a for
+	breakLabels    []string // labels of the enclosing for, switch and select statements
+	continueLabels []string // labels of the enclosing for statements
+}
+
+type labelChecker struct {
+	p      *Parser
+	labels map[string]*label
+	order  []*label // labels in source order, for deterministic errors
+	jumps  []*jump
+}
+
+// checkLabels reports undefined, duplicate and unused labels, gotos that
+// jump over variable declarations or into blocks, and break and continue
+// statements without a valid target in the function body.
+func (p *Parser) checkLabels(body []ast.Statement) {
+	c := &labelChecker{p: p, labels: map[string]*label{}}
+	c.walkList(body, nil, 0, branchContext{})
+
+	for _, j := range c.jumps {
+		name := j.stmt.Label.Value
+		l := c.labels[name]
+		if l == nil {
+			p.errorf("label %s not defined", name)
+			continue
+		}
+		l.used = true
+		switch j.stmt.Token.Type {
+		case lexer.GOTO:
+			c.checkGoto(j, l)
+		case lexer.BREAK, lexer.CONTINUE:
+			if !j.valid {
+				p.errorf("invalid %s label %s", j.stmt.Token.Literal, name)
+			}
+		}
+	}
+
+	for _, l := range c.order {
+		if !l.used {
+			p.errorf("label %s defined and not used", l.stmt.Label.Value)
+		}
+	}
+}
+
+// checkGoto finds the block of the target label among the blocks enclosing
+// the goto and checks that no variable declaration lies between them.
+func (c *labelChecker) checkGoto(j *jump, l *label) {
+	for b, index := j.block, j.index; b != nil; b, index = b.outer, b.index {
+		if b != l.block {
+			continue
+		}
+		for _, v := range b.vars {
+			if index < v && v < l.index {
+				c.p.errorf("goto %s jumps over variable declaration", l.stmt.Label.Value)
+				return
+			}
+		}
+		return
+	}
+	c.p.errorf("goto %s jumps into block", l.stmt.Label.Value)
+}
+
+func (c *labelChecker) walkList(list []ast.Statement, outer *labelBlock, index int, ctx branchContext) {
+	b := &labelBlock{outer: outer, index: index}
+	for i, s := range list {
+		c.walkStmt(s, b, i, ctx, "")
+	}
+}
+
+// walkStmt records the labels, branches and declarations of s, the
+// statement at position index of block b. name is the label of s, if any.
+func (c *labelChecker) walkStmt(s ast.Statement, b *labelBlock, index int, ctx branchContext, name string) {
+	switch s := s.(type) {
+	case *ast.LabeledStmt:
+		name := s.Label.Value
+		if name == "_" {
+			c.p.errorf("invalid label name _")
+		} else if _, dup := c.labels[name]; dup {
+			c.p.errorf("label %s already defined", name)
+		} else {
+			l := &label{stmt: s, block: b, index: index}
+			c.labels[name] = l
+			c.order = append(c.order, l)
+		}
+		c.walkStmt(s.Stmt, b, index, ctx, name)
+
+	case *ast.DeclStmt:
+		if s.Decl.Token.Type == lexer.VAR {
+			b.vars = append(b.vars, index)
+		}
+
+	case *ast.AssignStmt:
+		if s.Token.Type == lexer.DEFINE {
+			b.vars = append(b.vars, index)
+		}
+
+	case *ast.BranchStmt:
+		c.walkBranch(s, b, index, ctx)
+
+	case *ast.BlockStatement:
+		c.walkList(s.Statements, b, index, ctx)
+
+	case *ast.IfStmt:
+		if s.Body != nil {
+			c.walkList(s.Body.Statements, b, index, ctx)
+		}
+		if s.Else != nil {
+			c.walkStmt(s.Else, &labelBlock{outer: b, index: index}, 0, ctx, "")
+		}
+
+	case *ast.ForStmt:
+		c.walkLoop(s.Body, b, index, ctx, name)
+
+	case *ast.RangeStmt:
+		c.walkLoop(s.Body, b, index, ctx, name)
+
+	case *ast.SwitchStmt:
+		ctx = ctx.breakable(name)
+		for _, cc := range s.Cases {
+			c.walkList(cc.Body, b, index, ctx)
+		}
+
+	case *ast.TypeSwitchStmt:
+		ctx = ctx.breakable(name)
+		for _, cc := range s.Cases {
+			c.walkList(cc.Body, b, index, ctx)
+		}
+
+	case *ast.SelectStmt:
+		ctx = ctx.breakable(name)
+		for _, cc := range s.Cases {
+			c.walkList(cc.Body, b, index, ctx)
+		}
+	}
+}
+
+func (c *labelChecker) walkLoop(body *ast.BlockStatement, b *labelBlock, index int, ctx branchContext, name string) {
+	if body == nil {
+		return
+	}
+	ctx = ctx.breakable(name)
+	ctx.continueOK = true
+	if name != "" {
+		ctx.continueLabels = append(ctx.continueLabels[:len(ctx.continueLabels):len(ctx.continueLabels)], name)
+	}
+	c.walkList(body.Statements, b, index, ctx)
+}
+
+func (c *labelChecker) walkBranch(s *ast.BranchStmt, b *labelBlock, index int, ctx branchContext) {
+	switch s.Token.Type {
+	case lexer.BREAK:
+		if s.Label == nil {
+			if !ctx.breakOK {
+				c.p.errorf("break is not in a loop, switch, or select")
+			}
+			return
+		}
+		c.jumps = append(c.jumps, &jump{stmt: s, valid: contains(ctx.breakLabels, s.Label.Value)})
+	case lexer.CONTINUE:
+		if s.Label == nil {
+			if !ctx.continueOK {
+				c.p.errorf("continue is not in a loop")
+			}
+			return
+		}
+		c.jumps = append(c.jumps, &jump{stmt: s, valid: contains(ctx.continueLabels, s.Label.Value)})
+	case lexer.GOTO:
+		if s.Label != nil {
+			c.jumps = append(c.jumps, &jump{stmt: s, block: b, index: index})
+		}
+	}
+}
+
+// breakable returns the context for the body of a for, switch or select
+// statement labeled name (which may be empty).
+func (ctx branchContext) breakable(name string) branchContext {
+	ctx.breakOK = true
+	if name != "" {
+		ctx.breakLabels = append(ctx.breakLabels[:len(ctx.breakLabels):len(ctx.breakLabels)], name)
+	}
+	return ctx
+}
+
+func contains(list []string, name string) bool {
+	for _, s := range list {
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,135 @@
+package parser
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+)
+
+// Literals. The lexer hands over the literal text unchecked; the parser
+// validates it and attaches its exact value, reporting malformed literals as
+// syntax errors. The node is built either way, so that the tree stays
+// complete, but then lacks its value.
+
+func (p *Parser) parseIntegerLiteral() ast.Expression {
+	lit := &ast.IntegerLiteral{Token: p.curToken, Value: p.curToken.Literal}
+	// base 0 follows the Go syntax: 0b, 0o, 0 and 0x prefixes and _ separators
+	if v, ok := new(big.Int).SetString(lit.Value, 0); ok {
+		lit.Int = v
+	} else {
+		p.errorf("invalid integer literal %s", lit.Value)
+	}
+	return lit
+}
+
+func (p *Parser) parseFloatLiteral() ast.Expression {
+	lit := &ast.FloatLiteral{Token: p.curToken, Value: p.curToken.Literal}
+	if reason := floatSyntax(lit.Value); reason != "" {
+		p.errorf("invalid floating-point literal %s: %s", lit.Value, reason)
+		return lit
+	}
+	f, _, err := big.ParseFloat(lit.Value, 0, ast.FloatPrec, big.ToNearestEven)
+	if err != nil {
+		p.errorf("invalid floating-point literal %s: %v", lit.Value, err)
+		return lit
+	}
+	if f.IsInf() {
+		// the exponent exceeds even big.Float's range
+		p.errorf("floating-point literal %s is too large", lit.Value)
+		return lit
+	}
+	lit.Float = f
+	// SetString gives up on exponents too large to expand exactly; the
+	// value is then only known as a Float
+	if r, ok := new(big.Rat).SetString(lit.Value); ok {
+		lit.Rat = r
+	}
+	return lit
+}
+
+// floatSyntax reports what makes lit an invalid Go floating-point literal in
+// ways big.ParseFloat accepts, or "" if there is nothing.
+func floatSyntax(lit string) string {
+	prefix := ""
+	if len(lit) >= 2 && lit[0] == '0' {
+		prefix = strings.ToLower(lit[:2])
+	}
+	hasP := strings.ContainsAny(lit, "pP")
+	switch {
+	case prefix == "0b" || prefix == "0o":
+		return "invalid radix point"
+	case prefix == "0x" && !hasP:
+		return "hexadecimal mantissa requires a 'p' exponent"
+	case prefix != "0x" && hasP:
+		return "'p' exponent requires hexadecimal mantissa"
+	}
+	return ""
+}
+
+func (p *Parser) parseStringLiteral() ast.Expression {
+	return p.stringLiteral()
+}
+
+// stringLiteral returns the string literal at the current token, as found in
+// expressions, import paths and struct tags.
+func (p *Parser) stringLiteral() *ast.StringLiteral {
+	lit := &ast.StringLiteral{Token: p.curToken}
+	text := lit.Token.Literal
+	if text[0] == '`' {
+		if len(text) < 2 || text[len(text)-1] != '`' {
+			p.errorf("raw string literal not terminated")
+		}
+		// raw strings have no escapes; carriage returns are discarded
+		lit.Value = strings.ReplaceAll(stringValue(text), "\r", "")
+		return lit
+	}
+	v, err := strconv.Unquote(text)
+	if err != nil {
+		if len(text) < 2 || text[len(text)-1] != '"' {
+			p.errorf("string literal not terminated")
+		} else {
+			p.errorf("invalid string literal %s", text)
+		}
+		v = stringValue(text)
+	}
+	lit.Value = v
+	return lit
+}
+
+// stringValue strips the quotes (or back quotes) from a string literal. It
+// stands in for the decoded value of a malformed literal.
+func stringValue(lit string) string {
+	if len(lit) >= 2 && lit[len(lit)-1] == lit[0] {
+		return lit[1 : len(lit)-1]
+	}
+	if len(lit) > 0 {
+		return lit[1:] // unterminated
+	}
+	return lit
+}
+
+func (p *Parser) parseRuneLiteral() ast.Expression {
+	lit := &ast.RuneLiteral{Token: p.curToken}
+	text := lit.Token.Literal
+	if len(text) < 2 || text[len(text)-1] != '\'' {
+		p.errorf("rune literal not terminated")
+		return lit
+	}
+	body := text[1 : len(text)-1]
+	if body == "" {
+		p.errorf("empty rune literal or unescaped ' in rune literal")
+		return lit
+	}
+	v, _, tail, err := strconv.UnquoteChar(body, '\'')
+	switch {
+	case err != nil:
+		p.errorf("invalid rune literal %s", text)
+	case tail != "":
+		p.errorf("more than one character in rune literal")
+	default:
+		lit.Value = v
+	}
+	return lit
+}
@@ -0,0 +1,97 @@
+package parser
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+)
+
+// literalValue returns the exact value attached to a literal node, or "<nil>"
+// for a number without one. Malformed runes have the value 0.
+func literalValue(e ast.Expression) string {
+	switch lit := e.(type) {
+	case *ast.IntegerLiteral:
+		if lit.Int != nil {
+			return lit.Int.String()
+		}
+	case *ast.FloatLiteral:
+		if lit.Rat != nil {
+			return lit.Rat.RatString()
+		}
+	case *ast.StringLiteral:
+		return fmt.Sprintf("%q", lit.Value)
+	case *ast.RuneLiteral:
+		return fmt.Sprintf("%q", lit.Value)
+	default:
+		return fmt.Sprintf("%T", e)
+	}
+	return "<nil>"
+}
+
+func TestLiterals(t *testing.T) {
+	tests := []struct {
+		src, want string
+		errs      []string
+	}{
+		{"42", "42", nil},
+		{"0x_1F", "31", nil},
+		{"0X1f", "31", nil},
+		{"0b1_01", "5", nil},
+		{"0o17", "15", nil},
+		{"0777", "511", nil},
+		{"0", "0", nil},
+		{"1_000_000", "1000000", nil},
+		{"123456789012345678901234567890", "123456789012345678901234567890", nil},
+		{"09", "<nil>", []string{"invalid integer literal 09"}},
+		{"1__0", "<nil>", []string{"invalid integer literal 1__0"}},
+		{"1_", "<nil>", []string{"invalid integer literal 1_"}},
+		{"0x", "<nil>", []string{"invalid integer literal 0x"}},
+
+		{"1.5", "3/2", nil},
+		{"0.1", "1/10", nil},
+		{"1e3", "1000", nil},
+		{".25", "1/4", nil},
+		{"0x1p-2", "1/4", nil},
+		{"0x1.8p1", "3", nil},
+		{"09.5", "19/2", nil},
+		{"0x1.8", "<nil>", []string{"invalid floating-point literal 0x1.8: hexadecimal mantissa requires a 'p' exponent"}},
+		{"1.5p2", "<nil>", []string{"invalid floating-point literal 1.5p2: 'p' exponent requires hexadecimal mantissa"}},
+
+		{`"a\tb"`, `"a\tb"`, nil},
+		{`"é\xff"`, `"é\xff"`, nil},
+		{"`a\\n\r\nb`", `"a\\n\nb"`, nil},
+		{`"\q"`, `"\\q"`, []string{`invalid string literal "\q"`}},
+
+		{`'a'`, `'a'`, nil},
+		{`'\n'`, `'\n'`, nil},
+		{`'\377'`, `'ÿ'`, nil},
+		{`'\x00'`, `'\x00'`, nil},
+		{`'é'`, `'é'`, nil},
+		{`'\400'`, `'\x00'`, []string{`invalid rune literal '\400'`}},
+		{`'ab'`, `'\x00'`, []string{"more than one character in rune literal"}},
+		{`''`, `'\x00'`, []string{"empty rune literal or unescaped ' in rune literal"}},
+		{`'\'`, `'\x00'`, []string{`invalid rune literal '\'`}},
+	}
+	for _, tt := range tests {
+		p := New(lexer.New(tt.src))
+		prog := p.ParseProgram()
+		if len(prog.Statements) != 1 {
+			t.Errorf("%s: got %d statements, want 1", tt.src, len(prog.Statements))
+			continue
+		}
+		stmt, ok := prog.Statements[0].(*ast.ExprStmt)
+		if !ok {
+			t.Errorf("%s: got %T, want an expression", tt.src, prog.Statements[0])
+			continue
+		}
+		if got := literalValue(stmt.X); got != tt.want {
+			t.Errorf("%s: value %s, want %s", tt.src, got, tt.want)
+		}
+		if errs := p.Errors(); !slices.Equal(errs, tt.errs) {
+			t.Errorf("%s: errors %q, want %q", tt.src, errs, tt.errs)
+		}
+	}
+}
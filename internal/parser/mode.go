@@ -0,0 +1,145 @@
+package parser
+
+import (
+	"fmt"
+
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+)
+
+// Mode is a set of flags controlling how much of the source is parsed and
+// whether the parse is traced.
+type Mode uint
+
+const (
+	// ImportsOnly stops ParseFile after the import declarations.
+	ImportsOnly Mode = 1 << iota
+
+	// SkipFuncBodies parses declarations only: the body of each function
+	// declaration is skipped by matching braces at the token level and left
+	// as an empty BlockStatement until ParseBody is called for it.
+	SkipFuncBodies
+
+	// Trace prints the recursion of the Pratt loop and the statements being
+	// parsed to standard output.
+	Trace
+)
+
+// tokenSource is where a Parser gets its tokens: usually a *lexer.Lexer,
+// or a tokenList replaying a skipped function body.
+type tokenSource interface {
+	NextToken() lexer.Token
+}
+
+// tokenList replays a fixed list of tokens followed by EOF.
+type tokenList struct {
+	tokens []lexer.Token
+	next   int
+}
+
+func (tl *tokenList) NextToken() lexer.Token {
+	if tl.next >= len(tl.tokens) {
+		return lexer.Token{Type: lexer.EOF}
+	}
+	tok := tl.tokens[tl.next]
+	tl.next++
+	return tok
+}
+
+// skipFuncBody consumes the body of decl, from the opening brace at the
+// current token to the matching closing brace, and keeps its tokens for
+// ParseBody. It returns the empty placeholder body.
+func (p *Parser) skipFuncBody(decl *ast.FuncDecl) *ast.BlockStatement {
+	body := &ast.BlockStatement{Token: p.curToken}
+	tokens := []lexer.Token{p.curToken}
+	for depth := 1; depth > 0; {
+		if p.peekTokenIs(lexer.EOF) {
+			p.peekError(lexer.RBRACE)
+			break
+		}
+		p.nextToken()
+		tokens = append(tokens, p.curToken)
+		switch p.curToken.Type {
+		case lexer.LBRACE:
+			depth++
+		case lexer.RBRACE:
+			depth--
+		}
+	}
+	if p.curTokenIs(lexer.RBRACE) {
+		body.Rbrace = p.curToken
+	}
+	if p.skipped == nil {
+		p.skipped = map[*ast.FuncDecl][]lexer.Token{}
+	}
+	p.skipped[decl] = tokens
+	return body
+}
+
+// ParseBody parses the body of a function declaration that was skipped in
+// SkipFuncBodies mode, stores it in decl.Body and returns it. Errors found
+// in the body are added to p.Errors(). For any other declaration it returns
+// decl.Body unchanged.
+func (p *Parser) ParseBody(decl *ast.FuncDecl) *ast.BlockStatement {
+	tokens, ok := p.skipped[decl]
+	if !ok {
+		return decl.Body
+	}
+	delete(p.skipped, decl)
+
+	sub := newParser(&tokenList{tokens: tokens}, p.mode&^SkipFuncBodies)
+	sub.openScope(nil)
+	sub.declareTypeParams(decl.TypeParams)
+	sub.declareFields(decl.Recv)
+	sub.declareFields(decl.Type.Params)
+	sub.declareFields(decl.Type.Results)
+	decl.Body = sub.parseFuncBody()
+	sub.closeScope()
+
+	p.errors = append(p.errors, sub.errors...)
+	return decl.Body
+}
+
+// ------- Tracing -------- //
+
+var precedenceNames = map[int]string{
+	LOWEST:      "LOWEST",
+	EQUALS:      "EQUALS",
+	LESSGREATER: "LESSGREATER",
+	SUM:         "SUM",
+	PRODUCT:     "PRODUCT",
+	PREFIX:      "PREFIX",
+	GROUP:       "GROUP",
+	CALL:        "CALL",
+}
+
+// printTrace prints a trace line for the current token, indented by the
+// nesting depth of the traced parse functions.
+func (p *Parser) printTrace(format string, args ...any) {
+	const dots = ". . . . . . . . . . . . . . . . . . . . . . . . . . . . . . . . "
+	const n = len(dots)
+	fmt.Printf("%5d: ", p.curToken.Line)
+	i := 2 * p.indent
+	for i > n {
+		fmt.Print(dots)
+		i -= n
+	}
+	fmt.Print(dots[0:i])
+	fmt.Printf(format+"\n", args...)
+}
+
+// trace and un bracket a traced parse function:
+//
+//	if p.mode&Trace != 0 {
+//		defer un(trace(p, "Expression"))
+//	}
+func trace(p *Parser, msg string) *Parser {
+	p.printTrace("%s (", msg)
+	p.indent++
+	return p
+}
+
+func un(p *Parser) {
+	p.indent--
+	p.printTrace(")")
+}
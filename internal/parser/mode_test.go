@@ -0,0 +1,126 @@
+package parser
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+)
+
+func TestImportsOnly(t *testing.T) {
+	src := "package p\nimport \"fmt\"\nimport (\n\t\"os\"\n\tm \"math\"\n)\nfunc f( {\n"
+	p := NewWithMode(lexer.New(src), ImportsOnly)
+	file := p.ParseFile()
+	if errs := p.Errors(); len(errs) > 0 {
+		t.Errorf("errors after the imports reported: %q", errs)
+	}
+	if len(file.Decls) != 2 || len(file.Imports) != 3 {
+		t.Errorf("got %d declarations and %d imports, want 2 and 3", len(file.Decls), len(file.Imports))
+	}
+
+	p = NewWithMode(lexer.New("package p\nimport \"fmt\" \"os\"\n"), ImportsOnly)
+	p.ParseFile()
+	if len(p.Errors()) == 0 {
+		t.Error("no error for a malformed import")
+	}
+}
+
+func TestSkipFuncBodies(t *testing.T) {
+	src := "package p\n\nfunc f(a int) func() int {\n\tif a > 0 { return func() int { return a } }\n\treturn nil\n}\n\nfunc g() { x := }\n\nvar v = func() int { return 1 }\n"
+	p := NewWithMode(lexer.New(src), SkipFuncBodies)
+	file := p.ParseFile()
+	if errs := p.Errors(); len(errs) > 0 {
+		t.Fatalf("errors in skipped bodies reported: %q", errs)
+	}
+	if len(file.Decls) != 3 {
+		t.Fatalf("got %d declarations, want 3", len(file.Decls))
+	}
+	f, g := file.Decls[0].(*ast.FuncDecl), file.Decls[1].(*ast.FuncDecl)
+	for _, d := range []*ast.FuncDecl{f, g} {
+		if d.Body == nil || len(d.Body.Statements) != 0 || d.Body.Rbrace.Type != lexer.RBRACE {
+			t.Errorf("skipped body of %s = %v, want an empty block with its braces", d.Name.Value, d.Body)
+		}
+	}
+	// function literals outside function bodies are parsed
+	lit := file.Decls[2].(*ast.GenDecl).Specs[0].(*ast.ValueSpec).Values[0].(*ast.FuncLit)
+	if len(lit.Body.Statements) != 1 {
+		t.Errorf("function literal body has %d statements, want 1", len(lit.Body.Statements))
+	}
+
+	body := p.ParseBody(f)
+	if body != f.Body || len(body.Statements) != 2 {
+		t.Fatalf("ParseBody(f) gave %d statements, want 2", len(body.Statements))
+	}
+	var inner *ast.FuncLit
+	ast.Inspect(body, func(n ast.Node) bool {
+		if l, ok := n.(*ast.FuncLit); ok {
+			inner = l
+		}
+		return true
+	})
+	if inner == nil || len(inner.FreeVars) != 1 || inner.FreeVars[0] != f.Type.Params.List[0].Names[0] {
+		t.Error("closure in the parsed body does not capture the parameter a")
+	}
+	if again := p.ParseBody(f); again != body {
+		t.Error("ParseBody parsed a body twice")
+	}
+
+	p.ParseBody(g)
+	if len(p.Errors()) == 0 {
+		t.Error("ParseBody(g) reported no error")
+	}
+
+	p = NewWithMode(lexer.New("package p\nfunc f() {\n"), SkipFuncBodies)
+	p.ParseFile()
+	if len(p.Errors()) == 0 {
+		t.Error("no error for an unterminated skipped body")
+	}
+}
+
+func TestTrace(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	p := NewWithMode(lexer.New("1 + 2 * 3"), Trace)
+	p.ParseProgram()
+	os.Stdout = stdout
+	w.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	trace := string(out)
+	for _, want := range []string{
+		"    1: Statement int (\n",
+		"    1: . Expression LOWEST (\n",
+		"    1: . . prefix int \"1\"\n",
+		"    1: . . infix + \"+\"\n",
+		"    1: . . Expression SUM (\n",
+		"    1: . . . infix * \"*\"\n",
+		"    1: . . . Expression PRODUCT (\n",
+	} {
+		if !strings.Contains(trace, want) {
+			t.Errorf("trace has no line %q:\n%s", want, trace)
+		}
+	}
+	if strings.Count(trace, "(\n") != strings.Count(trace, ")\n") {
+		t.Errorf("unbalanced trace:\n%s", trace)
+	}
+
+	// without the mode nothing is printed
+	r, w, _ = os.Pipe()
+	os.Stdout = w
+	New(lexer.New("1 + 2")).ParseProgram()
+	os.Stdout = stdout
+	w.Close()
+	if out, _ := io.ReadAll(r); len(out) > 0 {
+		t.Errorf("untraced parse printed %q", out)
+	}
+}
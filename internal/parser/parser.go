@@ -1,6 +1,8 @@
 package parser
 
 import (
+	"fmt"
+
 	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
 	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
 )
@@ -11,23 +13,30 @@ const (
 	LOWEST
 	EQUALS      // == !=
 	LESSGREATER // < <= > >=
-	SUM         // + -
-	PRODUCT     // * /
-	PREFIX      // -X !X
+	SUM         // + - |
+	PRODUCT     // * / &
+	PREFIX      // -X !X &X *X <-X
 	GROUP       // ( ... )
+	CALL        // f(x) x.y a[i] s[lo:hi] x.(T)
 )
 
 var Precedences = map[lexer.TokenType]int{
-	lexer.EQ:       EQUALS,
-	lexer.NOT_EQ:   EQUALS,
-	lexer.LT:       LESSGREATER,
-	lexer.GT:       LESSGREATER,
-	lexer.LTE:      LESSGREATER,
-	lexer.GTE:      LESSGREATER,
-	lexer.PLUS:     SUM,
-	lexer.MINUS:    SUM,
-	lexer.SLASH:    PRODUCT,
-	lexer.ASTERISK: PRODUCT,
+	lexer.EQ:        EQUALS,
+	lexer.NOT_EQ:    EQUALS,
+	lexer.LT:        LESSGREATER,
+	lexer.GT:        LESSGREATER,
+	lexer.LTE:       LESSGREATER,
+	lexer.GTE:       LESSGREATER,
+	lexer.PLUS:      SUM,
+	lexer.MINUS:     SUM,
+	lexer.PIPE:      SUM,
+	lexer.SLASH:     PRODUCT,
+	lexer.ASTERISK:  PRODUCT,
+	lexer.AMPERSAND: PRODUCT,
+	lexer.PERIOD:    CALL,
+	lexer.LPAREN:    CALL,
+	lexer.LBRACKET:  CALL,
+	lexer.LBRACE:    CALL,
 }
 
 type (
@@ -36,7 +45,8 @@ type (
 )
 
 type Parser struct {
-	l *lexer.Lexer
+	l    tokenSource
+	mode Mode
 
 	curToken  lexer.Token
 	peekToken lexer.Token
@@ -45,11 +55,40 @@ type Parser struct {
 
 	prefixFns map[lexer.TokenType]prefixParseFn;
 	infixFns map[lexer.TokenType]infixParseFn;
+
+	inSwitchHeader bool // parsing the header of a switch; x.(type) is allowed
+	rangeOK        bool // parsing the header of a for loop; a range clause is allowed
+	exprLev        int  // < 0 in control clause headers, > 0 inside brackets and literals
+
+	topScope *scope                       // innermost scope of the function body being parsed; nil at package level
+	captures map[*ast.Identifier]capture // literals whose FreeVars gained a variable through this use
+
+	comments    []*ast.CommentGroup // all comment groups read so far
+	curDoc      *ast.CommentGroup   // lead comment of curToken; or nil
+	peekDoc     *ast.CommentGroup   // lead comment of peekToken; or nil
+	lineComment *ast.CommentGroup   // comment trailing curToken on the same line; or nil
+	lineSpec    ast.Spec            // spec ending at curToken, which takes the line comment of the semicolon after it; or nil
+
+	skipped map[*ast.FuncDecl][]lexer.Token // tokens of the bodies skipped in SkipFuncBodies mode
+	indent  int                             // nesting depth of the traced parse functions
+
+	nodeHook NodeHook // called for every completed node; or nil
+	prevEnd  int      // end offset of the token before curToken
 }
 
 func New(l *lexer.Lexer) *Parser{
+	return NewWithMode(l, 0)
+}
+
+// NewWithMode creates a Parser reading from l with the given mode flags.
+func NewWithMode(l *lexer.Lexer, mode Mode) *Parser {
+	return newParser(l, mode)
+}
+
+func newParser(l tokenSource, mode Mode) *Parser {
 	p := &Parser{
-		l: l,
+		l:    l,
+		mode: mode,
 
 		errors: []string{},
 		prefixFns: make(map[lexer.TokenType]prefixParseFn),
@@ -65,35 +104,448 @@ func New(l *lexer.Lexer) *Parser{
 	p.registerPrefix(lexer.INT, p.parseIntegerLiteral)
 	p.registerPrefix(lexer.FLOAT, p.parseFloatLiteral)
 	p.registerPrefix(lexer.STRING, p.parseStringLiteral)
-	// p.registerPrefix(lexer.BANG, p.parsePrefixExpression)
-	// p.registerPrefix(lexer.MINUS, p.parsePrefixExpression)
-	// p.registerPrefix(lexer.LPAREN, p.parseGroupedExpression)
+	p.registerPrefix(lexer.CHAR, p.parseRuneLiteral)
+	p.registerPrefix(lexer.BANG, p.parsePrefixExpression)
+	p.registerPrefix(lexer.MINUS, p.parsePrefixExpression)
+	p.registerPrefix(lexer.LPAREN, p.parseGroupedExpression)
+	p.registerPrefix(lexer.ASTERISK, p.parseStarExpression)
+	p.registerPrefix(lexer.FUNC, p.parseFuncLiteral)
+	p.registerPrefix(lexer.AMPERSAND, p.parsePrefixExpression)
+	p.registerPrefix(lexer.ARROW, p.parseReceiveExpression)
+	p.registerPrefix(lexer.LBRACKET, p.parseTypeExpression)
+	p.registerPrefix(lexer.MAP, p.parseTypeExpression)
+	p.registerPrefix(lexer.CHAN, p.parseTypeExpression)
+	p.registerPrefix(lexer.STRUCT, p.parseTypeExpression)
+	p.registerPrefix(lexer.INTERFACE, p.parseTypeExpression)
+
+	// register infix parse functions
+	for _, tt := range []lexer.TokenType{
+		lexer.EQ, lexer.NOT_EQ, lexer.LT, lexer.GT, lexer.LTE, lexer.GTE,
+		lexer.PLUS, lexer.MINUS, lexer.PIPE, lexer.SLASH, lexer.ASTERISK, lexer.AMPERSAND,
+	} {
+		p.registerInfix(tt, p.parseInfixExpression)
+	}
+	p.registerInfix(lexer.PERIOD, p.parseSelectorExpression)
+	p.registerInfix(lexer.LPAREN, p.parseCallExpression)
+	p.registerInfix(lexer.LBRACKET, p.parseIndexExpression)
+	p.registerInfix(lexer.LBRACE, p.parseCompositeLiteral)
 
 	return p;
 }
 
+// Errors returns the messages of all errors found while parsing.
+func (p *Parser) Errors() []string {
+	return p.errors
+}
+
+func (p *Parser) errorf(format string, args ...any) {
+	p.errors = append(p.errors, fmt.Sprintf(format, args...))
+}
+
 func (p *Parser) nextToken(){
+	p.prevEnd = p.curToken.End()
 	p.curToken = p.peekToken;
 	p.peekToken = p.l.NextToken();
+
+	p.curDoc, p.peekDoc, p.lineComment = p.peekDoc, nil, nil
+	if p.peekTokenIs(lexer.COMMENT) {
+		p.consumeComments()
+	}
+	if p.lineSpec != nil {
+		if p.curTokenIs(lexer.SEMICOLON) {
+			setSpecComments(p.lineSpec, nil, p.lineComment)
+		}
+		p.lineSpec = nil
+	}
 }
 
 func (p *Parser) registerPrefix(tt lexer.TokenType, fn prefixParseFn){
 	p.prefixFns[tt] = fn;
 }
 
+func (p *Parser) registerInfix(tt lexer.TokenType, fn infixParseFn) {
+	p.infixFns[tt] = fn
+}
+
+func (p *Parser) curTokenIs(tt lexer.TokenType) bool {
+	return p.curToken.Type == tt
+}
+
+func (p *Parser) peekTokenIs(tt lexer.TokenType) bool {
+	return p.peekToken.Type == tt
+}
+
+// expectPeek advances if the next token has type tt and records an error otherwise.
+func (p *Parser) expectPeek(tt lexer.TokenType) bool {
+	if p.peekTokenIs(tt) {
+		p.nextToken()
+		return true
+	}
+	p.peekError(tt)
+	return false
+}
+
+func (p *Parser) peekError(tt lexer.TokenType) {
+	p.errorf("expected next token to be %s, got %s instead", tt, p.peekToken.Type)
+}
+
+func (p *Parser) peekPrecedence() int {
+	if prec, ok := Precedences[p.peekToken.Type]; ok {
+		return prec
+	}
+	return LOWEST
+}
+
+func (p *Parser) curPrecedence() int {
+	if prec, ok := Precedences[p.curToken.Type]; ok {
+		return prec
+	}
+	return LOWEST
+}
+
+// ParseProgram parses statements until the end of input.
+func (p *Parser) ParseProgram() *ast.Program {
+	program := &ast.Program{}
+	p.openScope(nil)
+	defer p.closeScope()
+	for {
+		program.Statements = append(program.Statements, p.parseStatementList()...)
+		if p.curTokenIs(lexer.EOF) {
+			break
+		}
+		p.errorf("unexpected %s outside of a block", p.curToken.Type)
+		p.nextToken()
+	}
+	p.rejectFallthrough(program.Statements)
+	p.checkLabels(program.Statements)
+	return program
+}
+
+// parseExpression is the Pratt loop: it parses a prefix expression and then
+// folds in infix operators that bind tighter than precedence.
+// The current token is left on the last token of the expression.
+func (p *Parser) parseExpression(precedence int) ast.Expression {
+	if p.mode&Trace != 0 {
+		defer un(trace(p, "Expression "+precedenceNames[precedence]))
+		p.printTrace("prefix %s %q", p.curToken.Type, p.curToken.Literal)
+	}
+	prefix := p.prefixFns[p.curToken.Type]
+	if prefix == nil {
+		p.errorf("no prefix parse function for %s found", p.curToken.Type)
+		return nil
+	}
+	start := p.curToken.Offset
+	left := prefix()
+	p.reportNode(left, start)
+
+	for !p.peekTokenIs(lexer.SEMICOLON) && precedence < p.peekPrecedence() {
+		if p.peekTokenIs(lexer.LBRACE) && !p.isLiteralType(left) {
+			return left
+		}
+		infix := p.infixFns[p.peekToken.Type]
+		if infix == nil {
+			return left
+		}
+		p.nextToken()
+		if p.mode&Trace != 0 {
+			p.printTrace("infix %s %q", p.curToken.Type, p.curToken.Literal)
+		}
+		left = infix(left)
+		p.reportNode(left, start)
+	}
+	return left
+}
+
+// parseExpressionList parses a comma-separated list of expressions.
+func (p *Parser) parseExpressionList() []ast.Expression {
+	list := []ast.Expression{p.parseExpression(LOWEST)}
+	for p.peekTokenIs(lexer.COMMA) {
+		p.nextToken()
+		p.nextToken()
+		list = append(list, p.parseExpression(LOWEST))
+	}
+	return list
+}
 // prefix parse functions
 func (p *Parser) parseIdentifier() ast.Expression {
-	return &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+	ident := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+	p.recordUse(ident)
+	return ident
 }
 
-func (p *Parser) parseIntegerLiteral() ast.Expression {
-	return &ast.IntegerLiteral{Token: p.curToken, Value: p.curToken.Literal}
+func (p *Parser) parsePrefixExpression() ast.Expression {
+	expr := &ast.PrefixExpression{Token: p.curToken, Operator: p.curToken.Literal}
+	p.nextToken()
+	expr.Right = p.parseExpression(PREFIX)
+	return expr
 }
 
-func (p *Parser) parseFloatLiteral() ast.Expression {
-	return &ast.FloatLiteral{Token: p.curToken, Value: p.curToken.Literal}
+// parseReceiveExpression parses the receive operation <-ch. When `<-` is
+// followed by `chan` it instead starts a receive-only channel type, as in
+// the conversion (<-chan int)(ch).
+func (p *Parser) parseReceiveExpression() ast.Expression {
+	if p.peekTokenIs(lexer.CHAN) {
+		return p.parseChanType()
+	}
+	return p.parsePrefixExpression()
 }
 
-func (p *Parser) parseStringLiteral() ast.Expression {
-	return &ast.StringLiteral{Token: p.curToken, Value: p.curToken.Literal}
-}
\ No newline at end of file
+// parseStarExpression parses the pointer indirection *x.
+func (p *Parser) parseStarExpression() ast.Expression {
+	expr := &ast.StarExpr{Token: p.curToken}
+	p.nextToken()
+	expr.X = p.parseExpression(PREFIX)
+	return expr
+}
+
+// parseFuncLiteral parses a function literal. A signature that is not followed
+// by a body is a function type used as an expression, as in a conversion.
+func (p *Parser) parseFuncLiteral() ast.Expression {
+	tok := p.curToken
+	if !p.expectPeek(lexer.LPAREN) {
+		return nil
+	}
+	typ := p.parseSignature(tok)
+	if !p.peekTokenIs(lexer.LBRACE) {
+		return typ
+	}
+
+	lit := &ast.FuncLit{Token: tok, Type: typ}
+	p.nextToken()
+	p.openScope(lit)
+	p.declareFields(typ.Params)
+	p.declareFields(typ.Results)
+	outer := p.exprLev
+	p.exprLev = 0
+	lit.Body = p.parseFuncBody()
+	p.exprLev = outer
+	p.closeScope()
+	return lit
+}
+
+func (p *Parser) parseGroupedExpression() ast.Expression {
+	expr := &ast.ParenExpr{Token: p.curToken}
+	p.exprLev++
+	defer func() { p.exprLev-- }()
+	p.nextToken()
+	expr.X = p.parseExpression(LOWEST)
+	if !p.expectPeek(lexer.RPAREN) {
+		return nil
+	}
+	expr.Rparen = p.curToken
+	return expr
+}
+
+// infix parse functions
+func (p *Parser) parseInfixExpression(left ast.Expression) ast.Expression {
+	expr := &ast.InfixExpression{Token: p.curToken, Operator: p.curToken.Literal, Left: left}
+	precedence := p.curPrecedence()
+	p.nextToken()
+	expr.Right = p.parseExpression(precedence)
+	return expr
+}
+
+// parseCallExpression parses the argument list of a call; a trailing comma is
+// allowed and the final argument may be spread with `...`.
+func (p *Parser) parseCallExpression(fn ast.Expression) ast.Expression {
+	expr := &ast.CallExpr{Token: p.curToken, Fun: fn}
+	p.exprLev++
+	defer func() { p.exprLev-- }()
+	p.nextToken()
+	for !p.curTokenIs(lexer.RPAREN) {
+		if expr.Ellipsis.Type != "" {
+			p.errorf("can only use ... with final argument in list")
+		}
+		expr.Args = append(expr.Args, p.parseTypeOrExpression())
+		if p.peekTokenIs(lexer.ELLIPSIS) {
+			p.nextToken()
+			expr.Ellipsis = p.curToken
+		}
+		if p.peekTokenIs(lexer.COMMA) {
+			p.nextToken()
+			p.nextToken()
+			continue
+		}
+		if !p.expectPeek(lexer.RPAREN) {
+			return nil
+		}
+		break
+	}
+	expr.Rparen = p.curToken
+	return expr
+}
+
+// parseSelectorExpression parses what follows a `.`: a selector x.y, a type
+// assertion x.(T) or the x.(type) guard of a type switch.
+func (p *Parser) parseSelectorExpression(left ast.Expression) ast.Expression {
+	tok := p.curToken
+	if p.peekTokenIs(lexer.IDENT) {
+		p.nextToken()
+		sel := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+		return &ast.SelectorExpr{Token: tok, X: left, Sel: sel}
+	}
+
+	if !p.expectPeek(lexer.LPAREN) {
+		return nil
+	}
+	expr := &ast.TypeAssertExpr{Token: tok, X: left}
+	p.nextToken()
+	if p.curTokenIs(lexer.TYPE) {
+		if !p.inSwitchHeader {
+			p.errorf("use of .(type) outside type switch")
+		}
+	} else {
+		expr.Type = p.parseType()
+	}
+	if !p.expectPeek(lexer.RPAREN) {
+		return nil
+	}
+	expr.Rparen = p.curToken
+	return expr
+}
+
+// parseIndexExpression parses an index a[i], a slice s[lo:hi] or s[lo:hi:max],
+// or an instantiation F[T] or F[K, V].
+func (p *Parser) parseIndexExpression(left ast.Expression) ast.Expression {
+	tok := p.curToken
+	p.exprLev++
+	defer func() { p.exprLev-- }()
+
+	var index [3]ast.Expression
+	colons := 0
+	p.nextToken()
+	if !p.curTokenIs(lexer.COLON) {
+		index[0] = p.parseTypeOrExpression()
+		p.nextToken()
+	}
+
+	for p.curTokenIs(lexer.COLON) && colons < 2 {
+		colons++
+		p.nextToken()
+		if !p.curTokenIs(lexer.COLON) && !p.curTokenIs(lexer.RBRACKET) {
+			index[colons] = p.parseExpression(LOWEST)
+			p.nextToken()
+		}
+	}
+
+	if colons > 0 {
+		if !p.curTokenIs(lexer.RBRACKET) {
+			p.errorf("expected ], got %s instead", p.curToken.Type)
+			return nil
+		}
+		expr := &ast.SliceExpr{Token: tok, X: left, Low: index[0], High: index[1], Max: index[2], Slice3: colons == 2, Rbrack: p.curToken}
+		if expr.Slice3 {
+			if expr.High == nil {
+				p.errorf("middle index required in 3-index slice")
+			}
+			if expr.Max == nil {
+				p.errorf("final index required in 3-index slice")
+			}
+		}
+		return expr
+	}
+
+	if p.curTokenIs(lexer.COMMA) {
+		list := &ast.IndexListExpr{Token: tok, X: left, Indices: []ast.Expression{index[0]}}
+		for p.curTokenIs(lexer.COMMA) {
+			p.nextToken()
+			if p.curTokenIs(lexer.RBRACKET) {
+				break
+			}
+			list.Indices = append(list.Indices, p.parseType())
+			p.nextToken()
+		}
+		if !p.curTokenIs(lexer.RBRACKET) {
+			p.errorf("expected ], got %s instead", p.curToken.Type)
+			return nil
+		}
+		list.Rbrack = p.curToken
+		return list
+	}
+
+	if !p.curTokenIs(lexer.RBRACKET) {
+		p.errorf("expected ], got %s instead", p.curToken.Type)
+		return nil
+	}
+	if index[0] == nil {
+		p.errorf("expected operand")
+	}
+	return &ast.IndexExpr{Token: tok, X: left, Index: index[0], Rbrack: p.curToken}
+}
+
+// parseCompositeLiteral parses the braced elements of a composite literal;
+// typ is nil for an elided inner literal such as the {1} in [][]int{{1}}.
+func (p *Parser) parseCompositeLiteral(typ ast.Expression) ast.Expression {
+	lit := &ast.CompositeLit{Token: p.curToken, Type: typ}
+	p.exprLev++
+	defer func() { p.exprLev-- }()
+
+	_, isMap := typ.(*ast.MapType)
+	p.nextToken()
+	for !p.curTokenIs(lexer.RBRACE) {
+		lit.Elts = append(lit.Elts, p.parseElement(isMap))
+		if p.peekTokenIs(lexer.COMMA) {
+			p.nextToken()
+			p.nextToken()
+			continue
+		}
+		if !p.expectPeek(lexer.RBRACE) {
+			return nil
+		}
+		break
+	}
+	lit.Rbrace = p.curToken
+	return lit
+}
+
+// parseElement parses a composite literal element, either a value or a
+// key: value pair. Unless the literal is known to be a map, a key that is a
+// bare identifier may name a struct field rather than a variable, so it is
+// not recorded as a use.
+func (p *Parser) parseElement(isMap bool) ast.Expression {
+	x := p.parseElementValue()
+	if !p.peekTokenIs(lexer.COLON) {
+		return x
+	}
+	if ident, ok := x.(*ast.Identifier); ok && !isMap {
+		p.forgetUse(ident)
+	}
+	p.nextToken()
+	kv := &ast.KeyValueExpr{Token: p.curToken, Key: x}
+	p.nextToken()
+	kv.Value = p.parseElementValue()
+	return kv
+}
+
+// parseElementValue parses an element key or value, which may be a literal
+// with its type elided.
+func (p *Parser) parseElementValue() ast.Expression {
+	if p.curTokenIs(lexer.LBRACE) {
+		return p.parseCompositeLiteral(nil)
+	}
+	return p.parseExpression(LOWEST)
+}
+
+// isLiteralType reports whether x followed by `{` starts a composite literal.
+// In the header of an if, for or switch statement (exprLev < 0) the brace
+// after a type name opens the statement's block instead, so `if x == T {}`
+// parses as expected; such literals must be parenthesized there.
+func (p *Parser) isLiteralType(x ast.Expression) bool {
+	switch t := x.(type) {
+	case *ast.IndexExpr:
+		x = t.X
+	case *ast.IndexListExpr:
+		x = t.X
+	case *ast.ArrayType, *ast.MapType, *ast.StructType:
+		return true
+	}
+
+	switch t := x.(type) {
+	case *ast.Identifier:
+		return p.exprLev >= 0
+	case *ast.SelectorExpr:
+		_, ok := t.X.(*ast.Identifier)
+		return ok && p.exprLev >= 0
+	}
+	return false
+}
@@ -0,0 +1,345 @@
+package parser
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+)
+
+// parseSource parses src as a file and returns the parser's errors. It fails
+// the test if the parser does not return within a second, so that a parser
+// that stops making progress fails instead of hanging the test binary.
+func parseSource(t *testing.T, src string) []string {
+	t.Helper()
+	done := make(chan []string, 1)
+	go func() {
+		p := New(lexer.New(src))
+		p.ParseFile()
+		done <- p.Errors()
+	}()
+	select {
+	case errs := <-done:
+		return errs
+	case <-time.After(time.Second):
+		t.Fatalf("parsing %q does not terminate", src)
+		return nil
+	}
+}
+
+// checkSources checks that each of accepted parses without errors, and that
+// each of rejected reports at least one.
+func checkSources(t *testing.T, accepted, rejected []string) {
+	t.Helper()
+	for _, src := range accepted {
+		if errs := parseSource(t, src); len(errs) > 0 {
+			t.Errorf("%q: unexpected errors %q", src, errs)
+		}
+	}
+	for _, src := range rejected {
+		if errs := parseSource(t, src); len(errs) == 0 {
+			t.Errorf("%q: no errors, want some", src)
+		}
+	}
+}
+
+func TestCaseClauseRecovery(t *testing.T) {
+	checkSources(t, nil, []string{
+		"package s; func f(){switch{default}}",
+		"package s; func f(){switch{case default}}",
+		"package s; func f(){switch x {case 1 default:}}",
+		"package s; func f(){switch x.(type) {case int}}",
+	})
+}
+
+func TestCommClauseRecovery(t *testing.T) {
+	checkSources(t, nil, []string{
+		"package s; func f(){select{default}}",
+		"package s; func f(){select{case default}}",
+		"package s; func f(c chan int){select{case <-c default:}}",
+	})
+}
+
+func TestSwitchStatements(t *testing.T) {
+	checkSources(t, []string{
+		"package s; func f(x int) { switch x { case 1, 2: case 3: default: } }",
+		"package s; func f(x int) { switch { case x > 0: } }",
+		"package s; func f() { switch x := 1; x { case 1: } }",
+		"package s; func f() { switch x := 1; { case x > 0: } }",
+		"package s; func f(x any) { switch v := x.(type) { case int, string: _ = v; case nil: default: } }",
+		"package s; func f(x any) { switch x.(type) {} }",
+		"package s; func f(x any) { switch y := 1; v := x.(type) { case int: _, _ = v, y } }",
+		"package s; func f(x int) { switch x { case 1: fallthrough; case 2: } }",
+		"package s; func f(x int) { switch x { case 1: x++; fallthrough; default: } }",
+		"package s; func f(x any) { switch v := x; v.(type) {} }",
+	}, []string{
+		"package s; func f(x int) { switch x { case 1: { fallthrough }; case 2: } }",
+		"package s; func f(x int) { switch x { case 2: fallthrough } }",
+		"package s; func f(x int) { switch x { case 1: fallthrough; x++; case 2: } }",
+		"package s; func f(x any) { switch x.(type) { case int: fallthrough; case string: } }",
+		"package s; func f() { fallthrough }",
+		"package s; func f(x int) { switch x { default: default: } }",
+		"package s; func f(x int) { _ = x.(type) }",
+		"package s; func f(x int) { switch x { x++ } }",
+	})
+}
+
+func TestFuncDecls(t *testing.T) {
+	checkSources(t, []string{
+		"package s; func f() {}",
+		"package s; func f(a, b int, c string) {}",
+		"package s; func f(int, string) {}",
+		"package s; func f(a ...int) {}",
+		"package s; func f(a int, b ...[]string) {}",
+		"package s; func f(...int) {}",
+		"package s; func f(a, ...int) {}", // a is a type
+		"package s; func f() int { return 1 }",
+		"package s; func f() (int, error) { return 1, nil }",
+		"package s; func f() (q, r int) { return }",
+		"package s; func f() (q int, err error) { q, err = 1, nil; return q, err }",
+		"package s; func f() func() int { return nil }",
+		"package s; func f(g func(int) (int, bool)) {}",
+		"package s; func f()",
+		"package s; func f(a, b int,) {}",
+	}, []string{
+		"package s; func f(a ...int, b int) {}",
+		"package s; func f() (...int) {}",
+		"package s; func f(a int, string) {}",
+		"package s; func f() (q int, error) {}",
+		"package s; func () {}",
+		"package s; func f(a int {}",
+		"package s; func f() { return 1, }",
+	})
+}
+
+func TestMethodDecls(t *testing.T) {
+	checkSources(t, []string{
+		"package s; func (s Stack) Len() int { return 0 }",
+		"package s; func (s *Stack) Push(v int) {}",
+		"package s; func (Stack) Len() int { return 0 }",
+		"package s; func (*Stack) Len() int { return 0 }",
+		"package s; func (l *List[T]) Len() int { return 0 }",
+		"package s; func (m Map[K, V]) Get(k K) V { var v V; return v }",
+		"package s; func (l List[_]) Len() int { return 0 }",
+		"package s; func (s *Stack,) Len() {}",
+	}, []string{
+		"package s; func () Len() {}",
+		"package s; func (a, b Stack) Len() {}",
+		"package s; func (a Stack, b Stack) Len() {}",
+		"package s; func (s ...Stack) Len() {}",
+		"package s; func (s Stack) () {}",
+		"package s; func (s Stack) Len[T any]() {}",
+	})
+}
+
+func TestRecvBase(t *testing.T) {
+	tests := []struct {
+		src     string
+		name    string // "" for none
+		pointer bool
+		params  string
+	}{
+		{"package s; func (s Stack) Len() {}", "Stack", false, ""},
+		{"package s; func (s *Stack) Len() {}", "Stack", true, ""},
+		{"package s; func (l *List[T]) Len() {}", "List", true, "T"},
+		{"package s; func (m Map[K, V]) Len() {}", "Map", false, "K V"},
+		{"package s; func (s (*Stack)) Len() {}", "Stack", true, ""},
+		{"package s; func f() {}", "", false, ""},
+	}
+	for _, tt := range tests {
+		p := New(lexer.New(tt.src))
+		file := p.ParseFile()
+		if errs := p.Errors(); len(errs) > 0 {
+			t.Fatalf("%q: parse errors %q", tt.src, errs)
+		}
+		name, pointer, params := file.Decls[0].(*ast.FuncDecl).RecvBase()
+		var got, names string
+		if name != nil {
+			got = name.Value
+		}
+		for i, param := range params {
+			if i > 0 {
+				names += " "
+			}
+			names += param.Value
+		}
+		if got != tt.name || pointer != tt.pointer || names != tt.params {
+			t.Errorf("%q: RecvBase() = %q, %v, %q, want %q, %v, %q", tt.src, got, pointer, names, tt.name, tt.pointer, tt.params)
+		}
+	}
+}
+
+func TestPostfixExprs(t *testing.T) {
+	checkSources(t, []string{
+		"package s; func f() { g(); g(1, 2); g(1, 2,); g(xs...) }",
+		"package s; func f() { _ = a.b.c; a.b(1).c() }",
+		"package s; func f() { _ = a[i]; _ = a[i][j]; _ = m[k].f }",
+		"package s; func f() { _ = F[int]; _ = F[int, string](1); _ = G[[]int, map[K]V] }",
+		"package s; func f() { _ = s[:]; _ = s[1:]; _ = s[:2]; _ = s[1:2]; _ = s[1:2:3]; _ = s[:2:3] }",
+		"package s; func f() { _ = x.(T); _, ok := x.(*p.T); _ = x.(interface{ M() }).M() }",
+		"package s; func f() { _ = -g(1)[2].x; _ = (a + b).c }",
+	}, []string{
+		"package s; func f() { g(1 }",
+		"package s; func f() { g(xs..., 1) }",
+		"package s; func f() { _ = a. }",
+		"package s; func f() { _ = a[] }",
+		"package s; func f() { _ = s[1:2:] }",
+		"package s; func f() { _ = s[::3] }",
+		"package s; func f() { _ = s[1::3] }",
+		"package s; func f() { _ = s[1:2:3:4] }",
+		"package s; func f() { _ = x.() }",
+	})
+}
+
+// TestPostfixPrecedence checks that postfix expressions bind tighter than
+// unary and binary operators.
+func TestPostfixPrecedence(t *testing.T) {
+	tests := []struct{ src, want string }{
+		{"-a.b[c](d)", "(-a.b[c](d))"},
+		{"a + b.c * d[1]", "(a + (b.c * d[1]))"},
+		{"-s[1:2]", "(-s[1:2])"},
+		{"x.(T).y", "x.(T).y"},
+		{"!f()", "(!f())"},
+	}
+	for _, tt := range tests {
+		p := New(lexer.New(tt.src))
+		prog := p.ParseProgram()
+		if errs := p.Errors(); len(errs) > 0 {
+			t.Fatalf("%q: parse errors %q", tt.src, errs)
+		}
+		if got := prog.String(); got != tt.want {
+			t.Errorf("%q parses as %s, want %s", tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestCompositeLits(t *testing.T) {
+	checkSources(t, []string{
+		"package s; var _ = Point{X: 1, Y: 2}",
+		"package s; var _ = Point{1, 2}",
+		"package s; var _ = []int{1, 2, 3,}",
+		"package s; var _ = [...]string{\"a\"}",
+		"package s; var _ = [2]int{1: 5}",
+		"package s; var _ = map[string]int{\"a\": 1}",
+		"package s; var _ = [][]int{{1}, {2}}",
+		"package s; var _ = map[Point]string{{1, 2}: \"a\"}",
+		"package s; var _ = &T{}",
+		"package s; var _ = []*T{{}, &T{}}",
+		"package s; var _ = p.T{}",
+		"package s; var _ = G[int]{}",
+		"package s; var _ = struct{ X int }{1}",
+		// a literal in a control clause header must be parenthesized,
+		// but brackets restore the expression level
+		"package s; func f() { if x := (T{}); x.ok {} }",
+		"package s; func f() { for _, v := range []int{1, 2} { _ = v } }",
+		"package s; func f() { if f(T{}) {} }",
+		"package s; func f() { switch x := []int{1}; len(x) {} }",
+		"package s; func f() { if x == y {} }",
+	}, []string{
+		"package s; var _ = Point{X: 1",
+		"package s; var _ = []int{1 2}",
+		"package s; var _ = map[string]int{\"a\"; 1}",
+		"package s; func f() { if x := T{}; x.ok {} }",
+		"package s; func f() { for x == T{} {} }",
+		"package s; func f() { switch T{} {} }",
+	})
+}
+
+func TestTypeExprs(t *testing.T) {
+	checkSources(t, []string{
+		"package s; var _ *T; var _ **p.T",
+		"package s; var _ [4]int; var _ [N * 2][]string; var _ [][2]*T",
+		"package s; var _ map[string][]int; var _ map[K]map[K]V",
+		"package s; var _ chan int; var _ <-chan int; var _ chan<- int",
+		"package s; var _ chan<- chan int; var _ chan (<-chan int); var _ <-chan <-chan int",
+		"package s; var _ func(); var _ func(int, ...string) (bool, error); var _ func() func() int",
+		"package s; var _ struct{}",
+		"package s; var _ struct { X, Y int; Z string `json:\"z\"`; T; *U; p.V; W[int] }",
+		"package s; var _ interface{}",
+		"package s; var _ interface { M(int) bool; N() }",
+		"package s; var _ interface { io.Reader; fmt.Stringer; M() }",
+		"package s; type C interface { ~int | ~string | float64 }",
+		"package s; type C interface { ~[]byte | string; comparable }",
+	}, []string{
+		"package s; var _ map[string]",
+		"package s; var _ [4]",
+		"package s; var _ chan",
+		"package s; var _ struct { X int Y int }",
+		"package s; var _ struct { X int `a` `b` }",
+		"package s; var _ struct { *[]int }",
+		"package s; var _ interface { M( }",
+		"package s; type C interface { ~ }",
+		"package s; type C interface { int | }",
+		"package s; var _ [...]int",
+	})
+}
+
+func TestTypeDecls(t *testing.T) {
+	checkSources(t, []string{
+		"package s; type T struct{ X int }",
+		"package s; type A = B",
+		"package s; type A = map[string]int",
+		"package s; type ( T int; U = T; V[P any] []P )",
+		"package s; type Pair[K comparable, V any] struct { K K; V V }",
+		"package s; type L[T any] struct { next *L[T] }",
+		"package s; type S[P *C] struct{}",
+		"package s; type S[P *C,] struct{}",
+		"package s; type S[P interface{ ~int }] struct{}",
+		"package s; type S[P ~int | ~string] []P",
+		"package s; type A[T any] = []T",
+		// array lengths, not type parameters
+		"package s; type A [N]int",
+		"package s; type A [N * M]int",
+		"package s; type A [P * C]int",
+		"package s; type A [2]int",
+		"package s; type A[T] int",
+		"package s; type A[] int", // a slice type
+		"package s; func F[T any](x T) T { return x }",
+		"package s; func F[K comparable, V any, M ~map[K]V](m M) {}",
+		"package s; func F[S ~[]E, E any]() {}",
+	}, []string{
+		"package s; type T",
+		"package s; type = int",
+		"package s; type A[T any int",
+		"package s; func F[]() {}",
+		"package s; func F[T]() {}",
+		"package s; func F[T any, U]() {}",
+		"package s; type ( T int U int )",
+	})
+}
+
+func TestLabels(t *testing.T) {
+	tests := []struct {
+		src  string
+		want []string
+	}{
+		{"package s; func f() { L: for { break L } }", nil},
+		{"package s; func f() { L: for { continue L } }", nil},
+		{"package s; func f() { L: switch { default: break L } }", nil},
+		{"package s; func f(c chan int) { L: select { case <-c: break L } }", nil},
+		{"package s; func f() { goto L; L: }", nil},
+		{"package s; func f() { L: ; goto L }", nil},
+		{"package s; func f() { for { break }; switch { default: break } }", nil},
+		{"package s; func f() { goto L; { var x int; _ = x }; L: }", nil},
+		{"package s; func f() { goto L }", []string{"label L not defined"}},
+		{"package s; func f() { for { break L } }", []string{"label L not defined"}},
+		{"package s; func f() { L: }", []string{"label L defined and not used"}},
+		{"package s; func f() { L: ; L: ; goto L }", []string{"label L already defined"}},
+		{"package s; func f() { { L: for { break L } }; { L: for { break L } } }", []string{"label L already defined"}},
+		{"package s; func f() { L: { break L } }", []string{"invalid break label L"}},
+		{"package s; func f() { L: switch { default: continue L } }", []string{"invalid continue label L"}},
+		{"package s; func f() { goto L; x := 1; _ = x; L: }", []string{"goto L jumps over variable declaration"}},
+		{"package s; func f() { goto L; { L: } }", []string{"goto L jumps into block"}},
+		{"package s; func f() { break }", []string{"break is not in a loop, switch, or select"}},
+		{"package s; func f() { switch { default: continue } }", []string{"continue is not in a loop"}},
+		{"package s; func f() { for { func() { break }() } }", []string{"break is not in a loop, switch, or select"}},
+		{"package s; func f() { L: for { func() { goto L }() } }", []string{"label L not defined", "label L defined and not used"}},
+	}
+	for _, tt := range tests {
+		if errs := parseSource(t, tt.src); !slices.Equal(errs, tt.want) {
+			t.Errorf("%q: errors %q, want %q", tt.src, errs, tt.want)
+		}
+	}
+}
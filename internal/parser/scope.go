@@ -0,0 +1,123 @@
+package parser
+
+import (
+	"slices"
+
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+)
+
+// scope holds the names declared in one block of a function body. The parser
+// only tracks names and their declaring identifiers, which is enough to find
+// the variables a function literal captures from its enclosing functions.
+type scope struct {
+	outer *scope
+	names map[string]*ast.Identifier // the declaring identifier of a variable; nil for a constant or type
+	lit   *ast.FuncLit               // set on the outermost scope of a function literal
+}
+
+// A capture records the function literals whose FreeVars gained the
+// variable declared by decl through one use.
+type capture struct {
+	decl *ast.Identifier
+	lits []*ast.FuncLit
+}
+
+func (p *Parser) openScope(lit *ast.FuncLit) {
+	p.topScope = &scope{outer: p.topScope, names: make(map[string]*ast.Identifier), lit: lit}
+}
+
+func (p *Parser) closeScope() {
+	p.topScope = p.topScope.outer
+}
+
+// declare adds variables to the innermost scope. Outside function bodies there
+// is no scope and package-level names are never captured, so nothing is
+// recorded.
+func (p *Parser) declare(idents ...*ast.Identifier) {
+	p.declareNames(true, idents)
+}
+
+// declareNonVars adds constants or types to the innermost scope. They shadow
+// the variables of outer scopes, but are not captured themselves.
+func (p *Parser) declareNonVars(idents ...*ast.Identifier) {
+	p.declareNames(false, idents)
+}
+
+func (p *Parser) declareNames(vars bool, idents []*ast.Identifier) {
+	if p.topScope == nil {
+		return
+	}
+	for _, ident := range idents {
+		if ident != nil && ident.Value != "_" {
+			if vars {
+				p.topScope.names[ident.Value] = ident
+			} else {
+				p.topScope.names[ident.Value] = nil
+			}
+		}
+	}
+}
+
+// declareFields declares the names of parameters, results or a receiver.
+func (p *Parser) declareFields(list *ast.FieldList) {
+	if list == nil {
+		return
+	}
+	for _, f := range list.List {
+		p.declare(f.Names...)
+	}
+}
+
+// declareTypeParams declares the names of type parameters.
+func (p *Parser) declareTypeParams(list *ast.FieldList) {
+	if list == nil {
+		return
+	}
+	for _, f := range list.List {
+		p.declareNonVars(f.Names...)
+	}
+}
+
+// recordUse resolves a use of ident against the enclosing scopes. When the
+// name is a variable declared outside one or more function literals, each of
+// them captures it and gets its declaring identifier added to its FreeVars.
+func (p *Parser) recordUse(ident *ast.Identifier) {
+	var lits []*ast.FuncLit
+	for s := p.topScope; s != nil; s = s.outer {
+		if decl, ok := s.names[ident.Value]; ok {
+			if decl == nil {
+				return // a constant or type
+			}
+			c := capture{decl: decl}
+			for _, lit := range lits {
+				if !slices.Contains(lit.FreeVars, decl) {
+					lit.FreeVars = append(lit.FreeVars, decl)
+					c.lits = append(c.lits, lit)
+				}
+			}
+			if len(c.lits) > 0 {
+				if p.captures == nil {
+					p.captures = make(map[*ast.Identifier]capture)
+				}
+				p.captures[ident] = c
+			}
+			return
+		}
+		if s.lit != nil {
+			lits = append(lits, s.lit)
+		}
+	}
+}
+
+// forgetUse undoes recordUse for an identifier that turned out not to be a
+// use, such as the left-hand side of := or a field name in a struct literal.
+func (p *Parser) forgetUse(ident *ast.Identifier) {
+	c := p.captures[ident]
+	for _, lit := range c.lits {
+		lit.FreeVars = slices.DeleteFunc(lit.FreeVars, func(decl *ast.Identifier) bool { return decl == c.decl })
+		if len(lit.FreeVars) == 0 {
+			lit.FreeVars = nil
+		}
+	}
+	delete(p.captures, ident)
+}
@@ -0,0 +1,64 @@
+package parser
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+)
+
+// TestFreeVars checks the free variables of each function literal, given as
+// name:line of the declaring identifiers.
+func TestFreeVars(t *testing.T) {
+	tests := []struct {
+		name, src string
+		want      [][]string // per function literal, in source order
+	}{
+		{"use", "x := 1\nf := func() { _ = x }\n", [][]string{{"x:1"}}},
+		{"declared inside", "x := 1\nf := func() { x := 2; _ = x }\n", [][]string{nil}},
+		{"struct key", "X := 1\ny := 2\nf := func() { _ = Point{X: 3}; _ = y }\n", [][]string{{"y:2"}}},
+		{"struct key then use", "X := 1\nf := func() { _ = Point{X: 3}; _ = X }\n", [][]string{{"X:1"}}},
+		{"elided struct key", "X := 1\nf := func() { _ = []Point{{X: 3}} }\n", [][]string{nil}},
+		{"map key", "k := \"a\"\nf := func() { _ = map[string]int{k: 1} }\n", [][]string{{"k:1"}}},
+		{"struct value", "v := 1\nf := func() { _ = Point{X: v} }\n", [][]string{{"v:1"}}},
+		{"parameter", "func(a int) {\n\tf := func() { _ = a }\n\t_ = f\n}(1)\n", [][]string{nil, {"a:1"}}},
+		{
+			"shadowed",
+			"x := 1\nf := func() {\n\t_ = x\n\tx := 2\n\tg := func() { _ = x }\n\t_ = g\n}\n",
+			[][]string{{"x:1"}, {"x:4"}},
+		},
+		{
+			"nested",
+			"x := 1\nf := func() {\n\tg := func() { _ = x }\n\t_ = g\n}\n",
+			[][]string{{"x:1"}, {"x:1"}},
+		},
+		{"local type", "type T int\nf := func() { _ = T(1) }\n", [][]string{nil}},
+		{"local constant", "const c = 1\nf := func() { _ = c }\n", [][]string{nil}},
+		{"constant shadows variable", "c := 1\nf := func() {\n\tconst c = 2\n\tg := func() { _ = c }\n\t_ = g\n}\n", [][]string{nil, nil}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(lexer.New(tt.src))
+			prog := p.ParseProgram()
+			if errs := p.Errors(); len(errs) > 0 {
+				t.Fatalf("parse errors: %v", errs)
+			}
+			var got [][]string
+			ast.Inspect(prog, func(n ast.Node) bool {
+				if lit, ok := n.(*ast.FuncLit); ok {
+					var vars []string
+					for _, decl := range lit.FreeVars {
+						vars = append(vars, fmt.Sprintf("%s:%d", decl.Value, decl.Token.Line))
+					}
+					got = append(got, vars)
+				}
+				return true
+			})
+			if !slices.EqualFunc(got, tt.want, slices.Equal) {
+				t.Errorf("FreeVars = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,615 @@
+package parser
+
+import (
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+)
+
+// parseStatementList parses statements up to a closing brace, the next case
+// clause or the end of input. The current token is left on that terminator.
+func (p *Parser) parseStatementList() []ast.Statement {
+	list := []ast.Statement{}
+	for !p.curTokenIs(lexer.RBRACE) && !p.curTokenIs(lexer.EOF) &&
+		!p.curTokenIs(lexer.CASE) && !p.curTokenIs(lexer.DEFAULT) {
+		if p.curTokenIs(lexer.SEMICOLON) {
+			p.nextToken()
+			continue
+		}
+		if stmt := p.parseStatement(); stmt != nil {
+			list = append(list, stmt)
+		}
+		p.expectStatementEnd()
+		p.nextToken()
+	}
+	return list
+}
+
+// expectStatementEnd consumes the semicolon that terminates a statement.
+// It may be omitted before a closing brace.
+func (p *Parser) expectStatementEnd() {
+	switch p.peekToken.Type {
+	case lexer.SEMICOLON:
+		p.nextToken()
+	case lexer.RBRACE, lexer.EOF:
+	default:
+		p.errorf("expected ; or newline after statement, got %s instead", p.peekToken.Type)
+	}
+}
+
+// parseStatement parses a single statement starting at the current token and
+// leaves the current token on its last token.
+func (p *Parser) parseStatement() (stmt ast.Statement) {
+	defer deferNode(p, p.curToken.Offset, &stmt)
+	if p.mode&Trace != 0 {
+		defer un(trace(p, "Statement "+string(p.curToken.Type)))
+	}
+	switch p.curToken.Type {
+	case lexer.IF:
+		return p.parseIfStatement()
+	case lexer.FOR:
+		return p.parseForStatement()
+	case lexer.SWITCH:
+		return p.parseSwitchStatement()
+	case lexer.SELECT:
+		return p.parseSelectStatement()
+	case lexer.GO:
+		if call := p.parseCallStatement("go"); call != nil {
+			return &ast.GoStmt{Token: call.Token, Call: call.Call}
+		}
+		return nil
+	case lexer.DEFER:
+		if call := p.parseCallStatement("defer"); call != nil {
+			return &ast.DeferStmt{Token: call.Token, Call: call.Call}
+		}
+		return nil
+	case lexer.FALLTHROUGH:
+		return &ast.BranchStmt{Token: p.curToken}
+	case lexer.BREAK, lexer.CONTINUE, lexer.GOTO:
+		return p.parseBranchStatement()
+	case lexer.RETURN:
+		return p.parseReturnStatement()
+	case lexer.VAR, lexer.CONST:
+		return &ast.DeclStmt{Decl: p.parseGenDecl(p.parseValueSpec)}
+	case lexer.TYPE:
+		return &ast.DeclStmt{Decl: p.parseGenDecl(p.parseTypeSpec)}
+	case lexer.LBRACE:
+		return p.parseBlockStatement()
+	case lexer.IDENT:
+		if p.peekTokenIs(lexer.COLON) {
+			return p.parseLabeledStatement()
+		}
+		return p.parseSimpleStatement()
+	default:
+		return p.parseSimpleStatement()
+	}
+}
+
+// parseSimpleStatement parses an expression statement, a send, an increment
+// or decrement, an assignment or a short variable declaration. In the header
+// of a for loop it also parses a range clause and returns a partial *ast.RangeStmt.
+func (p *Parser) parseSimpleStatement() (stmt ast.Statement) {
+	defer deferNode(p, p.curToken.Offset, &stmt)
+	tok := p.curToken
+	lhs := p.parseExpressionList()
+
+	if p.peekTokenIs(lexer.ARROW) {
+		p.nextToken()
+		if len(lhs) > 1 {
+			p.errorf("expected 1 expression, found %d", len(lhs))
+		}
+		stmt := &ast.SendStmt{Token: p.curToken, Chan: lhs[0]}
+		p.nextToken()
+		stmt.Value = p.parseExpression(LOWEST)
+		return stmt
+	}
+
+	if p.peekTokenIs(lexer.INC) || p.peekTokenIs(lexer.DEC) {
+		p.nextToken()
+		if len(lhs) > 1 {
+			p.errorf("expected 1 expression, found %d", len(lhs))
+		}
+		return &ast.IncDecStmt{Token: p.curToken, X: lhs[0]}
+	}
+
+	if p.peekTokenIs(lexer.ASSIGN) || p.peekTokenIs(lexer.DEFINE) {
+		p.nextToken()
+		stmt := &ast.AssignStmt{Token: p.curToken, Lhs: lhs}
+		var names []*ast.Identifier
+		if stmt.Token.Type == lexer.DEFINE {
+			for _, x := range lhs {
+				ident, ok := x.(*ast.Identifier)
+				if !ok && x != nil {
+					p.errorf("non-name %s on left side of :=", x.String())
+				}
+				if ok {
+					p.forgetUse(ident)
+					names = append(names, ident)
+				}
+			}
+		}
+		p.nextToken()
+		if p.curTokenIs(lexer.RANGE) && p.rangeOK {
+			return p.parseRangeClause(stmt.Token, lhs, names)
+		}
+		stmt.Rhs = p.parseExpressionList()
+		p.declare(names...)
+		return stmt
+	}
+
+	if len(lhs) > 1 {
+		p.errorf("expected 1 expression, found %d", len(lhs))
+	}
+	return &ast.ExprStmt{Token: tok, X: lhs[0]}
+}
+
+// parseCallStatement parses the function call of a go or defer statement.
+// It returns a GoStmt carrying the keyword token and the call, or nil.
+func (p *Parser) parseCallStatement(keyword string) *ast.GoStmt {
+	stmt := &ast.GoStmt{Token: p.curToken}
+	p.nextToken()
+	x := p.parseExpression(LOWEST)
+	if _, ok := x.(*ast.ParenExpr); ok {
+		p.errorf("expression in %s must not be parenthesized", keyword)
+		return nil
+	}
+	call, ok := x.(*ast.CallExpr)
+	if !ok {
+		if x != nil {
+			p.errorf("expression in %s must be function call", keyword)
+		}
+		return nil
+	}
+	stmt.Call = call
+	return stmt
+}
+
+// parseBranchStatement parses break, continue or goto with an optional label.
+// Whether the branch is valid is checked per function by checkLabels.
+func (p *Parser) parseBranchStatement() *ast.BranchStmt {
+	stmt := &ast.BranchStmt{Token: p.curToken}
+	if p.peekTokenIs(lexer.IDENT) {
+		p.nextToken()
+		stmt.Label = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+	} else if stmt.Token.Type == lexer.GOTO {
+		p.peekError(lexer.IDENT)
+	}
+	return stmt
+}
+
+// parseLabeledStatement parses `label: stmt`. Labels live in their own
+// namespace, so the label is not recorded as a use of a variable.
+func (p *Parser) parseLabeledStatement() *ast.LabeledStmt {
+	label := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+	p.nextToken()
+	stmt := &ast.LabeledStmt{Token: p.curToken, Label: label}
+	if p.peekTokenIs(lexer.RBRACE) || p.peekTokenIs(lexer.SEMICOLON) {
+		return stmt
+	}
+	p.nextToken()
+	stmt.Stmt = p.parseStatement()
+	return stmt
+}
+
+// parseReturnStatement parses `return` with an optional list of results.
+func (p *Parser) parseReturnStatement() *ast.ReturnStmt {
+	stmt := &ast.ReturnStmt{Token: p.curToken}
+	if p.peekTokenIs(lexer.SEMICOLON) || p.peekTokenIs(lexer.RBRACE) {
+		return stmt
+	}
+	p.nextToken()
+	stmt.Results = p.parseExpressionList()
+	return stmt
+}
+
+// parseRangeClause parses `range x` after the iteration variables and the
+// assignment token of a for loop header.
+func (p *Parser) parseRangeClause(tok lexer.Token, lhs []ast.Expression, names []*ast.Identifier) *ast.RangeStmt {
+	stmt := &ast.RangeStmt{Tok: tok, Key: lhs[0]}
+	switch len(lhs) {
+	case 1:
+	case 2:
+		stmt.Value = lhs[1]
+	default:
+		p.errorf("range clause permits at most two iteration variables")
+	}
+	p.nextToken()
+	stmt.X = p.parseExpression(LOWEST)
+	p.declare(names...)
+	return stmt
+}
+
+func (p *Parser) parseBlockStatement() (block *ast.BlockStatement) {
+	defer deferNode(p, p.curToken.Offset, &block)
+	block = &ast.BlockStatement{Token: p.curToken}
+	p.openScope(nil)
+	defer p.closeScope()
+	p.nextToken()
+	block.Statements = p.parseStatementList()
+	if !p.curTokenIs(lexer.RBRACE) {
+		p.errorf("expected }, got %s instead", p.curToken.Type)
+	} else {
+		block.Rbrace = p.curToken
+	}
+	p.rejectFallthrough(block.Statements)
+	return block
+}
+
+// parseIfStatement parses `if [init;] cond { ... } [else ...]`.
+func (p *Parser) parseIfStatement() ast.Statement {
+	stmt := &ast.IfStmt{Token: p.curToken}
+	p.openScope(nil)
+	defer p.closeScope()
+
+	outer := p.exprLev
+	p.exprLev = -1
+	if p.peekTokenIs(lexer.LBRACE) {
+		p.errorf("missing condition in if statement")
+	} else {
+		p.nextToken()
+		header := p.parseSimpleStatement()
+		if p.peekTokenIs(lexer.SEMICOLON) {
+			p.nextToken()
+			stmt.Init, header = header, nil
+			if p.peekTokenIs(lexer.LBRACE) {
+				p.errorf("missing condition in if statement")
+			} else {
+				p.nextToken()
+				header = p.parseSimpleStatement()
+			}
+		}
+		stmt.Cond = p.conditionOf(header)
+	}
+	p.exprLev = outer
+
+	if !p.expectPeek(lexer.LBRACE) {
+		return nil
+	}
+	stmt.Body = p.parseBlockStatement()
+
+	if p.peekTokenIs(lexer.ELSE) {
+		p.nextToken()
+		switch {
+		case p.peekTokenIs(lexer.IF):
+			p.nextToken()
+			stmt.Else = p.parseIfStatement()
+		case p.peekTokenIs(lexer.LBRACE):
+			p.nextToken()
+			stmt.Else = p.parseBlockStatement()
+		default:
+			p.errorf("else must be followed by if or statement block")
+		}
+	}
+	return stmt
+}
+
+// parseForStatement parses the three forms of for loop and range loops:
+//
+//	for { ... }
+//	for cond { ... }
+//	for [init]; [cond]; [post] { ... }
+//	for [k[, v] (:= | =)] range x { ... }
+func (p *Parser) parseForStatement() ast.Statement {
+	tok := p.curToken
+	p.openScope(nil)
+	defer p.closeScope()
+
+	var init, cond, post ast.Statement
+	var rangeStmt *ast.RangeStmt
+	if !p.peekTokenIs(lexer.LBRACE) {
+		outer := p.exprLev
+		p.exprLev = -1
+		if !p.peekTokenIs(lexer.SEMICOLON) {
+			p.nextToken()
+			if p.curTokenIs(lexer.RANGE) {
+				rangeStmt = &ast.RangeStmt{}
+				p.nextToken()
+				rangeStmt.X = p.parseExpression(LOWEST)
+			} else {
+				p.rangeOK = true
+				cond = p.parseSimpleStatement()
+				p.rangeOK = false
+				rangeStmt, _ = cond.(*ast.RangeStmt)
+			}
+		}
+		if rangeStmt == nil && p.peekTokenIs(lexer.SEMICOLON) {
+			p.nextToken()
+			init, cond = cond, nil
+			if !p.peekTokenIs(lexer.SEMICOLON) {
+				p.nextToken()
+				cond = p.parseSimpleStatement()
+			}
+			if !p.expectPeek(lexer.SEMICOLON) {
+				p.exprLev = outer
+				return nil
+			}
+			if !p.peekTokenIs(lexer.LBRACE) {
+				p.nextToken()
+				post = p.parseSimpleStatement()
+				if as, ok := post.(*ast.AssignStmt); ok && as.Token.Type == lexer.DEFINE {
+					p.errorf("cannot declare in post statement of for loop")
+				}
+			}
+		}
+		p.exprLev = outer
+	}
+
+	if !p.expectPeek(lexer.LBRACE) {
+		return nil
+	}
+	body := p.parseBlockStatement()
+
+	if rangeStmt != nil {
+		rangeStmt.Token = tok
+		rangeStmt.Body = body
+		return rangeStmt
+	}
+	stmt := &ast.ForStmt{Token: tok, Init: init, Post: post, Body: body}
+	if cond != nil {
+		stmt.Cond = p.conditionOf(cond)
+	}
+	return stmt
+}
+
+// conditionOf returns the expression of a header statement used as a condition.
+func (p *Parser) conditionOf(s ast.Statement) ast.Expression {
+	es, ok := s.(*ast.ExprStmt)
+	if !ok {
+		if s != nil {
+			p.errorf("cannot use %s as value", s.String())
+		}
+		return nil
+	}
+	return es.X
+}
+
+// parseSwitchStatement parses both expression and type switches:
+//
+//	switch [init;] [tag] { clauses }
+//	switch [init;] [v :=] x.(type) { clauses }
+func (p *Parser) parseSwitchStatement() ast.Statement {
+	tok := p.curToken
+	p.openScope(nil)
+	defer p.closeScope()
+
+	var init, header ast.Statement
+	if !p.peekTokenIs(lexer.LBRACE) {
+		outer := p.exprLev
+		p.exprLev = -1
+		p.inSwitchHeader = true
+		if !p.peekTokenIs(lexer.SEMICOLON) {
+			p.nextToken()
+			header = p.parseSimpleStatement()
+		}
+		if p.peekTokenIs(lexer.SEMICOLON) {
+			p.nextToken()
+			init, header = header, nil
+			if !p.peekTokenIs(lexer.LBRACE) {
+				p.nextToken()
+				header = p.parseSimpleStatement()
+			}
+		}
+		p.inSwitchHeader = false
+		p.exprLev = outer
+	}
+	binding, x, typeSwitch := p.typeSwitchGuard(header)
+
+	var tag ast.Expression
+	if header != nil && !typeSwitch {
+		es, ok := header.(*ast.ExprStmt)
+		if !ok {
+			p.errorf("switch expression must be an expression, got %s", header.String())
+		} else {
+			tag = es.X
+		}
+	}
+
+	if !p.expectPeek(lexer.LBRACE) {
+		return nil
+	}
+	p.nextToken()
+
+	var cases []*ast.CaseClause
+	hasDefault := false
+	for p.curTokenIs(lexer.CASE) || p.curTokenIs(lexer.DEFAULT) {
+		if p.curTokenIs(lexer.DEFAULT) {
+			if hasDefault {
+				p.errorf("multiple defaults in switch")
+			}
+			hasDefault = true
+		}
+		p.openScope(nil)
+		p.declare(binding)
+		cases = append(cases, p.parseCaseClause())
+		p.closeScope()
+	}
+	if !p.curTokenIs(lexer.RBRACE) {
+		p.errorf("expected case or default or }, got %s instead", p.curToken.Type)
+		return nil
+	}
+	p.checkFallthrough(cases, typeSwitch)
+
+	if typeSwitch {
+		return &ast.TypeSwitchStmt{Token: tok, Init: init, Binding: binding, X: x, Cases: cases, Rbrace: p.curToken}
+	}
+	return &ast.SwitchStmt{Token: tok, Init: init, Tag: tag, Cases: cases, Rbrace: p.curToken}
+}
+
+// parseSelectStatement parses `select { comm clauses }`.
+func (p *Parser) parseSelectStatement() ast.Statement {
+	stmt := &ast.SelectStmt{Token: p.curToken}
+	if !p.expectPeek(lexer.LBRACE) {
+		return nil
+	}
+	p.nextToken()
+
+	hasDefault := false
+	for p.curTokenIs(lexer.CASE) || p.curTokenIs(lexer.DEFAULT) {
+		if p.curTokenIs(lexer.DEFAULT) {
+			if hasDefault {
+				p.errorf("multiple defaults in select")
+			}
+			hasDefault = true
+		}
+		p.openScope(nil)
+		stmt.Cases = append(stmt.Cases, p.parseCommClause())
+		p.closeScope()
+	}
+	if !p.curTokenIs(lexer.RBRACE) {
+		p.errorf("expected case or default or }, got %s instead", p.curToken.Type)
+		return nil
+	}
+	stmt.Rbrace = p.curToken
+	for _, c := range stmt.Cases {
+		p.rejectFallthrough(c.Body)
+	}
+	return stmt
+}
+
+// parseCommClause parses `case send-or-receive: stmts` or `default: stmts`.
+// Like parseCaseClause it leaves the current token on the token that follows
+// the clause.
+func (p *Parser) parseCommClause() (clause *ast.CommClause) {
+	defer deferClause(p, p.curToken.Offset, &clause)
+	clause = &ast.CommClause{Token: p.curToken}
+	if p.curTokenIs(lexer.CASE) {
+		p.nextToken()
+		clause.Comm = p.parseSimpleStatement()
+		if !isCommStatement(clause.Comm) {
+			p.errorf("select case must be receive, send or assign recv")
+		}
+	}
+	if !p.expectPeek(lexer.COLON) {
+		// skip to the next clause, past at least the current token so
+		// that the caller's clause loop makes progress
+		p.nextToken()
+		for !p.curTokenIs(lexer.CASE) && !p.curTokenIs(lexer.DEFAULT) &&
+			!p.curTokenIs(lexer.RBRACE) && !p.curTokenIs(lexer.EOF) {
+			p.nextToken()
+		}
+		return clause
+	}
+	clause.Colon = p.curToken
+	p.nextToken()
+	clause.Body = p.parseStatementList()
+	return clause
+}
+
+// isCommStatement reports whether s is a valid select case: a send, a
+// receive, or an assignment of up to two variables from a receive.
+func isCommStatement(s ast.Statement) bool {
+	switch s := s.(type) {
+	case *ast.SendStmt:
+		return true
+	case *ast.ExprStmt:
+		return isReceive(s.X)
+	case *ast.AssignStmt:
+		return len(s.Lhs) <= 2 && len(s.Rhs) == 1 && isReceive(s.Rhs[0])
+	}
+	return false
+}
+
+func isReceive(x ast.Expression) bool {
+	if paren, ok := x.(*ast.ParenExpr); ok {
+		return isReceive(paren.X)
+	}
+	unary, ok := x.(*ast.PrefixExpression)
+	return ok && unary.Operator == "<-"
+}
+
+// typeSwitchGuard reports whether a switch header is x.(type) or v := x.(type),
+// returning the bound identifier (if any) and the switched expression.
+func (p *Parser) typeSwitchGuard(header ast.Statement) (*ast.Identifier, ast.Expression, bool) {
+	switch h := header.(type) {
+	case *ast.ExprStmt:
+		if ta, ok := h.X.(*ast.TypeAssertExpr); ok && ta.Type == nil {
+			return nil, ta.X, true
+		}
+	case *ast.AssignStmt:
+		if len(h.Rhs) != 1 {
+			return nil, nil, false
+		}
+		ta, ok := h.Rhs[0].(*ast.TypeAssertExpr)
+		if !ok || ta.Type != nil {
+			return nil, nil, false
+		}
+		ident, ok := h.Lhs[0].(*ast.Identifier)
+		if h.Token.Type != lexer.DEFINE || len(h.Lhs) != 1 || !ok {
+			p.errorf("invalid variable name %s in type switch", joinExpressions(h.Lhs))
+		}
+		return ident, ta.X, true
+	}
+	return nil, nil, false
+}
+
+// parseCaseClause parses `case x, y: stmts` or `default: stmts`.
+// Unlike most parse functions it leaves the current token on the token that
+// follows the clause (the next case, default or the closing brace).
+func (p *Parser) parseCaseClause() (clause *ast.CaseClause) {
+	defer deferClause(p, p.curToken.Offset, &clause)
+	clause = &ast.CaseClause{Token: p.curToken}
+	if p.curTokenIs(lexer.CASE) {
+		p.nextToken()
+		clause.List = p.parseExpressionList()
+	}
+	if !p.expectPeek(lexer.COLON) {
+		// skip to the next clause, past at least the current token so
+		// that the caller's clause loop makes progress
+		p.nextToken()
+		for !p.curTokenIs(lexer.CASE) && !p.curTokenIs(lexer.DEFAULT) &&
+			!p.curTokenIs(lexer.RBRACE) && !p.curTokenIs(lexer.EOF) {
+			p.nextToken()
+		}
+		return clause
+	}
+	clause.Colon = p.curToken
+	p.nextToken()
+	clause.Body = p.parseStatementList()
+	return clause
+}
+
+// checkFallthrough accepts fallthrough only as the last statement of a
+// non-final clause of an expression switch.
+func (p *Parser) checkFallthrough(cases []*ast.CaseClause, typeSwitch bool) {
+	for i, c := range cases {
+		for j, s := range c.Body {
+			if !isFallthrough(s) {
+				continue
+			}
+			switch {
+			case j != len(c.Body)-1:
+				p.errorf("fallthrough statement out of place")
+			case typeSwitch:
+				p.errorf("cannot fallthrough in type switch")
+			case i == len(cases)-1:
+				p.errorf("cannot fallthrough final case in switch")
+			}
+		}
+	}
+}
+
+// rejectFallthrough reports every fallthrough in a list that is not a case body.
+func (p *Parser) rejectFallthrough(list []ast.Statement) {
+	for _, s := range list {
+		if isFallthrough(s) {
+			p.errorf("fallthrough statement out of place")
+		}
+	}
+}
+
+func isFallthrough(s ast.Statement) bool {
+	bs, ok := s.(*ast.BranchStmt)
+	return ok && bs.Token.Type == lexer.FALLTHROUGH
+}
+
+func joinExpressions(list []ast.Expression) string {
+	out := ""
+	for i, x := range list {
+		if i > 0 {
+			out += ", "
+		}
+		if x != nil {
+			out += x.String()
+		}
+	}
+	return out
+}
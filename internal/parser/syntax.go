@@ -0,0 +1,51 @@
+package parser
+
+import (
+	"reflect"
+
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+)
+
+// NodeHook is called for every node the parser completes, with the byte
+// range [start, end) of the source the node was parsed from. Inner nodes are
+// reported before the nodes that contain them.
+type NodeHook func(node ast.Node, start, end int)
+
+// SetNodeHook registers fn as the parser's NodeHook. The cst package uses it
+// to nest tokens into a concrete syntax tree.
+func (p *Parser) SetNodeHook(fn NodeHook) {
+	p.nodeHook = fn
+}
+
+// reportNode reports n, which started at offset start and ends with the
+// current token.
+func (p *Parser) reportNode(n ast.Node, start int) {
+	if p.nodeHook != nil && !isNil(n) {
+		p.nodeHook(n, start, p.curToken.End())
+	}
+}
+
+// deferNode is deferred by parse functions with a named result n, so that
+// the node is reported whichever way the function returns:
+//
+//	defer deferNode(p, p.curToken.Offset, &n)
+func deferNode[N ast.Node](p *Parser, start int, n *N) {
+	p.reportNode(*n, start)
+}
+
+// deferClause is like deferNode for the clause parse functions, which
+// return with the current token on the token after the clause.
+func deferClause[N ast.Node](p *Parser, start int, n *N) {
+	if p.nodeHook != nil && !isNil(*n) {
+		p.nodeHook(*n, start, p.prevEnd)
+	}
+}
+
+// isNil reports whether n is nil or an interface holding a nil pointer.
+func isNil(n ast.Node) bool {
+	if n == nil {
+		return true
+	}
+	v := reflect.ValueOf(n)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
@@ -0,0 +1,485 @@
+package parser
+
+import (
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+)
+
+// isTypeStart reports whether a token of type tt can begin a type.
+func isTypeStart(tt lexer.TokenType) bool {
+	switch tt {
+	case lexer.IDENT, lexer.LPAREN, lexer.ASTERISK, lexer.FUNC, lexer.LBRACKET, lexer.MAP,
+		lexer.CHAN, lexer.ARROW, lexer.STRUCT, lexer.INTERFACE:
+		return true
+	}
+	return false
+}
+
+// parseTypeOrExpression parses an operand that may be either a type or a
+// value, such as a type argument F[int] or the argument of make([]int, n).
+// Types that cannot start an expression are parsed with parseType.
+func (p *Parser) parseTypeOrExpression() ast.Expression {
+	if p.prefixFns[p.curToken.Type] == nil && isTypeStart(p.curToken.Type) {
+		return p.parseType()
+	}
+	return p.parseExpression(LOWEST)
+}
+
+// parseTypeExpression parses a type used as an operand: the type of a
+// composite literal or the target of a conversion such as []byte(s).
+func (p *Parser) parseTypeExpression() ast.Expression {
+	if !p.curTokenIs(lexer.LBRACKET) {
+		return p.parseType()
+	}
+	typ := p.parseArrayType()
+	if typ == nil {
+		return nil
+	}
+	if _, ok := typ.Len.(*ast.Ellipsis); ok && !p.peekTokenIs(lexer.LBRACE) {
+		p.errorf("invalid use of [...] array (outside a composite literal)")
+	}
+	return typ
+}
+
+// parseType parses a type expression starting at the current token and
+// leaves the current token on its last token.
+func (p *Parser) parseType() (typ ast.Expression) {
+	defer deferNode(p, p.curToken.Offset, &typ)
+	switch p.curToken.Type {
+	case lexer.IDENT:
+		return p.parseTypeName()
+	case lexer.ASTERISK:
+		expr := &ast.StarExpr{Token: p.curToken}
+		p.nextToken()
+		expr.X = p.parseType()
+		return expr
+	case lexer.LBRACKET:
+		typ := p.parseArrayType()
+		if typ == nil {
+			return nil
+		}
+		if _, ok := typ.Len.(*ast.Ellipsis); ok {
+			p.errorf("invalid use of [...] array (outside a composite literal)")
+		}
+		return typ
+	case lexer.MAP:
+		if typ := p.parseMapType(); typ != nil {
+			return typ
+		}
+		return nil
+	case lexer.CHAN, lexer.ARROW:
+		return p.parseChanType()
+	case lexer.STRUCT:
+		if typ := p.parseStructType(); typ != nil {
+			return typ
+		}
+		return nil
+	case lexer.INTERFACE:
+		if typ := p.parseInterfaceType(); typ != nil {
+			return typ
+		}
+		return nil
+	case lexer.FUNC:
+		tok := p.curToken
+		if !p.expectPeek(lexer.LPAREN) {
+			return nil
+		}
+		return p.parseSignature(tok)
+	case lexer.LPAREN:
+		expr := &ast.ParenExpr{Token: p.curToken}
+		p.nextToken()
+		expr.X = p.parseType()
+		if !p.expectPeek(lexer.RPAREN) {
+			return nil
+		}
+		expr.Rparen = p.curToken
+		return expr
+	}
+	p.errorf("expected type, got %s instead", p.curToken.Type)
+	return nil
+}
+
+// parseTypeName parses a possibly qualified type name with optional type
+// arguments, such as List, list.List or List[int].
+func (p *Parser) parseTypeName() ast.Expression {
+	var typ ast.Expression = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+	if p.peekTokenIs(lexer.PERIOD) {
+		p.nextToken()
+		tok := p.curToken
+		if !p.expectPeek(lexer.IDENT) {
+			return nil
+		}
+		sel := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+		typ = &ast.SelectorExpr{Token: tok, X: typ, Sel: sel}
+	}
+	if !p.peekTokenIs(lexer.LBRACKET) {
+		return typ
+	}
+
+	p.nextToken()
+	tok := p.curToken
+	var args []ast.Expression
+	for {
+		p.nextToken()
+		args = append(args, p.parseType())
+		if !p.peekTokenIs(lexer.COMMA) {
+			break
+		}
+		p.nextToken()
+		if p.peekTokenIs(lexer.RBRACKET) {
+			break
+		}
+	}
+	if !p.expectPeek(lexer.RBRACKET) {
+		return nil
+	}
+	if len(args) == 1 {
+		return &ast.IndexExpr{Token: tok, X: typ, Index: args[0], Rbrack: p.curToken}
+	}
+	return &ast.IndexListExpr{Token: tok, X: typ, Indices: args, Rbrack: p.curToken}
+}
+
+// parseArrayType parses [N]T, [...]T or the slice type []T.
+func (p *Parser) parseArrayType() *ast.ArrayType {
+	typ := &ast.ArrayType{Token: p.curToken}
+	switch {
+	case p.peekTokenIs(lexer.RBRACKET):
+	case p.peekTokenIs(lexer.ELLIPSIS):
+		p.nextToken()
+		typ.Len = &ast.Ellipsis{Token: p.curToken}
+	default:
+		p.nextToken()
+		p.exprLev++
+		typ.Len = p.parseExpression(LOWEST)
+		p.exprLev--
+	}
+	if !p.expectPeek(lexer.RBRACKET) {
+		return nil
+	}
+	p.nextToken()
+	typ.Elt = p.parseType()
+	return typ
+}
+
+// parseMapType parses map[K]V.
+func (p *Parser) parseMapType() *ast.MapType {
+	typ := &ast.MapType{Token: p.curToken}
+	if !p.expectPeek(lexer.LBRACKET) {
+		return nil
+	}
+	p.nextToken()
+	typ.Key = p.parseType()
+	if !p.expectPeek(lexer.RBRACKET) {
+		return nil
+	}
+	p.nextToken()
+	typ.Value = p.parseType()
+	return typ
+}
+
+// parseChanType parses chan T, chan<- T or <-chan T.
+func (p *Parser) parseChanType() ast.Expression {
+	typ := &ast.ChanType{Token: p.curToken, Dir: ast.SEND | ast.RECV}
+	if p.curTokenIs(lexer.ARROW) {
+		if !p.expectPeek(lexer.CHAN) {
+			return nil
+		}
+		typ.Dir = ast.RECV
+	} else if p.peekTokenIs(lexer.ARROW) {
+		p.nextToken()
+		typ.Dir = ast.SEND
+	}
+	p.nextToken()
+	typ.Value = p.parseType()
+	return typ
+}
+
+// parseStructType parses struct { fields }. Each line declares named fields
+// (X, Y int), or an embedded field (T, *T, pkg.T or T[A]), optionally
+// followed by a tag string.
+func (p *Parser) parseStructType() *ast.StructType {
+	typ := &ast.StructType{Token: p.curToken}
+	if !p.expectPeek(lexer.LBRACE) {
+		return nil
+	}
+	typ.Fields = &ast.FieldList{Token: p.curToken}
+	p.nextToken()
+	for !p.curTokenIs(lexer.RBRACE) && !p.curTokenIs(lexer.EOF) {
+		if p.curTokenIs(lexer.SEMICOLON) {
+			p.nextToken()
+			continue
+		}
+		doc := p.curDoc
+		field := p.parseFieldDecl()
+		if field != nil {
+			field.Doc = doc
+			typ.Fields.List = append(typ.Fields.List, field)
+		}
+		if p.peekTokenIs(lexer.STRING) {
+			p.nextToken()
+			if field != nil {
+				field.Tag = p.stringLiteral()
+			}
+		}
+		if !p.peekTokenIs(lexer.RBRACE) && !p.expectPeek(lexer.SEMICOLON) {
+			return nil
+		}
+		if field != nil {
+			field.Comment = p.lineComment
+		}
+		p.nextToken()
+	}
+	typ.Fields.Closing = p.curToken
+	return typ
+}
+
+// parseFieldDecl parses the names and type, or the embedded type, of one
+// struct field declaration.
+func (p *Parser) parseFieldDecl() *ast.Field {
+	switch p.curToken.Type {
+	case lexer.ASTERISK:
+		star := &ast.StarExpr{Token: p.curToken}
+		if !p.expectPeek(lexer.IDENT) {
+			return nil
+		}
+		star.X = p.parseTypeName()
+		return &ast.Field{Type: star}
+	case lexer.LPAREN:
+		p.errorf("cannot parenthesize embedded type")
+		return &ast.Field{Type: p.parseType()}
+	case lexer.IDENT:
+	default:
+		p.errorf("expected field name or embedded type, got %s instead", p.curToken.Type)
+		return nil
+	}
+
+	switch p.peekToken.Type {
+	case lexer.PERIOD, lexer.SEMICOLON, lexer.RBRACE, lexer.STRING:
+		return &ast.Field{Type: p.parseTypeName()}
+	case lexer.LBRACKET:
+		return p.parseArrayFieldOrTypeInstance()
+	}
+
+	field := &ast.Field{Names: p.parseIdentifierList()}
+	p.nextToken()
+	field.Type = p.parseType()
+	return field
+}
+
+// parseArrayFieldOrTypeInstance resolves the `name [` ambiguity of a struct
+// field or parameter: a [N]T is a field a of array type, while T[A] with
+// nothing after the bracket is an (embedded or unnamed) instance of the
+// generic type T.
+func (p *Parser) parseArrayFieldOrTypeInstance() *ast.Field {
+	name := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+	p.nextToken()
+	if p.peekTokenIs(lexer.RBRACKET) || p.peekTokenIs(lexer.ELLIPSIS) {
+		return &ast.Field{Names: []*ast.Identifier{name}, Type: p.parseType()}
+	}
+
+	tok := p.curToken
+	var args []ast.Expression
+	p.exprLev++
+	for {
+		p.nextToken()
+		args = append(args, p.parseTypeOrExpression())
+		if !p.peekTokenIs(lexer.COMMA) {
+			break
+		}
+		p.nextToken()
+	}
+	p.exprLev--
+	if !p.expectPeek(lexer.RBRACKET) {
+		return nil
+	}
+
+	if len(args) == 1 && isTypeStart(p.peekToken.Type) {
+		p.nextToken()
+		array := &ast.ArrayType{Token: tok, Len: args[0], Elt: p.parseType()}
+		return &ast.Field{Names: []*ast.Identifier{name}, Type: array}
+	}
+	if len(args) == 1 {
+		return &ast.Field{Type: &ast.IndexExpr{Token: tok, X: name, Index: args[0], Rbrack: p.curToken}}
+	}
+	return &ast.Field{Type: &ast.IndexListExpr{Token: tok, X: name, Indices: args, Rbrack: p.curToken}}
+}
+
+// parseInterfaceType parses interface { elements }. An element is a method
+// Name(params) results, or a type element: an embedded interface or a union
+// of terms such as ~int | ~string.
+func (p *Parser) parseInterfaceType() *ast.InterfaceType {
+	typ := &ast.InterfaceType{Token: p.curToken}
+	if !p.expectPeek(lexer.LBRACE) {
+		return nil
+	}
+	typ.Methods = &ast.FieldList{Token: p.curToken}
+	p.nextToken()
+	for !p.curTokenIs(lexer.RBRACE) && !p.curTokenIs(lexer.EOF) {
+		if p.curTokenIs(lexer.SEMICOLON) {
+			p.nextToken()
+			continue
+		}
+		var field *ast.Field
+		doc := p.curDoc
+		if p.curTokenIs(lexer.IDENT) && p.peekTokenIs(lexer.LPAREN) {
+			name := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+			p.nextToken()
+			sig := p.parseSignature(lexer.Token{})
+			field = &ast.Field{Doc: doc, Names: []*ast.Identifier{name}, Type: sig}
+		} else if elem := p.parseTypeElem(); elem != nil {
+			field = &ast.Field{Doc: doc, Type: elem}
+		}
+		if field != nil {
+			typ.Methods.List = append(typ.Methods.List, field)
+		}
+		if !p.peekTokenIs(lexer.RBRACE) && !p.expectPeek(lexer.SEMICOLON) {
+			return nil
+		}
+		if field != nil {
+			field.Comment = p.lineComment
+		}
+		p.nextToken()
+	}
+	typ.Methods.Closing = p.curToken
+	return typ
+}
+
+// parseTypeElem parses a union of type terms, T1 | ~T2 | ..., used in
+// interfaces and type constraints.
+func (p *Parser) parseTypeElem() ast.Expression {
+	x := p.parseTypeTerm()
+	for p.peekTokenIs(lexer.PIPE) {
+		p.nextToken()
+		union := &ast.InfixExpression{Token: p.curToken, Left: x, Operator: p.curToken.Literal}
+		p.nextToken()
+		union.Right = p.parseTypeTerm()
+		x = union
+	}
+	return x
+}
+
+// parseTypeTerm parses T or ~T.
+func (p *Parser) parseTypeTerm() ast.Expression {
+	if !p.curTokenIs(lexer.TILDE) {
+		return p.parseType()
+	}
+	term := &ast.PrefixExpression{Token: p.curToken, Operator: p.curToken.Literal}
+	p.nextToken()
+	term.Right = p.parseType()
+	return term
+}
+
+// parseSignature parses the parameters and optional results of a function,
+// starting at the opening parenthesis of the parameter list.
+func (p *Parser) parseSignature(funcTok lexer.Token) *ast.FuncType {
+	ft := &ast.FuncType{Token: funcTok, Params: p.parseParameters(true)}
+
+	switch {
+	case p.peekTokenIs(lexer.LPAREN):
+		p.nextToken()
+		ft.Results = p.parseParameters(false)
+	case isTypeStart(p.peekToken.Type):
+		p.nextToken()
+		ft.Results = &ast.FieldList{List: []*ast.Field{{Type: p.parseType()}}}
+	}
+	return ft
+}
+
+// parameter is one comma-separated entry of a parameter list before the
+// entries are grouped into fields.
+type parameter struct {
+	name *ast.Identifier // nil if the entry is only a type (or an ungrouped name)
+	typ  ast.Expression
+}
+
+// parseParameters parses a parenthesized parameter or result list.
+// Go requires the entries to be either all named (a, b int, c string) or all
+// unnamed (int, string); a name without a type takes the type of the next
+// named entry. variadicOK permits a final ...T parameter.
+func (p *Parser) parseParameters(variadicOK bool) (list *ast.FieldList) {
+	defer deferNode(p, p.curToken.Offset, &list)
+	list = &ast.FieldList{Token: p.curToken}
+	var entries []parameter
+	named := false
+
+	p.nextToken()
+	for !p.curTokenIs(lexer.RPAREN) && !p.curTokenIs(lexer.EOF) {
+		var entry parameter
+		if p.curTokenIs(lexer.IDENT) && p.peekTokenIs(lexer.LBRACKET) {
+			// a []int or a [N]int, or the unnamed instance List[int]
+			if field := p.parseArrayFieldOrTypeInstance(); field != nil {
+				if len(field.Names) > 0 {
+					entry.name = field.Names[0]
+					named = true
+				}
+				entry.typ = field.Type
+			}
+		} else if p.curTokenIs(lexer.IDENT) && !p.peekTokenIs(lexer.COMMA) && !p.peekTokenIs(lexer.RPAREN) &&
+			!p.peekTokenIs(lexer.PERIOD) {
+			entry.name = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+			named = true
+			p.nextToken()
+			entry.typ = p.parseParameterType()
+		} else {
+			entry.typ = p.parseParameterType()
+		}
+		entries = append(entries, entry)
+
+		if p.peekTokenIs(lexer.COMMA) {
+			p.nextToken()
+			p.nextToken()
+			continue
+		}
+		if !p.expectPeek(lexer.RPAREN) {
+			return list
+		}
+		break
+	}
+	if p.curTokenIs(lexer.RPAREN) {
+		list.Closing = p.curToken
+	}
+
+	if named {
+		var pending []*ast.Identifier
+		for _, entry := range entries {
+			if entry.name == nil {
+				ident, ok := entry.typ.(*ast.Identifier)
+				if !ok {
+					p.errorf("mixed named and unnamed parameters")
+					continue
+				}
+				pending = append(pending, ident)
+				continue
+			}
+			list.List = append(list.List, &ast.Field{Names: append(pending, entry.name), Type: entry.typ})
+			pending = nil
+		}
+		if len(pending) > 0 {
+			p.errorf("mixed named and unnamed parameters")
+		}
+	} else {
+		for _, entry := range entries {
+			list.List = append(list.List, &ast.Field{Type: entry.typ})
+		}
+	}
+
+	for i, f := range list.List {
+		if _, ok := f.Type.(*ast.Ellipsis); ok {
+			if !variadicOK || i != len(list.List)-1 || len(f.Names) > 1 {
+				p.errorf("can only use ... with final parameter in list")
+			}
+		}
+	}
+	return list
+}
+
+// parseParameterType parses a parameter type, which may be a variadic ...T.
+func (p *Parser) parseParameterType() ast.Expression {
+	if p.curTokenIs(lexer.ELLIPSIS) {
+		expr := &ast.Ellipsis{Token: p.curToken}
+		p.nextToken()
+		expr.Elt = p.parseType()
+		return expr
+	}
+	return p.parseType()
+}
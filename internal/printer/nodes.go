@@ -0,0 +1,1741 @@
+// Copyright 2009 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE-GO file.
+//
+// Adapted from go/printer.
+
+package printer
+
+import (
+	"fmt"
+	"math"
+
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+)
+
+// Printing of syntax tree nodes. Line breaks in the source are kept where
+// the format allows them; the printer's own line breaks are limited to the
+// places gofmt puts them.
+
+// ------- Common -------- //
+
+// linebreak prints as many newlines (at least min, at most maxNewlines) as
+// are needed to get to line. If newSection is set, the first newline is a
+// formfeed, ending the current alignment section. ws is printed before the
+// first newline. linebreak reports the number of newlines printed,
+// counting a formfeed as two.
+func (p *printer) linebreak(line, min int, ws whiteSpace, newSection bool) (nbreaks int) {
+	n := max(nlimit(line-p.pos.Line), min)
+	if n > 0 {
+		p.print(ws)
+		if newSection {
+			p.print(formfeed)
+			n--
+			nbreaks = 2
+		}
+		nbreaks += n
+		for ; n > 0; n-- {
+			p.print(newline)
+		}
+	}
+	return
+}
+
+// lineComment reports whether the next comment starts on the line where
+// the node ending at end ends, that is, whether the node has a trailing
+// line comment.
+func (p *printer) lineComment(end lexer.Pos) bool {
+	return p.commentOffset < infinity && end.IsValid() &&
+		p.lineFor(p.comment.List[0].Pos()) == p.lineFor(end)
+}
+
+// identList prints a list of identifiers, indenting the continuation lines
+// of a list spanning several lines if indent is set.
+func (p *printer) identList(list []*ast.Identifier, indent bool) {
+	// convert into an expression list so we can re-use exprList formatting
+	xlist := make([]ast.Expression, len(list))
+	for i, x := range list {
+		xlist[i] = x
+	}
+	var mode exprListMode
+	if !indent {
+		mode = noIndent
+	}
+	p.exprList(lexer.NoPos, xlist, 1, mode, lexer.NoPos)
+}
+
+type exprListMode uint
+
+const (
+	commaTerm exprListMode = 1 << iota // list is optionally terminated by a comma
+	noIndent                           // no extra indentation in multi-line lists
+)
+
+// exprList prints a comma-separated list of expressions. prev0 and next0
+// are the positions of the tokens before and after the list; if either is
+// unknown, line breaks within the list are kept but not aligned.
+func (p *printer) exprList(prev0 lexer.Pos, list []ast.Expression, depth int, mode exprListMode, next0 lexer.Pos) {
+	if len(list) == 0 {
+		return
+	}
+
+	prev := p.posFor(prev0)
+	next := p.posFor(next0)
+	line := p.lineFor(list[0].Pos())
+	endLine := p.lineFor(list[len(list)-1].End())
+
+	if prev.IsValid() && prev.Line == line && line == endLine {
+		// all list entries on a single line
+		for i, x := range list {
+			if i > 0 {
+				// use position of expression following the comma as
+				// comma position for correct comment placement
+				p.setPos(x.Pos())
+				p.print(lexer.COMMA, blank)
+			}
+			p.expr0(x, depth)
+		}
+		return
+	}
+
+	// list entries span multiple lines;
+	// use source code positions to guide line breaks
+
+	// Don't add extra indentation if noIndent is set;
+	// i.e., pretend that the first line is already indented.
+	ws := ignore
+	if mode&noIndent == 0 {
+		ws = indent
+	}
+
+	// The first linebreak is always a formfeed since this section must not
+	// depend on any previous formatting.
+	prevBreak := -1 // index of last expression that was followed by a linebreak
+	if prev.IsValid() && prev.Line < line && p.linebreak(line, 0, ws, true) > 0 {
+		ws = ignore
+		prevBreak = 0
+	}
+
+	// initialize expression/key size: a zero value indicates expr/key doesn't fit on a single line
+	size := 0
+
+	// The ratio between the geometric mean of the previous key sizes and
+	// the current size determines if there should be a break in the
+	// alignment. lnsum and count accumulate the ln(size) values and the
+	// number of sizes included.
+	lnsum := 0.0
+	count := 0
+
+	// print all list elements
+	prevLine := prev.Line
+	for i, x := range list {
+		line = p.lineFor(x.Pos())
+
+		// Determine if the next linebreak, if any, needs to use formfeed:
+		// in general, use the entire node size to make the decision; for
+		// key:value expressions, use the key size.
+		useFF := true
+
+		// Determine element size: all bets are off if we don't have
+		// position information for the previous and next token.
+		prevSize := size
+		const infinity = 1e6 // larger than any source line
+		size = p.nodeSize(x, infinity)
+		pair, isPair := x.(*ast.KeyValueExpr)
+		if size <= infinity && prev.IsValid() && next.IsValid() {
+			// x fits on a single line
+			if isPair {
+				size = p.nodeSize(pair.Key, infinity) // size <= infinity
+			}
+		} else {
+			// size too large or we don't have good layout information
+			size = 0
+		}
+
+		// If the previous line and the current line had single-
+		// line-expressions and the key sizes are small or the
+		// ratio between the current key and the geometric mean
+		// of the previous key sizes does not exceed a threshold,
+		// align columns and do not use formfeed.
+		if prevSize > 0 && size > 0 {
+			const smallSize = 40
+			if count == 0 || prevSize <= smallSize && size <= smallSize {
+				useFF = false
+			} else {
+				const r = 2.5                               // threshold
+				geomean := math.Exp(lnsum / float64(count)) // count > 0
+				ratio := float64(size) / geomean
+				useFF = r*ratio <= 1 || r <= ratio
+			}
+		}
+
+		needsLinebreak := 0 < prevLine && prevLine < line
+		if i > 0 {
+			// Use position of expression following the comma as
+			// comma position for correct comment placement, but
+			// only if the expression is on the same line.
+			if !needsLinebreak {
+				p.setPos(x.Pos())
+			}
+			p.print(lexer.COMMA)
+			needsBlank := true
+			if needsLinebreak {
+				// Lines are broken using newlines so comments remain aligned
+				// unless useFF is set or there are multiple expressions on
+				// the same line in which case formfeed is used.
+				nbreaks := p.linebreak(line, 0, ws, useFF || prevBreak+1 < i)
+				if nbreaks > 0 {
+					ws = ignore
+					prevBreak = i
+					needsBlank = false // we got a line break instead
+				}
+				// If there was a new section or more than one new line
+				// (which means that the tabwriter will implicitly break
+				// the section), reset the geomean variables since we are
+				// starting a new group of elements with the next element.
+				if nbreaks > 1 {
+					lnsum = 0
+					count = 0
+				}
+			}
+			if needsBlank {
+				p.print(blank)
+			}
+		}
+
+		if len(list) > 1 && isPair && size > 0 && needsLinebreak {
+			// A key:value expression that fits onto one line and is
+			// not on the same line as the prior expression: use a
+			// column for the key such that consecutive entries can
+			// align if possible.
+			p.expr(pair.Key)
+			p.setPos(pair.Token.Pos)
+			p.print(lexer.COLON, vtab)
+			p.expr(pair.Value)
+		} else {
+			p.expr0(x, depth)
+		}
+
+		if size > 0 {
+			lnsum += math.Log(float64(size))
+			count++
+		}
+
+		prevLine = p.lineFor(x.End())
+	}
+
+	if mode&commaTerm != 0 && next.IsValid() && p.pos.Line < next.Line {
+		// print a terminating comma if the next token is on a new line
+		p.print(lexer.COMMA)
+		if ws == ignore && mode&noIndent == 0 {
+			// unindent if we indented
+			p.print(unindent)
+		}
+		p.print(formfeed) // terminating comma needs a line break to look good
+		return
+	}
+
+	if ws == ignore && mode&noIndent == 0 {
+		// unindent if we indented
+		p.print(unindent)
+	}
+}
+
+type paramMode int
+
+const (
+	funcParam paramMode = iota
+	typeTParam
+)
+
+// parameters prints a parameter, result, receiver or type parameter list.
+func (p *printer) parameters(fields *ast.FieldList, mode paramMode) {
+	openTok, closeTok := lexer.LPAREN, lexer.RPAREN
+	if mode != funcParam {
+		openTok, closeTok = lexer.LBRACKET, lexer.RBRACKET
+	}
+	p.setPos(fields.Token.Pos)
+	p.print(openTok)
+	if len(fields.List) > 0 {
+		prevLine := p.lineFor(fields.Token.Pos)
+		ws := indent
+		for i, par := range fields.List {
+			// determine par begin and end line (may be different
+			// if there are multiple parameter names for this par
+			// or the type is on a separate line)
+			parLineBeg := p.lineFor(par.Pos())
+			parLineEnd := p.lineFor(par.Type.Pos())
+			// separating "," if needed
+			needsLinebreak := 0 < prevLine && prevLine < parLineBeg
+			if i > 0 {
+				// use position of parameter following the comma as
+				// comma position for correct comma placement, but
+				// only if the next parameter is on the same line
+				if !needsLinebreak {
+					p.setPos(par.Pos())
+				}
+				p.print(lexer.COMMA)
+			}
+			// separator if needed (linebreak or blank)
+			if needsLinebreak && p.linebreak(parLineBeg, 0, ws, true) > 0 {
+				// break line if the opening "(" or previous parameter ended on a different line
+				ws = ignore
+			} else if i > 0 {
+				p.print(blank)
+			}
+			// parameter names
+			if len(par.Names) > 0 {
+				// If we indented before (ws == ignore), identList won't
+				// indent again. If we didn't (ws == indent), identList
+				// indents a list spanning multiple lines and outdents
+				// again at its end.
+				p.identList(par.Names, ws == indent)
+				p.print(blank)
+			}
+			// parameter type
+			p.expr(stripParensAlways(par.Type))
+			prevLine = parLineEnd
+		}
+
+		// if the closing ")" is on a separate line from the last parameter,
+		// print an additional "," and line break
+		if closing := p.lineFor(fields.Closing.Pos); 0 < prevLine && prevLine < closing {
+			p.print(lexer.COMMA)
+			p.linebreak(closing, 0, ignore, true)
+		} else if mode == typeTParam && fields.NumFields() == 1 && combinesWithName(fields.List[0].Type) {
+			// A type parameter list [P T] where the name P and the type
+			// expression T syntactically combine to another valid (value)
+			// expression requires a trailing comma, as in [P *T,].
+			p.print(lexer.COMMA)
+		}
+
+		// unindent if we indented
+		if ws == ignore {
+			p.print(unindent)
+		}
+	}
+
+	p.setPos(fields.Closing.Pos)
+	p.print(closeTok)
+}
+
+// combinesWithName reports whether a name followed by the expression x
+// syntactically combines to another valid (value) expression. For instance
+// using *T for x, "name *T" syntactically appears as the expression x*T.
+func combinesWithName(x ast.Expression) bool {
+	switch x := x.(type) {
+	case *ast.StarExpr:
+		// name *x.X
+		return !isTypeElem(x.X)
+	case *ast.InfixExpression:
+		return combinesWithName(x.Left) && !isTypeElem(x.Right)
+	}
+	return false
+}
+
+// isTypeElem reports whether x is a (possibly parenthesized) type element
+// expression. The result is false if x could be a type element OR an
+// ordinary (value) expression.
+func isTypeElem(x ast.Expression) bool {
+	switch x := x.(type) {
+	case *ast.ArrayType, *ast.StructType, *ast.FuncType, *ast.InterfaceType, *ast.MapType, *ast.ChanType:
+		return true
+	case *ast.PrefixExpression:
+		return x.Operator == string(lexer.TILDE)
+	case *ast.InfixExpression:
+		return isTypeElem(x.Left) || isTypeElem(x.Right)
+	case *ast.ParenExpr:
+		return isTypeElem(x.X)
+	}
+	return false
+}
+
+// signature prints the type parameters, if any, and the parameters and
+// results of a function, without the func keyword.
+func (p *printer) signature(typeParams *ast.FieldList, sig *ast.FuncType) {
+	if typeParams != nil {
+		p.parameters(typeParams, typeTParam)
+	}
+	if sig.Params != nil {
+		p.parameters(sig.Params, funcParam)
+	} else {
+		p.print(lexer.LPAREN, lexer.RPAREN)
+	}
+	res := sig.Results
+	n := res.NumFields()
+	if n > 0 {
+		// res != nil
+		p.print(blank)
+		if n == 1 && res.List[0].Names == nil {
+			// single anonymous result; no ()'s
+			p.expr(stripParensAlways(res.List[0].Type))
+			return
+		}
+		// multiple or named result(s)
+		p.parameters(res, funcParam)
+	}
+}
+
+func identListSize(list []*ast.Identifier, maxSize int) (size int) {
+	for i, x := range list {
+		if i > 0 {
+			size += len(", ")
+		}
+		size += len(x.Value)
+		if size >= maxSize {
+			break
+		}
+	}
+	return
+}
+
+func (p *printer) isOneLineFieldList(list []*ast.Field) bool {
+	if len(list) != 1 {
+		return false // allow only one field
+	}
+	f := list[0]
+	if f.Tag != nil || f.Comment != nil {
+		return false // don't allow tags or comments
+	}
+	// only name(s) and type
+	const maxSize = 30 // adjust as appropriate, this is an approximate value
+	namesSize := identListSize(f.Names, maxSize)
+	if namesSize > 0 {
+		namesSize = 1 // blank between names and types
+	}
+	typeSize := p.nodeSize(f.Type, maxSize)
+	return namesSize+typeSize <= maxSize
+}
+
+// fieldList prints the braced field list of a struct or interface type.
+// Struct fields are aligned in columns of names, types, tags and
+// comments.
+func (p *printer) fieldList(fields *ast.FieldList, isStruct bool) {
+	lbrace := fields.Token.Pos
+	list := fields.List
+	rbrace := fields.Closing.Pos
+	hasComments := p.commentBefore(p.posFor(rbrace))
+	srcIsOneLine := lbrace.IsValid() && rbrace.IsValid() && p.lineFor(lbrace) == p.lineFor(rbrace)
+
+	if !hasComments && srcIsOneLine {
+		// possibly a one-line struct/interface
+		if len(list) == 0 {
+			// no blank between keyword and {} in this case
+			p.setPos(lbrace)
+			p.print(lexer.LBRACE)
+			p.setPos(rbrace)
+			p.print(lexer.RBRACE)
+			return
+		} else if p.isOneLineFieldList(list) {
+			// small enough - print on one line
+			// (don't use identList and ignore source line breaks)
+			p.setPos(lbrace)
+			p.print(lexer.LBRACE, blank)
+			f := list[0]
+			if isStruct {
+				for i, x := range f.Names {
+					if i > 0 {
+						// no comments so no need for comma position
+						p.print(lexer.COMMA, blank)
+					}
+					p.expr(x)
+				}
+				if len(f.Names) > 0 {
+					p.print(blank)
+				}
+				p.expr(f.Type)
+			} else { // interface
+				if len(f.Names) > 0 {
+					p.expr(f.Names[0])                       // method name
+					p.signature(nil, f.Type.(*ast.FuncType)) // don't print "func"
+				} else {
+					// embedded interface
+					p.expr(f.Type)
+				}
+			}
+			p.print(blank)
+			p.setPos(rbrace)
+			p.print(lexer.RBRACE)
+			return
+		}
+	}
+	// hasComments || !srcIsOneLine
+
+	p.print(blank)
+	p.setPos(lbrace)
+	p.print(lexer.LBRACE, indent)
+	if hasComments || len(list) > 0 {
+		p.print(formfeed)
+	}
+
+	if isStruct {
+		sep := vtab
+		if len(list) == 1 {
+			sep = blank
+		}
+		var line int
+		for i, f := range list {
+			if i > 0 {
+				p.linebreak(p.lineFor(f.Pos()), 1, ignore, p.linesFrom(line) > 0)
+			}
+			extraTabs := 0
+			p.recordLine(&line)
+			if len(f.Names) > 0 {
+				// named fields
+				p.identList(f.Names, false)
+				p.print(sep)
+				p.expr(f.Type)
+				extraTabs = 1
+			} else {
+				// anonymous field
+				p.expr(f.Type)
+				extraTabs = 2
+			}
+			if f.Tag != nil {
+				if len(f.Names) > 0 && sep == vtab {
+					p.print(sep)
+				}
+				p.print(sep)
+				p.expr(f.Tag)
+				extraTabs = 0
+			}
+			if f.Comment != nil {
+				for ; extraTabs > 0; extraTabs-- {
+					p.print(sep)
+				}
+			}
+		}
+	} else { // interface
+		var line int
+		for i, f := range list {
+			if i > 0 {
+				p.linebreak(p.lineFor(f.Pos()), 1, ignore, p.linesFrom(line) > 0)
+			}
+			p.recordLine(&line)
+			if len(f.Names) > 0 {
+				// method
+				p.expr(f.Names[0])
+				p.signature(nil, f.Type.(*ast.FuncType)) // don't print "func"
+			} else {
+				// embedded interface or type union
+				p.expr(f.Type)
+			}
+		}
+	}
+	p.print(unindent, formfeed)
+	p.setPos(rbrace)
+	p.print(lexer.RBRACE)
+}
+
+// ------- Expressions -------- //
+
+// Operator precedences as the parser binds them, numbered like go/token's
+// so that the spacing rules below read the same.
+const (
+	lowestPrec  = 0
+	unaryPrec   = 6
+	highestPrec = 7
+)
+
+// precedence returns the binding strength of the binary operator op.
+func precedence(op string) int {
+	switch lexer.TokenType(op) {
+	case lexer.EQ, lexer.NOT_EQ:
+		return 2
+	case lexer.LT, lexer.LTE, lexer.GT, lexer.GTE:
+		return 3
+	case lexer.PLUS, lexer.MINUS, lexer.PIPE:
+		return 4
+	case lexer.ASTERISK, lexer.SLASH, lexer.AMPERSAND:
+		return 5
+	}
+	return lowestPrec
+}
+
+// walkBinary reports whether e contains operators of precedence 4 and 5
+// (outside of parentheses the printer inserts), and the precedence of the
+// tightest operator pair that must be separated by blanks to not combine
+// into another token.
+func walkBinary(e *ast.InfixExpression) (has4, has5 bool, maxProblem int) {
+	switch precedence(e.Operator) {
+	case 4:
+		has4 = true
+	case 5:
+		has5 = true
+	}
+
+	switch l := e.Left.(type) {
+	case *ast.InfixExpression:
+		if precedence(l.Operator) < precedence(e.Operator) {
+			// parens will be inserted.
+			// pretend this is a *ast.ParenExpr and do nothing.
+			break
+		}
+		h4, h5, mp := walkBinary(l)
+		has4 = has4 || h4
+		has5 = has5 || h5
+		maxProblem = max(maxProblem, mp)
+	}
+
+	switch r := e.Right.(type) {
+	case *ast.InfixExpression:
+		if precedence(r.Operator) <= precedence(e.Operator) {
+			// parens will be inserted.
+			// pretend this is a *ast.ParenExpr and do nothing.
+			break
+		}
+		h4, h5, mp := walkBinary(r)
+		has4 = has4 || h4
+		has5 = has5 || h5
+		maxProblem = max(maxProblem, mp)
+
+	case *ast.StarExpr:
+		if e.Operator == string(lexer.SLASH) { // `*/`
+			maxProblem = 5
+		}
+
+	case *ast.PrefixExpression:
+		switch e.Operator + r.Operator {
+		case "/*", "&&", "&^":
+			maxProblem = 5
+		case "++", "--":
+			maxProblem = max(maxProblem, 4)
+		}
+	}
+	return
+}
+
+// cutoff returns the precedence below which the operators of e are
+// surrounded by blanks.
+func cutoff(e *ast.InfixExpression, depth int) int {
+	has4, has5, maxProblem := walkBinary(e)
+	if maxProblem > 0 {
+		return maxProblem + 1
+	}
+	if has4 && has5 {
+		if depth == 1 {
+			return 5
+		}
+		return 4
+	}
+	if depth == 1 {
+		return 6
+	}
+	return 4
+}
+
+func diffPrec(expr ast.Expression, prec int) int {
+	x, ok := expr.(*ast.InfixExpression)
+	if !ok || prec != precedence(x.Operator) {
+		return 1
+	}
+	return 0
+}
+
+func reduceDepth(depth int) int {
+	depth--
+	if depth < 1 {
+		depth = 1
+	}
+	return depth
+}
+
+// binaryExpr prints a binary expression, using blanks around its operators
+// to show their grouping: in
+//
+//	x + y*z
+//	x*y + z
+//
+// the tighter binding operator goes without blanks. depth is the nesting
+// depth of the expression; the deeper the expression, the fewer blanks.
+func (p *printer) binaryExpr(x *ast.InfixExpression, prec1, cutoff, depth int) {
+	prec := precedence(x.Operator)
+	if prec < prec1 {
+		// parenthesis needed
+		// Note: The parser inserts an ast.ParenExpr node; thus this case
+		//       can only occur if the tree is built in a different way.
+		p.print(lexer.LPAREN)
+		p.expr0(x, reduceDepth(depth)) // parentheses undo one level of depth
+		p.print(lexer.RPAREN)
+		return
+	}
+
+	printBlank := prec < cutoff
+
+	ws := indent
+	p.expr1(x.Left, prec, depth+diffPrec(x.Left, prec))
+	if printBlank {
+		p.print(blank)
+	}
+	xline := p.pos.Line // before the operator (it may be on the next line!)
+	yline := p.lineFor(x.Right.Pos())
+	p.setPos(x.Token.Pos)
+	p.print(lexer.TokenType(x.Operator))
+	if xline != yline && xline > 0 && yline > 0 {
+		// at least one line break, but respect an extra empty line
+		// in the source
+		if p.linebreak(yline, 1, ws, true) > 0 {
+			ws = ignore
+			printBlank = false // no blank after line break
+		}
+	}
+	if printBlank {
+		p.print(blank)
+	}
+	p.expr1(x.Right, prec+1, depth+1)
+	if ws == ignore {
+		p.print(unindent)
+	}
+}
+
+func isBinary(expr ast.Expression) bool {
+	_, ok := expr.(*ast.InfixExpression)
+	return ok
+}
+
+func (p *printer) expr1(expr ast.Expression, prec1, depth int) {
+	p.setPos(expr.Pos())
+
+	switch x := expr.(type) {
+	case *ast.Identifier:
+		p.print(x)
+
+	case *ast.InfixExpression:
+		p.binaryExpr(x, prec1, cutoff(x, depth), depth)
+
+	case *ast.KeyValueExpr:
+		p.expr(x.Key)
+		p.setPos(x.Token.Pos)
+		p.print(lexer.COLON, blank)
+		p.expr(x.Value)
+
+	case *ast.StarExpr:
+		if unaryPrec < prec1 {
+			// parenthesis needed
+			p.print(lexer.LPAREN, lexer.ASTERISK)
+			p.expr(x.X)
+			p.print(lexer.RPAREN)
+		} else {
+			// no parenthesis needed
+			p.print(lexer.ASTERISK)
+			p.expr(x.X)
+		}
+
+	case *ast.PrefixExpression:
+		if unaryPrec < prec1 {
+			// parenthesis needed
+			p.print(lexer.LPAREN)
+			p.expr(x)
+			p.print(lexer.RPAREN)
+		} else {
+			// no parenthesis needed
+			p.print(lexer.TokenType(x.Operator))
+			p.expr1(x.Right, unaryPrec, depth)
+		}
+
+	case *ast.IntegerLiteral, *ast.FloatLiteral, *ast.StringLiteral, *ast.RuneLiteral:
+		p.print(x)
+
+	case *ast.FuncLit:
+		p.print(lexer.FUNC)
+		// See the comment in funcDecl about how the header size is computed.
+		startCol := p.out.Column - len("func")
+		p.signature(nil, x.Type)
+		p.funcBody(p.distanceFrom(x.Pos(), startCol), blank, x.Body)
+
+	case *ast.ParenExpr:
+		if _, hasParens := x.X.(*ast.ParenExpr); hasParens {
+			// don't print parentheses around an already parenthesized expression
+			p.expr0(x.X, depth)
+		} else {
+			p.print(lexer.LPAREN)
+			p.expr0(x.X, reduceDepth(depth)) // parentheses undo one level of depth
+			p.setPos(x.Rparen.Pos)
+			p.print(lexer.RPAREN)
+		}
+
+	case *ast.SelectorExpr:
+		p.selectorExpr(x, depth, false)
+
+	case *ast.TypeAssertExpr:
+		p.expr1(x.X, highestPrec, depth)
+		p.setPos(x.Token.Pos)
+		p.print(lexer.PERIOD, lexer.LPAREN)
+		if x.Type != nil {
+			p.expr(x.Type)
+		} else {
+			p.print(lexer.TYPE)
+		}
+		p.setPos(x.Rparen.Pos)
+		p.print(lexer.RPAREN)
+
+	case *ast.IndexExpr:
+		p.expr1(x.X, highestPrec, 1)
+		p.setPos(x.Token.Pos)
+		p.print(lexer.LBRACKET)
+		p.expr0(x.Index, depth+1)
+		p.setPos(x.Rbrack.Pos)
+		p.print(lexer.RBRACKET)
+
+	case *ast.IndexListExpr:
+		p.expr1(x.X, highestPrec, 1)
+		p.setPos(x.Token.Pos)
+		p.print(lexer.LBRACKET)
+		p.exprList(x.Token.Pos, x.Indices, depth+1, commaTerm, x.Rbrack.Pos)
+		p.setPos(x.Rbrack.Pos)
+		p.print(lexer.RBRACKET)
+
+	case *ast.SliceExpr:
+		p.expr1(x.X, highestPrec, 1)
+		p.setPos(x.Token.Pos)
+		p.print(lexer.LBRACKET)
+		indices := []ast.Expression{x.Low, x.High}
+		if x.Max != nil {
+			indices = append(indices, x.Max)
+		}
+		// determine if we need extra blanks around ':'
+		var needsBlanks bool
+		if depth <= 1 {
+			var indexCount int
+			var hasBinaries bool
+			for _, x := range indices {
+				if x != nil {
+					indexCount++
+					if isBinary(x) {
+						hasBinaries = true
+					}
+				}
+			}
+			if indexCount > 1 && hasBinaries {
+				needsBlanks = true
+			}
+		}
+		for i, x := range indices {
+			if i > 0 {
+				if indices[i-1] != nil && needsBlanks {
+					p.print(blank)
+				}
+				p.print(lexer.COLON)
+				if x != nil && needsBlanks {
+					p.print(blank)
+				}
+			}
+			if x != nil {
+				p.expr0(x, depth+1)
+			}
+		}
+		p.setPos(x.Rbrack.Pos)
+		p.print(lexer.RBRACKET)
+
+	case *ast.CallExpr:
+		if len(x.Args) > 1 {
+			depth++
+		}
+
+		// Conversions to literal function types or <-chan
+		// types require parentheses around the type.
+		paren := false
+		switch t := x.Fun.(type) {
+		case *ast.FuncType:
+			paren = true
+		case *ast.ChanType:
+			paren = t.Dir == ast.RECV
+		}
+		if paren {
+			p.print(lexer.LPAREN)
+		}
+		wasIndented := p.possibleSelectorExpr(x.Fun, highestPrec, depth)
+		if paren {
+			p.print(lexer.RPAREN)
+		}
+
+		p.setPos(x.Token.Pos)
+		p.print(lexer.LPAREN)
+		if x.Ellipsis.Type != "" {
+			p.exprList(x.Token.Pos, x.Args, depth, 0, x.Ellipsis.Pos)
+			p.setPos(x.Ellipsis.Pos)
+			p.print(lexer.ELLIPSIS)
+			if x.Rparen.Pos.IsValid() && p.lineFor(x.Ellipsis.Pos) < p.lineFor(x.Rparen.Pos) {
+				p.print(lexer.COMMA, formfeed)
+			}
+		} else {
+			p.exprList(x.Token.Pos, x.Args, depth, commaTerm, x.Rparen.Pos)
+		}
+		p.setPos(x.Rparen.Pos)
+		p.print(lexer.RPAREN, noExtraLinebreak)
+		if wasIndented {
+			p.print(unindent)
+		}
+
+	case *ast.CompositeLit:
+		// composite literal elements that are composite literals themselves may have the type omitted
+		if x.Type != nil {
+			p.expr1(x.Type, highestPrec, depth)
+		}
+		p.level++
+		p.setPos(x.Token.Pos)
+		p.print(lexer.LBRACE)
+		p.exprList(x.Token.Pos, x.Elts, 1, commaTerm, x.Rbrace.Pos)
+		// do not insert extra line break following a /*-style comment
+		// before the closing '}' as it might break the code if there
+		// is no trailing ','
+		mode := noExtraLinebreak
+		// do not insert extra blank following a /*-style comment
+		// before the closing '}' unless the literal is empty
+		if len(x.Elts) > 0 {
+			mode |= noExtraBlank
+		}
+		// need the initial indent to print lone comments with
+		// the proper level of indentation
+		p.print(indent, unindent, mode)
+		p.setPos(x.Rbrace.Pos)
+		p.print(lexer.RBRACE, mode)
+		p.level--
+
+	case *ast.Ellipsis:
+		p.print(lexer.ELLIPSIS)
+		if x.Elt != nil {
+			p.expr(x.Elt)
+		}
+
+	case *ast.ArrayType:
+		p.print(lexer.LBRACKET)
+		if x.Len != nil {
+			p.expr(x.Len)
+		}
+		p.print(lexer.RBRACKET)
+		p.expr(x.Elt)
+
+	case *ast.StructType:
+		p.print(lexer.STRUCT)
+		p.fieldList(x.Fields, true)
+
+	case *ast.FuncType:
+		p.print(lexer.FUNC)
+		p.signature(nil, x)
+
+	case *ast.InterfaceType:
+		p.print(lexer.INTERFACE)
+		p.fieldList(x.Methods, false)
+
+	case *ast.MapType:
+		p.print(lexer.MAP, lexer.LBRACKET)
+		p.expr(x.Key)
+		p.print(lexer.RBRACKET)
+		p.expr(x.Value)
+
+	case *ast.ChanType:
+		switch x.Dir {
+		case ast.SEND | ast.RECV:
+			p.print(lexer.CHAN)
+		case ast.RECV:
+			p.print(lexer.ARROW, lexer.CHAN)
+		case ast.SEND:
+			p.print(lexer.CHAN, lexer.ARROW)
+		}
+		p.print(blank)
+		p.expr(x.Value)
+
+	default:
+		panic("printer: unexpected expression type " + typeName(expr))
+	}
+}
+
+// possibleSelectorExpr prints expr, reporting whether it is a selector
+// expression whose selector was moved to an indented line.
+func (p *printer) possibleSelectorExpr(expr ast.Expression, prec1, depth int) bool {
+	if x, ok := expr.(*ast.SelectorExpr); ok {
+		return p.selectorExpr(x, depth, true)
+	}
+	p.expr1(expr, prec1, depth)
+	return false
+}
+
+// selectorExpr handles an *ast.SelectorExpr node and reports whether x spans
+// multiple lines.
+func (p *printer) selectorExpr(x *ast.SelectorExpr, depth int, isMethod bool) bool {
+	p.expr1(x.X, highestPrec, depth)
+	p.print(lexer.PERIOD)
+	if line := p.lineFor(x.Sel.Pos()); p.pos.IsValid() && p.pos.Line < line {
+		p.print(indent, newline)
+		p.setPos(x.Sel.Pos())
+		p.print(x.Sel)
+		if !isMethod {
+			p.print(unindent)
+		}
+		return true
+	}
+	p.setPos(x.Sel.Pos())
+	p.print(x.Sel)
+	return false
+}
+
+func (p *printer) expr0(x ast.Expression, depth int) {
+	p.expr1(x, lowestPrec, depth)
+}
+
+func (p *printer) expr(x ast.Expression) {
+	const depth = 1
+	p.expr1(x, lowestPrec, depth)
+}
+
+// ------- Statements -------- //
+
+// stmtList prints a list of statements, each on its own line. nindent is
+// the indentation of the list relative to the enclosing construct; a list
+// of case clauses is not indented. nextIsRBrace reports whether the list is
+// followed by a closing brace.
+func (p *printer) stmtList(list []ast.Statement, nindent int, nextIsRBrace bool) {
+	if nindent > 0 {
+		p.print(indent)
+	}
+	var line int
+	for i, s := range list {
+		// nindent == 0 only for lists of switch/select case clauses;
+		// in those cases each clause is a new section
+		if len(p.output) > 0 {
+			// only print line break if we are not at the beginning of the output
+			// (i.e., we are not printing only a partial program)
+			p.linebreak(p.lineFor(s.Pos()), 1, ignore, i == 0 || nindent == 0 || p.linesFrom(line) > 0)
+		}
+		p.recordLine(&line)
+		p.stmt(s, nextIsRBrace && i == len(list)-1)
+		// labeled statements put labels on a separate line, but here
+		// we only care about the start line of the actual statement
+		// without label - correct line for each label
+		for t := s; ; {
+			lt, _ := t.(*ast.LabeledStmt)
+			if lt == nil {
+				break
+			}
+			line++
+			t = lt.Stmt
+		}
+	}
+	if nindent > 0 {
+		p.print(unindent)
+	}
+}
+
+// block prints a block statement; it always spans at least two lines.
+func (p *printer) block(b *ast.BlockStatement, nindent int) {
+	p.setPos(b.Token.Pos)
+	p.print(lexer.LBRACE)
+	p.stmtList(b.Statements, nindent, true)
+	p.linebreak(p.lineFor(b.Rbrace.Pos), 1, ignore, true)
+	p.setPos(b.Rbrace.Pos)
+	p.print(lexer.RBRACE)
+}
+
+// caseBlock prints the braced clauses of a switch or select statement,
+// which have no block of their own.
+func (p *printer) caseBlock(clauses []ast.Statement, rbrace lexer.Pos) {
+	p.print(lexer.LBRACE)
+	p.stmtList(clauses, 0, true)
+	p.linebreak(p.lineFor(rbrace), 1, ignore, true)
+	p.setPos(rbrace)
+	p.print(lexer.RBRACE)
+}
+
+func isTypeName(x ast.Expression) bool {
+	switch t := x.(type) {
+	case *ast.Identifier:
+		return true
+	case *ast.SelectorExpr:
+		return isTypeName(t.X)
+	}
+	return false
+}
+
+// stripParens returns x without its redundant outer parentheses. They are
+// kept around an expression with a composite literal starting with a type
+// name, which would otherwise be read as a block.
+func stripParens(x ast.Expression) ast.Expression {
+	if px, strip := x.(*ast.ParenExpr); strip {
+		ast.Inspect(px.X, func(node ast.Node) bool {
+			switch x := node.(type) {
+			case *ast.ParenExpr:
+				// parentheses protect enclosed composite literals
+				return false
+			case *ast.CompositeLit:
+				if isTypeName(x.Type) {
+					strip = false // do not strip parentheses
+				}
+				return false
+			}
+			// in all other cases, keep inspecting
+			return true
+		})
+		if strip {
+			return stripParens(px.X)
+		}
+	}
+	return x
+}
+
+func stripParensAlways(x ast.Expression) ast.Expression {
+	if x, ok := x.(*ast.ParenExpr); ok {
+		return stripParensAlways(x.X)
+	}
+	return x
+}
+
+// controlClause prints the header of an if, for or switch statement, up to
+// the opening brace.
+func (p *printer) controlClause(isForStmt bool, init ast.Statement, expr ast.Expression, post ast.Statement) {
+	p.print(blank)
+	needsBlank := false
+	if init == nil && post == nil {
+		// no semicolons required
+		if expr != nil {
+			p.expr(stripParens(expr))
+			needsBlank = true
+		}
+	} else {
+		// all semicolons required
+		// (they are not separators, print them explicitly)
+		if init != nil {
+			p.stmt(init, false)
+		}
+		p.print(lexer.SEMICOLON, blank)
+		if expr != nil {
+			p.expr(stripParens(expr))
+			needsBlank = true
+		}
+		if isForStmt {
+			p.print(lexer.SEMICOLON, blank)
+			needsBlank = false
+			if post != nil {
+				p.stmt(post, false)
+				needsBlank = true
+			}
+		}
+	}
+	if needsBlank {
+		p.print(blank)
+	}
+}
+
+// indentList reports whether an expression list would look better if it
+// were indented wholesale (starting with the very first element, rather
+// than starting at the first line break).
+func (p *printer) indentList(list []ast.Expression) bool {
+	// Heuristic: indentList reports whether there are more than one multi-
+	// line element in the list, or if there is any element that is not
+	// starting on the same line as the previous one ends.
+	if len(list) >= 2 {
+		b := p.lineFor(list[0].Pos())
+		e := p.lineFor(list[len(list)-1].End())
+		if 0 < b && b < e {
+			// list spans multiple lines
+			n := 0 // multi-line element count
+			line := b
+			for _, x := range list {
+				xb := p.lineFor(x.Pos())
+				xe := p.lineFor(x.End())
+				if line < xb {
+					// x is not starting on the same
+					// line as the previous one ended
+					return true
+				}
+				if xb < xe {
+					// x is a multi-line element
+					n++
+				}
+				line = xe
+			}
+			return n > 1
+		}
+	}
+	return false
+}
+
+func (p *printer) stmt(stmt ast.Statement, nextIsRBrace bool) {
+	p.setPos(stmt.Pos())
+
+	switch s := stmt.(type) {
+	case *ast.DeclStmt:
+		p.decl(s.Decl)
+
+	case *ast.LabeledStmt:
+		// a "correcting" unindent immediately following a line break
+		// is applied before the line break if there is no comment
+		// between (see writeWhitespace)
+		p.print(unindent)
+		p.expr(s.Label)
+		p.setPos(s.Token.Pos)
+		p.print(lexer.COLON, indent)
+		if s.Stmt == nil {
+			// label right before a closing brace
+			break
+		}
+		p.linebreak(p.lineFor(s.Stmt.Pos()), 1, ignore, true)
+		p.stmt(s.Stmt, nextIsRBrace)
+
+	case *ast.ExprStmt:
+		const depth = 1
+		p.expr0(s.X, depth)
+
+	case *ast.SendStmt:
+		const depth = 1
+		p.expr0(s.Chan, depth)
+		p.print(blank)
+		p.setPos(s.Token.Pos)
+		p.print(lexer.ARROW, blank)
+		p.expr0(s.Value, depth)
+
+	case *ast.IncDecStmt:
+		const depth = 1
+		p.expr0(s.X, depth+1)
+		p.setPos(s.Token.Pos)
+		p.print(s.Token.Type)
+
+	case *ast.AssignStmt:
+		depth := 1
+		if len(s.Lhs) > 1 && len(s.Rhs) > 1 {
+			depth++
+		}
+		p.exprList(s.Pos(), s.Lhs, depth, 0, s.Token.Pos)
+		p.print(blank)
+		p.setPos(s.Token.Pos)
+		p.print(s.Token.Type, blank)
+		p.exprList(s.Token.Pos, s.Rhs, depth, 0, lexer.NoPos)
+
+	case *ast.GoStmt:
+		p.print(lexer.GO, blank)
+		p.expr(s.Call)
+
+	case *ast.DeferStmt:
+		p.print(lexer.DEFER, blank)
+		p.expr(s.Call)
+
+	case *ast.ReturnStmt:
+		p.print(lexer.RETURN)
+		if s.Results != nil {
+			p.print(blank)
+			// Indent a list whose elements don't simply follow each
+			// other wholesale; see indentList.
+			if p.indentList(s.Results) {
+				p.print(indent)
+				// Use NoPos so that a newline never goes before
+				// the results.
+				p.exprList(lexer.NoPos, s.Results, 1, noIndent, lexer.NoPos)
+				p.print(unindent)
+			} else {
+				p.exprList(lexer.NoPos, s.Results, 1, 0, lexer.NoPos)
+			}
+		}
+
+	case *ast.BranchStmt:
+		p.print(s.Token.Type)
+		if s.Label != nil {
+			p.print(blank)
+			p.expr(s.Label)
+		}
+
+	case *ast.BlockStatement:
+		p.block(s, 1)
+
+	case *ast.IfStmt:
+		p.print(lexer.IF)
+		p.controlClause(false, s.Init, s.Cond, nil)
+		p.block(s.Body, 1)
+		if s.Else != nil {
+			p.print(blank, lexer.ELSE, blank)
+			switch s.Else.(type) {
+			case *ast.BlockStatement, *ast.IfStmt:
+				p.stmt(s.Else, nextIsRBrace)
+			default:
+				// This can only happen with an incorrectly built
+				// tree. Permit it but print so that it can be
+				// parsed without errors.
+				p.print(lexer.LBRACE, indent, formfeed)
+				p.stmt(s.Else, true)
+				p.print(unindent, formfeed, lexer.RBRACE)
+			}
+		}
+
+	case *ast.CaseClause:
+		if s.List != nil {
+			p.print(lexer.CASE, blank)
+			p.exprList(s.Pos(), s.List, 1, 0, s.Colon.Pos)
+		} else {
+			p.print(lexer.DEFAULT)
+		}
+		p.setPos(s.Colon.Pos)
+		p.print(lexer.COLON)
+		p.stmtList(s.Body, 1, nextIsRBrace)
+
+	case *ast.SwitchStmt:
+		p.print(lexer.SWITCH)
+		p.controlClause(false, s.Init, s.Tag, nil)
+		clauses := make([]ast.Statement, len(s.Cases))
+		for i, c := range s.Cases {
+			clauses[i] = c
+		}
+		p.caseBlock(clauses, s.Rbrace.Pos)
+
+	case *ast.TypeSwitchStmt:
+		p.print(lexer.SWITCH)
+		if s.Init != nil {
+			p.print(blank)
+			p.stmt(s.Init, false)
+			p.print(lexer.SEMICOLON)
+		}
+		p.print(blank)
+		if s.Binding != nil {
+			p.expr(s.Binding)
+			p.print(blank, lexer.DEFINE, blank)
+		}
+		p.expr1(s.X, highestPrec, 1)
+		p.print(lexer.PERIOD, lexer.LPAREN, lexer.TYPE, lexer.RPAREN, blank)
+		clauses := make([]ast.Statement, len(s.Cases))
+		for i, c := range s.Cases {
+			clauses[i] = c
+		}
+		p.caseBlock(clauses, s.Rbrace.Pos)
+
+	case *ast.CommClause:
+		if s.Comm != nil {
+			p.print(lexer.CASE, blank)
+			p.stmt(s.Comm, false)
+		} else {
+			p.print(lexer.DEFAULT)
+		}
+		p.setPos(s.Colon.Pos)
+		p.print(lexer.COLON)
+		p.stmtList(s.Body, 1, nextIsRBrace)
+
+	case *ast.SelectStmt:
+		p.print(lexer.SELECT, blank)
+		if len(s.Cases) == 0 && !p.commentBefore(p.posFor(s.Rbrace.Pos)) {
+			// print empty select statement w/o comments on one line
+			p.print(lexer.LBRACE)
+			p.setPos(s.Rbrace.Pos)
+			p.print(lexer.RBRACE)
+			break
+		}
+		clauses := make([]ast.Statement, len(s.Cases))
+		for i, c := range s.Cases {
+			clauses[i] = c
+		}
+		p.caseBlock(clauses, s.Rbrace.Pos)
+
+	case *ast.ForStmt:
+		p.print(lexer.FOR)
+		p.controlClause(true, s.Init, s.Cond, s.Post)
+		p.block(s.Body, 1)
+
+	case *ast.RangeStmt:
+		p.print(lexer.FOR, blank)
+		if s.Key != nil {
+			p.expr(s.Key)
+			if s.Value != nil {
+				// use position of value following the comma as
+				// comma position for correct comment placement
+				p.setPos(s.Value.Pos())
+				p.print(lexer.COMMA, blank)
+				p.expr(s.Value)
+			}
+			p.print(blank)
+			p.setPos(s.Tok.Pos)
+			p.print(s.Tok.Type, blank)
+		}
+		p.print(lexer.RANGE, blank)
+		p.expr(stripParens(s.X))
+		p.print(blank)
+		p.block(s.Body, 1)
+
+	default:
+		panic("printer: unexpected statement type " + typeName(stmt))
+	}
+}
+
+// ------- Declarations -------- //
+
+// keepTypeColumn returns the keepType flags for the value specs of a
+// const or var group: a spec keeps an (empty) type column if it is part of
+// a run of specs with values in which some spec has a type. This keeps
+// the values of such a run aligned:
+//
+//	const (
+//		a      = 1
+//		b int  = 2
+//		c      = 3
+//	)
+func keepTypeColumn(specs []ast.Spec) []bool {
+	m := make([]bool, len(specs))
+
+	populate := func(i, j int, keepType bool) {
+		if keepType {
+			for ; i < j; i++ {
+				m[i] = true
+			}
+		}
+	}
+
+	i0 := -1 // if i0 >= 0 we are in a run and i0 is the start of the run
+	var keepType bool
+	for i, s := range specs {
+		t := s.(*ast.ValueSpec)
+		if t.Values != nil {
+			if i0 < 0 {
+				// start of a run of ValueSpecs with non-nil Values
+				i0 = i
+				keepType = false
+			}
+		} else {
+			if i0 >= 0 {
+				// end of a run
+				populate(i0, i, keepType)
+				i0 = -1
+			}
+		}
+		if t.Type != nil {
+			keepType = true
+		}
+	}
+	if i0 >= 0 {
+		// end of a run
+		populate(i0, len(specs), keepType)
+	}
+
+	return m
+}
+
+// valueSpec prints a spec of a const or var group in columns of names,
+// types, values and line comments.
+func (p *printer) valueSpec(s *ast.ValueSpec, keepType bool) {
+	p.identList(s.Names, false) // always present
+	extraTabs := 3
+	if s.Type != nil || keepType {
+		p.print(vtab)
+		extraTabs--
+	}
+	if s.Type != nil {
+		p.expr(s.Type)
+	}
+	if s.Values != nil {
+		p.print(vtab, lexer.ASSIGN, blank)
+		p.exprList(lexer.NoPos, s.Values, 1, 0, lexer.NoPos)
+		extraTabs--
+	}
+	if p.lineComment(s.End()) {
+		for ; extraTabs > 0; extraTabs-- {
+			p.print(vtab)
+		}
+	}
+}
+
+// spec prints a single spec; n is the number of specs of its declaration.
+func (p *printer) spec(spec ast.Spec, n int, doIndent bool) {
+	switch s := spec.(type) {
+	case *ast.ImportSpec:
+		if s.Name != nil {
+			p.expr(s.Name)
+			p.print(blank)
+		}
+		p.expr(s.Path)
+
+	case *ast.ValueSpec:
+		p.identList(s.Names, doIndent) // always present
+		if s.Type != nil {
+			p.print(blank)
+			p.expr(s.Type)
+		}
+		if s.Values != nil {
+			p.print(blank, lexer.ASSIGN, blank)
+			p.exprList(lexer.NoPos, s.Values, 1, 0, lexer.NoPos)
+		}
+
+	case *ast.TypeSpec:
+		p.expr(s.Name)
+		if s.TypeParams != nil {
+			p.parameters(s.TypeParams, typeTParam)
+		}
+		if n == 1 {
+			p.print(blank)
+		} else {
+			p.print(vtab)
+		}
+		if s.Assign.Type != "" {
+			p.print(lexer.ASSIGN, blank)
+		}
+		p.expr(s.Type)
+
+	default:
+		panic("printer: unexpected spec type " + typeName(spec))
+	}
+}
+
+func (p *printer) genDecl(d *ast.GenDecl) {
+	p.setPos(d.Pos())
+	p.print(d.Token.Type, blank)
+
+	if d.Lparen.Type != "" || len(d.Specs) != 1 {
+		// group of parenthesized declarations
+		p.setPos(d.Lparen.Pos)
+		p.print(lexer.LPAREN)
+		if n := len(d.Specs); n > 0 {
+			p.print(indent, formfeed)
+			if n > 1 && (d.Token.Type == lexer.CONST || d.Token.Type == lexer.VAR) {
+				// two or more grouped const/var declarations:
+				// determine if the type column must be kept
+				keepType := keepTypeColumn(d.Specs)
+				var line int
+				for i, s := range d.Specs {
+					if i > 0 {
+						p.linebreak(p.lineFor(s.Pos()), 1, ignore, p.linesFrom(line) > 0)
+					}
+					p.recordLine(&line)
+					p.valueSpec(s.(*ast.ValueSpec), keepType[i])
+				}
+			} else {
+				var line int
+				for i, s := range d.Specs {
+					if i > 0 {
+						p.linebreak(p.lineFor(s.Pos()), 1, ignore, p.linesFrom(line) > 0)
+					}
+					p.recordLine(&line)
+					p.spec(s, n, false)
+				}
+			}
+			p.print(unindent, formfeed)
+		}
+		p.setPos(d.Rparen.Pos)
+		p.print(lexer.RPAREN)
+
+	} else if len(d.Specs) > 0 {
+		// single declaration
+		p.spec(d.Specs[0], 1, true)
+	}
+}
+
+// nodeSize determines the size of n in chars after formatting. The result
+// is <= maxSize if the node fits on one line with at most maxSize chars
+// and the formatted output doesn't contain any control chars. Otherwise,
+// the result is > maxSize.
+func (p *printer) nodeSize(n ast.Node, maxSize int) (size int) {
+	// nodeSize invokes the printer, which may invoke nodeSize
+	// recursively. For deep composite literal nests, this can
+	// lead to an exponential algorithm. Remember previous
+	// results to prune the recursion.
+	if size, found := p.nodeSizes[n]; found {
+		return size
+	}
+
+	size = maxSize + 1 // assume n doesn't fit
+	p.nodeSizes[n] = size
+
+	// nodeSize computation must be independent of particular
+	// style so that we always get the same decision; print
+	// in RawFormat
+	cfg := Config{Mode: RawFormat}
+	var counter sizeCounter
+	if err := cfg.fprint(&counter, p.fset, n, p.nodeSizes); err != nil {
+		return
+	}
+	if counter.size <= maxSize && !counter.hasNewline {
+		// n fits in a single line
+		size = counter.size
+		p.nodeSizes[n] = size
+	}
+	return
+}
+
+// sizeCounter is an io.Writer which counts the number of bytes written,
+// as well as whether a newline character was seen.
+type sizeCounter struct {
+	hasNewline bool
+	size       int
+}
+
+func (c *sizeCounter) Write(p []byte) (int, error) {
+	if !c.hasNewline {
+		for _, b := range p {
+			if b == '\n' || b == '\f' {
+				c.hasNewline = true
+				break
+			}
+		}
+	}
+	c.size += len(p)
+	return len(p), nil
+}
+
+// bodySize is like nodeSize but it is specialized for function bodies.
+func (p *printer) bodySize(b *ast.BlockStatement, maxSize int) int {
+	pos1 := b.Pos()
+	pos2 := b.Rbrace.Pos
+	if pos1.IsValid() && pos2.IsValid() && p.lineFor(pos1) != p.lineFor(pos2) {
+		// opening and closing brace are on different lines - don't make it a one-liner
+		return maxSize + 1
+	}
+	if len(b.Statements) > 5 {
+		// too many statements - don't make it a one-liner
+		return maxSize + 1
+	}
+	// otherwise, estimate body size
+	bodySize := p.commentSizeBefore(p.posFor(pos2))
+	for i, s := range b.Statements {
+		if bodySize > maxSize {
+			break // no need to continue
+		}
+		if i > 0 {
+			bodySize += 2 // space for a semicolon and blank
+		}
+		bodySize += p.nodeSize(s, maxSize)
+	}
+	return bodySize
+}
+
+// funcBody prints a function body following a function header of given
+// headerSize. If the header's and block's size are "small enough" and the
+// block is "simple enough", the block is printed on the current line,
+// without line breaks, spaced from the header by sep. Otherwise the block's
+// opening "{" is printed on the current line, followed by lines for the
+// block's statements and its closing "}".
+func (p *printer) funcBody(headerSize int, sep whiteSpace, b *ast.BlockStatement) {
+	if b == nil {
+		return
+	}
+
+	// save/restore composite literal nesting level
+	defer func(level int) {
+		p.level = level
+	}(p.level)
+	p.level = 0
+
+	const maxSize = 100
+	if headerSize+p.bodySize(b, maxSize) <= maxSize {
+		p.print(sep)
+		p.setPos(b.Token.Pos)
+		p.print(lexer.LBRACE)
+		if len(b.Statements) > 0 {
+			p.print(blank)
+			for i, s := range b.Statements {
+				if i > 0 {
+					p.print(lexer.SEMICOLON, blank)
+				}
+				p.stmt(s, i == len(b.Statements)-1)
+			}
+			p.print(blank)
+		}
+		p.print(noExtraLinebreak)
+		p.setPos(b.Rbrace.Pos)
+		p.print(lexer.RBRACE, noExtraLinebreak)
+		return
+	}
+
+	if sep != ignore {
+		p.print(blank) // always use blank
+	}
+	p.block(b, 1)
+}
+
+// distanceFrom returns the column difference between p.out (the current output
+// position) and startOutCol. If the start position is on a different line from
+// the current position (or either is unknown), the result is infinity.
+func (p *printer) distanceFrom(startPos lexer.Pos, startOutCol int) int {
+	if startPos.IsValid() && p.pos.IsValid() && p.posFor(startPos).Line == p.pos.Line {
+		return p.out.Column - startOutCol
+	}
+	return infinity
+}
+
+func (p *printer) funcDecl(d *ast.FuncDecl) {
+	p.setPos(d.Pos())
+	p.print(lexer.FUNC, blank)
+	// We have to save startCol only after emitting FUNC; otherwise it can be on a
+	// different line (all whitespace preceding the FUNC is emitted only when the
+	// FUNC is emitted).
+	startCol := p.out.Column - len("func ")
+	if d.Recv != nil {
+		p.parameters(d.Recv, funcParam) // method: print receiver
+		p.print(blank)
+	}
+	p.expr(d.Name)
+	p.signature(d.TypeParams, d.Type)
+	p.funcBody(p.distanceFrom(d.Pos(), startCol), vtab, d.Body)
+}
+
+func (p *printer) decl(decl ast.Declaration) {
+	switch d := decl.(type) {
+	case *ast.GenDecl:
+		p.genDecl(d)
+	case *ast.FuncDecl:
+		p.funcDecl(d)
+	default:
+		panic("printer: unexpected declaration type " + typeName(decl))
+	}
+}
+
+// ------- Files -------- //
+
+func declToken(decl ast.Declaration) lexer.TokenType {
+	switch d := decl.(type) {
+	case *ast.GenDecl:
+		return d.Token.Type
+	case *ast.FuncDecl:
+		return lexer.FUNC
+	}
+	return lexer.ILLEGAL
+}
+
+func declDoc(decl ast.Declaration) *ast.CommentGroup {
+	switch d := decl.(type) {
+	case *ast.GenDecl:
+		return d.Doc
+	case *ast.FuncDecl:
+		return d.Doc
+	}
+	return nil
+}
+
+// declList prints the top-level declarations of a file, separated by an
+// empty line where the kind of declaration changes or a declaration is
+// documented.
+func (p *printer) declList(list []ast.Declaration) {
+	tok := lexer.ILLEGAL
+	for _, d := range list {
+		prev := tok
+		tok = declToken(d)
+		if len(p.output) > 0 {
+			// only print line break if we are not at the beginning of the output
+			// (i.e., we are not printing only a partial program)
+			min := 1
+			if prev != tok || declDoc(d) != nil {
+				min = 2
+			}
+			// start a new section if the next declaration is a function
+			// that spans multiple lines
+			p.linebreak(p.lineFor(d.Pos()), min, ignore, tok == lexer.FUNC && p.numLines(d) > 1)
+		}
+		p.decl(d)
+	}
+}
+
+// numLines returns the number of lines spanned by n, or infinity if n has
+// no positions.
+func (p *printer) numLines(n ast.Node) int {
+	if from := n.Pos(); from.IsValid() {
+		if to := n.End(); to.IsValid() {
+			return p.lineFor(to) - p.lineFor(from) + 1
+		}
+	}
+	return infinity
+}
+
+func (p *printer) file(src *ast.File) {
+	p.setPos(src.Pos())
+	p.print(lexer.PACKAGE, blank)
+	p.expr(src.Name)
+	p.declList(src.Decls)
+	p.print(newline)
+}
+
+// typeName returns the name of the dynamic type of n, for error messages.
+func typeName(n ast.Node) string {
+	return fmt.Sprintf("%T", n)
+}
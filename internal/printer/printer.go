@@ -0,0 +1,1127 @@
+// Copyright 2009 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE-GO file.
+//
+// Adapted from go/printer.
+
+// Package printer prints syntax trees as canonically formatted Go source:
+// tabs for indentation, blanks for alignment, aligned struct fields, const
+// and var groups and trailing comments, and all comments of a file kept in
+// place. Its layout rules are those of gofmt (go/printer), so a tree parsed
+// from gofmt-formatted source prints back exactly as that source.
+//
+// Printing uses the positions of the tree's tokens to respect the original
+// line breaks and blank lines; Fprint therefore takes the FileSet the tree
+// was parsed with. A tree without positions prints in the most compact
+// layout the rules allow.
+package printer
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+)
+
+const (
+	maxNewlines = 2 // max. number of newlines between source text
+	infinity    = 1 << 30
+	tabwidth    = 8
+)
+
+// whiteSpace is a formatting instruction buffered until the next token is
+// printed, so that comments can be interspersed with it.
+type whiteSpace byte
+
+const (
+	ignore   = whiteSpace(0)
+	blank    = whiteSpace(' ')
+	vtab     = whiteSpace('\v') // alignment cell separator
+	newline  = whiteSpace('\n')
+	formfeed = whiteSpace('\f') // newline that also ends the current alignment section
+	indent   = whiteSpace('>')
+	unindent = whiteSpace('<')
+)
+
+// pmode toggles special handling of /*-style comments.
+type pmode int
+
+const (
+	noExtraBlank     pmode = 1 << iota // disables extra blank after /*-style comment
+	noExtraLinebreak                   // disables extra line break after /*-style comment
+)
+
+// A Mode value is a set of flags (or 0). They control printing.
+type Mode uint
+
+const (
+	// RawFormat prints without a tabwriter: no alignment, and vertical
+	// tabs and formfeeds become tabs and newlines.
+	RawFormat Mode = 1 << iota
+)
+
+type commentInfo struct {
+	cindex         int               // index of the next comment
+	comment        *ast.CommentGroup // = printer.comments[cindex-1]; or nil
+	commentOffset  int               // offset of the comment group's first comment; or infinity
+	commentNewline bool              // true if the comment group contains newlines
+}
+
+type printer struct {
+	mode Mode
+	fset *lexer.FileSet
+
+	// Current state
+	output       []byte          // raw printer result
+	indent       int             // current indentation
+	level        int             // level == 0: outside composite literal; level > 0: inside composite literal
+	pmode        pmode           // current comment handling mode
+	endAlignment bool            // if set, terminate alignment immediately
+	impliedSemi  bool            // if set, a linebreak implies a semicolon
+	lastTok      lexer.TokenType // last token printed ("" if it's whitespace)
+	prevOpen     lexer.TokenType // previous non-brace "open" token (, [, or ""
+	wsbuf        []whiteSpace    // delayed white space
+
+	// Positions. pos is the current position in source space and out the
+	// current position in output space; they differ when the formatting
+	// differs from the source's. last is the value of pos after the
+	// previous token or comment.
+	pos     lexer.Position
+	out     lexer.Position
+	last    lexer.Position
+	linePtr *int // if set, record out.Line for the next token in *linePtr
+
+	// All comments of the tree, in order of appearance.
+	comments []*ast.CommentGroup
+	commentInfo
+
+	// Cache of already computed node sizes.
+	nodeSizes map[ast.Node]int
+}
+
+// nlimit limits n to maxNewlines.
+func nlimit(n int) int {
+	return min(n, maxNewlines)
+}
+
+func (p *printer) posFor(pos lexer.Pos) lexer.Position {
+	if p.fset == nil || !pos.IsValid() {
+		return lexer.Position{}
+	}
+	return p.fset.Position(pos)
+}
+
+func (p *printer) lineFor(pos lexer.Pos) int {
+	return p.posFor(pos).Line
+}
+
+func (p *printer) setPos(pos lexer.Pos) {
+	if pos.IsValid() {
+		p.pos = p.posFor(pos) // accurate position of next item
+	}
+}
+
+// recordLine records the output line number for the next non-whitespace
+// token in *linePtr, independent of pending whitespace or comments.
+func (p *printer) recordLine(linePtr *int) {
+	p.linePtr = linePtr
+}
+
+// linesFrom returns the number of output lines between the current output
+// line and line, ignoring pending whitespace and comments.
+func (p *printer) linesFrom(line int) int {
+	return p.out.Line - line
+}
+
+// ------- Comments -------- //
+
+// commentsHaveNewline reports whether a list of comments belonging to a
+// comment group contains newlines.
+func (p *printer) commentsHaveNewline(list []*ast.Comment) bool {
+	line := p.lineFor(list[0].Pos())
+	for i, c := range list {
+		if i > 0 && p.lineFor(c.Pos()) != line {
+			return true
+		}
+		if t := c.Token.Literal; len(t) >= 2 && (t[1] == '/' || strings.Contains(t, "\n")) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *printer) nextComment() {
+	for p.cindex < len(p.comments) {
+		c := p.comments[p.cindex]
+		p.cindex++
+		if list := c.List; len(list) > 0 {
+			p.comment = c
+			p.commentOffset = p.posFor(list[0].Pos()).Offset
+			p.commentNewline = p.commentsHaveNewline(list)
+			return
+		}
+	}
+	// no more comments
+	p.commentOffset = infinity
+}
+
+// commentBefore reports whether the current comment group occurs before
+// the next position in the source and printing it does not introduce
+// implicit semicolons.
+func (p *printer) commentBefore(next lexer.Position) bool {
+	return p.commentOffset < next.Offset && (!p.impliedSemi || !p.commentNewline)
+}
+
+// commentSizeBefore returns the estimated size of the comments on the same
+// line before the next position.
+func (p *printer) commentSizeBefore(next lexer.Position) int {
+	// save/restore current p.commentInfo (p.nextComment() modifies it)
+	defer func(info commentInfo) {
+		p.commentInfo = info
+	}(p.commentInfo)
+
+	size := 0
+	for p.commentBefore(next) {
+		for _, c := range p.comment.List {
+			size += len(c.Token.Literal)
+		}
+		p.nextComment()
+	}
+	return size
+}
+
+// writeCommentPrefix writes the whitespace before a comment, consuming as
+// much of the pending whitespace as helps to position the comment. pos is
+// the comment position, next the position of the item after all pending
+// comments, prev the previous comment of the group (or nil), and tok the
+// next token.
+func (p *printer) writeCommentPrefix(pos, next lexer.Position, prev *ast.Comment, tok lexer.TokenType) {
+	if len(p.output) == 0 {
+		// the comment is the first item to be printed - don't write any whitespace
+		return
+	}
+
+	if pos.Line == p.last.Line && (prev == nil || prev.Token.Literal[1] != '/') {
+		// comment on the same line as last item:
+		// separate with at least one separator
+		hasSep := false
+		if prev == nil {
+			// first comment of a comment group
+			j := 0
+			for i, ch := range p.wsbuf {
+				switch ch {
+				case blank:
+					// ignore any blanks before a comment
+					p.wsbuf[i] = ignore
+					continue
+				case vtab:
+					// respect existing tabs - important
+					// for proper formatting of commented structs
+					hasSep = true
+					continue
+				case indent:
+					// apply pending indentation
+					continue
+				}
+				j = i
+				break
+			}
+			p.writeWhitespace(j)
+		}
+		// make sure there is at least one separator
+		if !hasSep {
+			sep := byte('\t')
+			if pos.Line == next.Line {
+				// next item is on the same line as the comment
+				// (which must be a /*-style comment): separate
+				// with a blank instead of a tab
+				sep = ' '
+			}
+			p.writeByte(sep, 1)
+		}
+		return
+	}
+
+	// comment on a different line:
+	// separate with at least one line break
+	droppedLinebreak := false
+	j := 0
+	for i, ch := range p.wsbuf {
+		switch ch {
+		case blank, vtab:
+			// ignore any horizontal whitespace before line breaks
+			p.wsbuf[i] = ignore
+			continue
+		case indent:
+			// apply pending indentation
+			continue
+		case unindent:
+			// if this is not the last unindent, apply it
+			// as it is (likely) belonging to the last
+			// construct (e.g., a multi-line expression list)
+			// and is not part of closing a block
+			if i+1 < len(p.wsbuf) && p.wsbuf[i+1] == unindent {
+				continue
+			}
+			// if the next token is not a closing }, apply the unindent
+			// if it appears that the comment is aligned with the
+			// token; otherwise assume the unindent is part of a
+			// closing block and stop (this scenario appears with
+			// comments before a case label where the comments
+			// apply to the next case instead of the current one)
+			if tok != lexer.RBRACE && pos.Column == next.Column {
+				continue
+			}
+		case newline, formfeed:
+			p.wsbuf[i] = ignore
+			droppedLinebreak = prev == nil // record only if first comment of a group
+		}
+		j = i
+		break
+	}
+	p.writeWhitespace(j)
+
+	// determine number of linebreaks before the comment
+	n := 0
+	if pos.IsValid() && p.last.IsValid() {
+		n = max(pos.Line-p.last.Line, 0)
+	}
+
+	// at the package scope level only (p.indent == 0),
+	// add an extra newline if we dropped one before:
+	// this preserves a blank line before documentation
+	// comments at the package scope level
+	if p.indent == 0 && droppedLinebreak {
+		n++
+	}
+
+	// make sure there is at least one line break
+	// if the previous comment was a line comment
+	if n == 0 && prev != nil && prev.Token.Literal[1] == '/' {
+		n = 1
+	}
+
+	if n > 0 {
+		// use formfeeds to break columns before a comment;
+		// this is analogous to using formfeeds to separate
+		// individual lines of /*-style comments
+		p.writeByte('\f', nlimit(n))
+	}
+}
+
+// isBlank reports whether s contains only white space.
+func isBlank(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] > ' ' {
+			return false
+		}
+	}
+	return true
+}
+
+// commonPrefix returns the common prefix of a and b.
+func commonPrefix(a, b string) string {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] && (a[i] <= ' ' || a[i] == '*') {
+		i++
+	}
+	return a[0:i]
+}
+
+// trimRight returns s with trailing whitespace removed.
+func trimRight(s string) string {
+	return strings.TrimRightFunc(s, unicode.IsSpace)
+}
+
+// stripCommonPrefix removes a common prefix from /*-style comment lines, so
+// that the comment's text can be reindented with the printer's current
+// indentation. The heuristics handle comments whose text is indented
+// relative to the /* and */, comments with a vertical line of stars on the
+// left, and comments whose */ ends the last text line.
+func stripCommonPrefix(lines []string) {
+	if len(lines) <= 1 {
+		return // at most one line - nothing to do
+	}
+
+	// Compute maximum common white prefix of all but the first,
+	// last, and blank lines, and replace blank lines with empty
+	// lines (the first line starts with /* and has no prefix).
+	// In cases where only the first and last lines are not blank,
+	// consider the last line for the prefix computation since
+	// otherwise the prefix would be empty.
+	prefix := ""
+	prefixSet := false
+	if len(lines) > 2 {
+		for i, line := range lines[1 : len(lines)-1] {
+			if isBlank(line) {
+				lines[1+i] = "" // range starts with lines[1]
+			} else {
+				if !prefixSet {
+					prefix = line
+					prefixSet = true
+				}
+				prefix = commonPrefix(prefix, line)
+			}
+		}
+	}
+	if !prefixSet {
+		line := lines[len(lines)-1]
+		prefix = commonPrefix(line, line)
+	}
+
+	// Check for a vertical "line of stars" and correct the prefix accordingly.
+	lineOfStars := false
+	if p, _, ok := strings.Cut(prefix, "*"); ok {
+		// remove trailing blank from prefix so stars remain aligned
+		prefix = strings.TrimSuffix(p, " ")
+		lineOfStars = true
+	} else {
+		// No line of stars present. Determine the white space on the
+		// first line after the /* and before the beginning of the
+		// comment text; assume two blanks instead of the /* unless the
+		// first character after the /* is a tab. If the first line is
+		// empty but for the opening /*, assume up to 3 blanks or a tab.
+		first := lines[0]
+		if isBlank(first[2:]) {
+			// no comment text on the first line:
+			// reduce prefix by up to 3 blanks or a tab
+			i := len(prefix)
+			for n := 0; n < 3 && i > 0 && prefix[i-1] == ' '; n++ {
+				i--
+			}
+			if i == len(prefix) && i > 0 && prefix[i-1] == '\t' {
+				i--
+			}
+			prefix = prefix[0:i]
+		} else {
+			// comment text on the first line
+			suffix := make([]byte, len(first))
+			n := 2 // start after opening /*
+			for n < len(first) && first[n] <= ' ' {
+				suffix[n] = first[n]
+				n++
+			}
+			if n > 2 && suffix[2] == '\t' {
+				// assume the '\t' compensates for the /*
+				suffix = suffix[2:n]
+			} else {
+				// otherwise assume two blanks
+				suffix[0], suffix[1] = ' ', ' '
+				suffix = suffix[0:n]
+			}
+			prefix = strings.TrimSuffix(prefix, string(suffix))
+		}
+	}
+
+	// Handle last line: If it only contains a closing */, align it
+	// with the opening /*, otherwise align the text with the other
+	// lines.
+	last := lines[len(lines)-1]
+	closing := "*/"
+	before, _, _ := strings.Cut(last, closing) // closing always present
+	if isBlank(before) {
+		// last line only contains closing */
+		if lineOfStars {
+			closing = " */" // add blank to align final star
+		}
+		lines[len(lines)-1] = prefix + closing
+	} else {
+		// last line contains more comment text - assume
+		// it is aligned like the other lines and include
+		// in prefix computation
+		prefix = commonPrefix(prefix, last)
+	}
+
+	// Remove the common prefix from all but the first and empty lines.
+	for i, line := range lines {
+		if i > 0 && line != "" {
+			lines[i] = line[len(prefix):]
+		}
+	}
+}
+
+func (p *printer) writeComment(comment *ast.Comment) {
+	text := comment.Token.Literal
+	pos := p.posFor(comment.Pos())
+
+	// shortcut common case of //-style comments
+	if text[1] == '/' {
+		p.writeString(pos, trimRight(text), true)
+		return
+	}
+
+	// for /*-style comments, print line by line and let the
+	// write function take care of the proper indentation
+	lines := strings.Split(text, "\n")
+
+	// The comment started in the first column but is going to be
+	// indented. For an idempotent result, add indentation to all lines
+	// such that they look like they were indented before.
+	if pos.IsValid() && pos.Column == 1 && p.indent > 0 {
+		for i, line := range lines[1:] {
+			lines[1+i] = "   " + line
+		}
+	}
+
+	stripCommonPrefix(lines)
+
+	// write comment lines, separated by formfeed,
+	// without a line break after the last line
+	for i, line := range lines {
+		if i > 0 {
+			p.writeByte('\f', 1)
+			pos = p.pos
+		}
+		if len(line) > 0 {
+			p.writeString(pos, trimRight(line), true)
+		}
+	}
+}
+
+// writeCommentSuffix writes a line break after a comment if indicated and
+// processes any leftover indentation information. It reports whether a
+// newline was written and whether a formfeed was dropped from the
+// whitespace buffer.
+func (p *printer) writeCommentSuffix(needsLinebreak bool) (wroteNewline, droppedFF bool) {
+	for i, ch := range p.wsbuf {
+		switch ch {
+		case blank, vtab:
+			// ignore trailing whitespace
+			p.wsbuf[i] = ignore
+		case indent, unindent:
+			// don't lose indentation information
+		case newline, formfeed:
+			// if we need a line break, keep exactly one
+			// but remember if we dropped any formfeeds
+			if needsLinebreak {
+				needsLinebreak = false
+				wroteNewline = true
+			} else {
+				if ch == formfeed {
+					droppedFF = true
+				}
+				p.wsbuf[i] = ignore
+			}
+		}
+	}
+	p.writeWhitespace(len(p.wsbuf))
+
+	// make sure we have a line break
+	if needsLinebreak {
+		p.writeByte('\n', 1)
+		wroteNewline = true
+	}
+
+	return
+}
+
+// containsLinebreak reports whether the whitespace buffer contains any line breaks.
+func (p *printer) containsLinebreak() bool {
+	for _, ch := range p.wsbuf {
+		if ch == newline || ch == formfeed {
+			return true
+		}
+	}
+	return false
+}
+
+// intersperseComments prints all comments that appear before the next
+// token tok together with the buffered whitespace, using a heuristic to mix
+// the two. It reports whether a newline was written and whether a formfeed
+// was dropped from the whitespace buffer.
+func (p *printer) intersperseComments(next lexer.Position, tok lexer.TokenType) (wroteNewline, droppedFF bool) {
+	var last *ast.Comment
+	for p.commentBefore(next) {
+		for _, c := range p.comment.List {
+			p.writeCommentPrefix(p.posFor(c.Pos()), next, last, tok)
+			p.writeComment(c)
+			last = c
+		}
+		p.nextComment()
+	}
+
+	if last == nil {
+		return
+	}
+
+	// If the last comment is a /*-style comment and the next item
+	// follows on the same line but is not a comma, and not a "closing"
+	// token immediately following its corresponding "opening" token,
+	// add an extra separator unless explicitly disabled. Use a blank
+	// as separator unless we have pending linebreaks, they are not
+	// disabled, and we are outside a composite literal, in which case
+	// we want a linebreak.
+	needsLinebreak := false
+	if p.pmode&noExtraBlank == 0 &&
+		last.Token.Literal[1] == '*' && p.lineFor(last.Pos()) == next.Line &&
+		tok != lexer.COMMA &&
+		(tok != lexer.RPAREN || p.prevOpen == lexer.LPAREN) &&
+		(tok != lexer.RBRACKET || p.prevOpen == lexer.LBRACKET) {
+		if p.containsLinebreak() && p.pmode&noExtraLinebreak == 0 && p.level == 0 {
+			needsLinebreak = true
+		} else {
+			p.writeByte(' ', 1)
+		}
+	}
+	// Ensure that there is a line break after a //-style comment,
+	// before EOF, and before a closing '}' unless explicitly disabled.
+	if last.Token.Literal[1] == '/' ||
+		tok == lexer.EOF ||
+		tok == lexer.RBRACE && p.pmode&noExtraLinebreak == 0 {
+		needsLinebreak = true
+	}
+	return p.writeCommentSuffix(needsLinebreak)
+}
+
+// ------- Output -------- //
+
+// writeIndent writes indentation.
+func (p *printer) writeIndent() {
+	// use "hard" htabs - indentation columns
+	// must not be discarded by the tabwriter
+	for i := 0; i < p.indent; i++ {
+		p.output = append(p.output, '\t')
+	}
+
+	// update positions
+	p.pos.Offset += p.indent
+	p.pos.Column += p.indent
+	p.out.Column += p.indent
+}
+
+// writeByte writes ch n times to p.output and updates p.pos.
+// Only used to write formatting (white space) characters.
+func (p *printer) writeByte(ch byte, n int) {
+	if p.endAlignment {
+		// Ignore any alignment control character;
+		// and at the end of the line, break with
+		// a formfeed to indicate termination of
+		// existing columns.
+		switch ch {
+		case '\t', '\v':
+			ch = ' '
+		case '\n', '\f':
+			ch = '\f'
+			p.endAlignment = false
+		}
+	}
+
+	if p.out.Column == 1 {
+		p.writeIndent()
+	}
+
+	for i := 0; i < n; i++ {
+		p.output = append(p.output, ch)
+	}
+
+	// update positions
+	p.pos.Offset += n
+	if ch == '\n' || ch == '\f' {
+		p.pos.Line += n
+		p.out.Line += n
+		p.pos.Column = 1
+		p.out.Column = 1
+		return
+	}
+	p.pos.Column += n
+	p.out.Column += n
+}
+
+// writeString writes the string s to p.output and updates p.pos, p.out,
+// and p.last. If isLit is set, s is escaped w/ tabwriter.Escape characters
+// to protect s from being interpreted by the tabwriter.
+func (p *printer) writeString(pos lexer.Position, s string, isLit bool) {
+	if p.out.Column == 1 {
+		p.writeIndent()
+	}
+
+	if pos.IsValid() {
+		// update p.pos (if pos is invalid, continue with existing p.pos)
+		// Note: Must do this after handling line beginnings because
+		// writeIndent updates p.pos if there's indentation, but p.pos
+		// is the position of s.
+		p.pos = pos
+	}
+
+	if isLit {
+		// Protect s such that is passes through the tabwriter
+		// unchanged. Valid Go programs cannot contain
+		// tabwriter.Escape bytes since they do not appear in legal
+		// UTF-8 sequences.
+		p.output = append(p.output, tabwriter.Escape)
+	}
+
+	p.output = append(p.output, s...)
+
+	// update positions
+	nlines := 0
+	var li int // index of last newline; valid if nlines > 0
+	for i := 0; i < len(s); i++ {
+		// Raw string literals may contain any character except back quote (`).
+		if ch := s[i]; ch == '\n' || ch == '\f' {
+			// account for line break
+			nlines++
+			li = i
+			// A line break inside a literal will break whatever column
+			// formatting is in place; ignore any further alignment through
+			// the end of the line.
+			p.endAlignment = true
+		}
+	}
+	p.pos.Offset += len(s)
+	if nlines > 0 {
+		p.pos.Line += nlines
+		p.out.Line += nlines
+		c := len(s) - li
+		p.pos.Column = c
+		p.out.Column = c
+	} else {
+		p.pos.Column += len(s)
+		p.out.Column += len(s)
+	}
+
+	if isLit {
+		p.output = append(p.output, tabwriter.Escape)
+	}
+
+	p.last = p.pos
+}
+
+// writeWhitespace writes the first n whitespace entries.
+func (p *printer) writeWhitespace(n int) {
+	// write entries
+	for i := 0; i < n; i++ {
+		switch ch := p.wsbuf[i]; ch {
+		case ignore:
+			// ignore!
+		case indent:
+			p.indent++
+		case unindent:
+			p.indent--
+			if p.indent < 0 {
+				p.indent = 0
+			}
+		case newline, formfeed:
+			// A line break immediately followed by a "correcting"
+			// unindent is swapped with the unindent - this permits
+			// proper label positioning. If a comment is between
+			// the line break and the label, the unindent is not
+			// part of the comment whitespace prefix and the comment
+			// will be positioned correctly indented.
+			if i+1 < n && p.wsbuf[i+1] == unindent {
+				// Use a formfeed to terminate the current section.
+				// Otherwise, a long label name on the next line leading
+				// to a wide column may increase the indentation column
+				// of lines before the label; effectively leading to wrong
+				// indentation.
+				p.wsbuf[i], p.wsbuf[i+1] = unindent, formfeed
+				i-- // do it again
+				continue
+			}
+			fallthrough
+		default:
+			p.writeByte(byte(ch), 1)
+		}
+	}
+
+	// shift remaining entries down
+	l := copy(p.wsbuf, p.wsbuf[n:])
+	p.wsbuf = p.wsbuf[:l]
+}
+
+// mayCombine reports whether the token prev followed by a token starting
+// with next would be read as a different token.
+func mayCombine(prev lexer.TokenType, next byte) (b bool) {
+	switch prev {
+	case lexer.INT:
+		b = next == '.' // 1.
+	case lexer.PLUS:
+		b = next == '+' // ++
+	case lexer.MINUS:
+		b = next == '-' // --
+	case lexer.SLASH:
+		b = next == '*' // /*
+	case lexer.LT:
+		b = next == '-' || next == '<' // <- or <<
+	case lexer.AMPERSAND:
+		b = next == '&' || next == '^' // && or &^
+	}
+	return
+}
+
+// print prints a list of "items": tokens, identifiers and literals, and
+// whitespace and formatting instructions. It is the only print function
+// the node printing functions call directly.
+//
+// Whitespace is accumulated until a non-whitespace item appears. Any
+// comments that need to appear before that item are printed first, taking
+// into account the amount and structure of the pending whitespace for best
+// comment placement. Then any leftover whitespace is printed, followed by
+// the item itself.
+func (p *printer) print(args ...any) {
+	for _, arg := range args {
+		// information about the current arg
+		var data string
+		var isLit bool
+		var impliedSemi bool // value for p.impliedSemi after this arg
+
+		// record previous opening token, if any
+		switch p.lastTok {
+		case "":
+			// ignore (white space)
+		case lexer.LPAREN, lexer.LBRACKET:
+			p.prevOpen = p.lastTok
+		default:
+			// other tokens followed any opening token
+			p.prevOpen = ""
+		}
+
+		switch x := arg.(type) {
+		case pmode:
+			// toggle printer mode
+			p.pmode ^= x
+			continue
+
+		case whiteSpace:
+			if x == ignore {
+				// don't add ignore's to the buffer; they
+				// may screw up "correcting" unindents (see
+				// LabeledStmt)
+				continue
+			}
+			p.wsbuf = append(p.wsbuf, x)
+			if x == newline || x == formfeed {
+				// newlines affect the current state (p.impliedSemi)
+				// and not the state after printing arg (impliedSemi)
+				// because comments can be interspersed before the arg
+				// in this case
+				p.impliedSemi = false
+			}
+			p.lastTok = ""
+			continue
+
+		case *ast.Identifier:
+			data = x.Value
+			impliedSemi = true
+			p.lastTok = lexer.IDENT
+
+		case *ast.IntegerLiteral:
+			data = literal(x.Token, x.Value)
+			impliedSemi = true
+			p.lastTok = lexer.INT
+
+		case *ast.FloatLiteral:
+			data = literal(x.Token, x.Value)
+			impliedSemi = true
+			p.lastTok = lexer.FLOAT
+
+		case *ast.StringLiteral:
+			data = x.Token.Literal
+			if data == "" {
+				data = strconv.Quote(x.Value)
+			}
+			isLit = true
+			impliedSemi = true
+			p.lastTok = lexer.STRING
+
+		case *ast.RuneLiteral:
+			data = x.Token.Literal
+			if data == "" {
+				data = strconv.QuoteRune(x.Value)
+			}
+			isLit = true
+			impliedSemi = true
+			p.lastTok = lexer.CHAR
+
+		case lexer.TokenType:
+			s := string(x)
+			if mayCombine(p.lastTok, s[0]) {
+				// the previous and the current token must be
+				// separated by a blank otherwise they combine
+				// into a different incorrect token sequence
+				p.wsbuf = append(p.wsbuf, blank)
+			}
+			data = s
+			// some keywords followed by a newline imply a semicolon
+			switch x {
+			case lexer.BREAK, lexer.CONTINUE, lexer.FALLTHROUGH, lexer.RETURN,
+				lexer.INC, lexer.DEC, lexer.RPAREN, lexer.RBRACKET, lexer.RBRACE:
+				impliedSemi = true
+			}
+			p.lastTok = x
+
+		case string:
+			// incorrect AST - print error message
+			data = x
+			isLit = true
+			impliedSemi = true
+			p.lastTok = lexer.STRING
+
+		default:
+			panic(fmt.Sprintf("printer: unsupported argument %v (%T)", arg, arg))
+		}
+
+		next := p.pos // estimated/accurate position of next item
+		wroteNewline, droppedFF := p.flush(next, p.lastTok)
+
+		// intersperse extra newlines if present in the source and
+		// if they don't cause extra semicolons (don't do this in
+		// flush as it will cause extra newlines at the end of a file)
+		if !p.impliedSemi {
+			n := nlimit(next.Line - p.pos.Line)
+			// don't exceed maxNewlines if we already wrote one
+			if wroteNewline && n == maxNewlines {
+				n = maxNewlines - 1
+			}
+			if n > 0 {
+				ch := byte('\n')
+				if droppedFF {
+					ch = '\f' // use formfeed since we dropped one before
+				}
+				p.writeByte(ch, n)
+				impliedSemi = false
+			}
+		}
+
+		// the next token starts now - record its line number if requested
+		if p.linePtr != nil {
+			*p.linePtr = p.out.Line
+			p.linePtr = nil
+		}
+
+		p.writeString(next, data, isLit)
+		p.impliedSemi = impliedSemi
+	}
+}
+
+// literal returns the source text of a number literal, or its value for a
+// literal built without a token.
+func literal(tok lexer.Token, value string) string {
+	if tok.Literal != "" {
+		return tok.Literal
+	}
+	return value
+}
+
+// flush prints any pending comments and whitespace occurring textually
+// before the position of the next token tok. It reports whether a newline
+// was written and whether a formfeed was dropped from the whitespace buffer.
+func (p *printer) flush(next lexer.Position, tok lexer.TokenType) (wroteNewline, droppedFF bool) {
+	if p.commentBefore(next) {
+		// if there are comments before the next item, intersperse them
+		wroteNewline, droppedFF = p.intersperseComments(next, tok)
+	} else {
+		// otherwise, write any leftover whitespace
+		p.writeWhitespace(len(p.wsbuf))
+	}
+	return
+}
+
+// ------- Trimmer -------- //
+
+// A trimmer is an io.Writer filter for stripping tabwriter.Escape
+// characters, trailing blanks and tabs, and for converting formfeed
+// and vtab characters into newlines and htabs (in case no tabwriter
+// is used). Text bracketed by tabwriter.Escape characters is passed
+// through unchanged.
+type trimmer struct {
+	output io.Writer
+	state  int
+	space  []byte
+}
+
+// trimmer is implemented as a state machine.
+// It can be in one of the following states:
+const (
+	inSpace  = iota // inside space
+	inEscape        // inside text bracketed by tabwriter.Escapes
+	inText          // inside text
+)
+
+func (p *trimmer) resetSpace() {
+	p.state = inSpace
+	p.space = p.space[0:0]
+}
+
+var aNewline = []byte("\n")
+
+func (p *trimmer) Write(data []byte) (n int, err error) {
+	// invariants:
+	// p.state == inSpace:
+	//	p.space is unwritten
+	// p.state == inEscape, inText:
+	//	data[m:n] is unwritten
+	m := 0
+	var b byte
+	for n, b = range data {
+		if b == '\v' {
+			b = '\t' // convert to htab
+		}
+		switch p.state {
+		case inSpace:
+			switch b {
+			case '\t', ' ':
+				p.space = append(p.space, b)
+			case '\n', '\f':
+				p.resetSpace() // discard trailing space
+				_, err = p.output.Write(aNewline)
+			case tabwriter.Escape:
+				_, err = p.output.Write(p.space)
+				p.state = inEscape
+				m = n + 1 // +1: skip tabwriter.Escape
+			default:
+				_, err = p.output.Write(p.space)
+				p.state = inText
+				m = n
+			}
+		case inEscape:
+			if b == tabwriter.Escape {
+				_, err = p.output.Write(data[m:n])
+				p.resetSpace()
+			}
+		case inText:
+			switch b {
+			case '\t', ' ':
+				_, err = p.output.Write(data[m:n])
+				p.resetSpace()
+				p.space = append(p.space, b)
+			case '\n', '\f':
+				_, err = p.output.Write(data[m:n])
+				p.resetSpace()
+				if err == nil {
+					_, err = p.output.Write(aNewline)
+				}
+			case tabwriter.Escape:
+				_, err = p.output.Write(data[m:n])
+				p.state = inEscape
+				m = n + 1 // +1: skip tabwriter.Escape
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+	n = len(data)
+
+	switch p.state {
+	case inEscape, inText:
+		_, err = p.output.Write(data[m:n])
+		p.resetSpace()
+	}
+
+	return
+}
+
+// ------- Public interface -------- //
+
+// A Config controls the output of Fprint.
+type Config struct {
+	Mode Mode
+}
+
+// Fprint prints node to output in the canonical format. Positions in the
+// tree are decoded with fset, which may be nil for a tree without
+// positions. node must be an *ast.File, an *ast.Program, or a declaration,
+// spec, statement or expression. The comments of a File are printed in
+// place; for other nodes the doc and line comments attached to the tree
+// are.
+func Fprint(output io.Writer, fset *lexer.FileSet, node ast.Node) error {
+	return (&Config{}).Fprint(output, fset, node)
+}
+
+// Fprint is like the package-level Fprint, printing with the configuration c.
+func (c *Config) Fprint(output io.Writer, fset *lexer.FileSet, node ast.Node) error {
+	return c.fprint(output, fset, node, make(map[ast.Node]int))
+}
+
+// Source returns the canonically formatted source of node; see Fprint.
+func Source(fset *lexer.FileSet, node ast.Node) (string, error) {
+	var buf bytes.Buffer
+	if err := Fprint(&buf, fset, node); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func (c *Config) fprint(output io.Writer, fset *lexer.FileSet, node ast.Node, nodeSizes map[ast.Node]int) error {
+	p := &printer{
+		mode:      c.Mode,
+		fset:      fset,
+		pos:       lexer.Position{Line: 1, Column: 1},
+		out:       lexer.Position{Line: 1, Column: 1},
+		nodeSizes: nodeSizes,
+	}
+	if err := p.printNode(node); err != nil {
+		return err
+	}
+	// print outstanding comments
+	p.impliedSemi = false // EOF acts like a newline
+	p.flush(lexer.Position{Offset: infinity, Line: infinity}, lexer.EOF)
+
+	// Redirect output through a trimmer to eliminate trailing whitespace.
+	// (Input to a tabwriter must be untrimmed since trailing tabs provide
+	// formatting information.)
+	output = &trimmer{output: output}
+
+	// gofmt aligns with blanks and indents with tabs
+	if c.Mode&RawFormat == 0 {
+		output = tabwriter.NewWriter(output, 0, tabwidth, 1, ' ', tabwriter.DiscardEmptyColumns|tabwriter.TabIndent)
+	}
+
+	if _, err := output.Write(p.output); err != nil {
+		return err
+	}
+	if tw, ok := output.(*tabwriter.Writer); ok {
+		return tw.Flush()
+	}
+	return nil
+}
+
+// printNode prints node with the comments that belong to it.
+func (p *printer) printNode(node ast.Node) error {
+	if f, ok := node.(*ast.File); ok {
+		p.comments = f.Comments
+	}
+	if p.comments == nil {
+		// use the comments attached to the nodes
+		ast.Inspect(node, func(n ast.Node) bool {
+			if g, ok := n.(*ast.CommentGroup); ok {
+				p.comments = append(p.comments, g)
+				return false
+			}
+			return true
+		})
+	}
+
+	// get comments ready for use
+	p.nextComment()
+
+	switch n := node.(type) {
+	case *ast.File:
+		p.file(n)
+	case *ast.Program:
+		p.stmtList(n.Statements, 0, false)
+	case ast.Declaration:
+		p.decl(n)
+	case ast.Spec:
+		p.spec(n, 1, false)
+	case ast.Statement:
+		// A labeled statement will un-indent to position the label.
+		// Set p.indent to 1 so we don't get indent "underflow".
+		if _, ok := n.(*ast.LabeledStmt); ok {
+			p.indent = 1
+		}
+		p.stmt(n, false)
+	case ast.Expression:
+		p.expr(n)
+	default:
+		return fmt.Errorf("printer: unsupported node type %T", node)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package printer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+	parser "github.com/mohit-bhandari45/Compiler-GO.git/internal/parser"
+)
+
+// TestGolden checks that each testdata/*.golden file, which is formatted
+// by gofmt, parses and prints back exactly as itself.
+func TestGolden(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "*.golden"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no golden files")
+	}
+	for _, name := range files {
+		t.Run(filepath.Base(name), func(t *testing.T) {
+			src, err := os.ReadFile(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			fset := lexer.NewFileSet()
+			f := fset.AddFile(name, string(src))
+			p := parser.New(lexer.NewInFile(f, string(src), lexer.ScanComments))
+			file := p.ParseFile()
+			if errs := p.Errors(); len(errs) > 0 {
+				t.Fatalf("parse errors: %v", errs)
+			}
+			got, err := Source(fset, file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != string(src) {
+				t.Errorf("printed source differs from %s:\n%s", name, got)
+			}
+		})
+	}
+}
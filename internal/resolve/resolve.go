@@ -0,0 +1,708 @@
+// Package resolve binds the identifiers of a syntax tree to the objects they
+// denote. It builds the nested scopes of a package (universe, package, file,
+// function and block scopes), declares each named entity in its scope
+// following Go's declaration-order rules, and looks every other identifier up
+// through the enclosing scopes, so that an inner declaration shadows an outer
+// one.
+//
+// The results are recorded in an Info rather than in the tree itself, which
+// stays a plain value that can be compared, cloned and encoded.
+package resolve
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+)
+
+// Info holds the results of resolving a package.
+type Info struct {
+	// Defs maps each declaring identifier to the object it declares. The
+	// variable of a type switch (v in v := x.(type)) maps to nil: it
+	// declares a distinct object in each clause, recorded in Implicits.
+	// Package names and the field and method names of struct and
+	// interface types are not recorded.
+	Defs map[*ast.Identifier]*Object
+
+	// Uses maps each identifier that refers to an object to that object.
+	// Selected names (Sel in x.Sel) are not resolved, as that needs types.
+	Uses map[*ast.Identifier]*Object
+
+	// Implicits maps the case clauses of a type switch with a variable to
+	// the variable declared for that clause.
+	Implicits map[ast.Node]*Object
+
+	// Scopes maps the nodes that open a scope to that scope: *ast.File,
+	// *ast.FuncType, *ast.TypeSpec (type parameters), *ast.BlockStatement,
+	// *ast.IfStmt, *ast.ForStmt, *ast.RangeStmt, *ast.SwitchStmt,
+	// *ast.TypeSwitchStmt, *ast.CaseClause, *ast.CommClause and
+	// *ast.Program. The body of a function shares the scope of its
+	// parameters, keyed by the FuncType.
+	Scopes map[ast.Node]*Scope
+
+	// Package is the package scope.
+	Package *Scope
+}
+
+// ObjectOf returns the object id declares or refers to, or nil.
+func (info *Info) ObjectOf(id *ast.Identifier) *Object {
+	if obj := info.Defs[id]; obj != nil {
+		return obj
+	}
+	return info.Uses[id]
+}
+
+// An Error is an undeclared name or a conflicting declaration.
+type Error struct {
+	Pos lexer.Position // the decoded position of the offending identifier
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return e.Pos.String() + ": " + e.Msg
+}
+
+// Files resolves the files of one package. fset decodes the positions of
+// the errors; it may be nil, leaving them unknown. The errors are sorted by
+// position.
+func Files(fset *lexer.FileSet, files ...*ast.File) (*Info, []*Error) {
+	r := newResolver(fset)
+	r.files(files)
+	return r.info, r.sortedErrors()
+}
+
+// Program resolves the statements of prog as a block in a package of its
+// own, following the declaration-order rules of a function body.
+func Program(fset *lexer.FileSet, prog *ast.Program) (*Info, []*Error) {
+	r := newResolver(fset)
+	r.scope = r.info.Package
+	r.openScope(prog, BlockScope)
+	r.funcBody(prog, prog.Statements)
+	r.closeScope()
+	return r.info, r.sortedErrors()
+}
+
+type resolver struct {
+	fset   *lexer.FileSet
+	info   *Info
+	errors []*Error
+
+	scope     *Scope             // the innermost scope
+	labels    map[string]*Object // the labels of the innermost function body
+	dotImport bool               // the current file has a dot import, so an unknown name may come from it
+}
+
+func newResolver(fset *lexer.FileSet) *resolver {
+	return &resolver{
+		fset: fset,
+		info: &Info{
+			Defs:      make(map[*ast.Identifier]*Object),
+			Uses:      make(map[*ast.Identifier]*Object),
+			Implicits: make(map[ast.Node]*Object),
+			Scopes:    make(map[ast.Node]*Scope),
+			Package:   NewScope(Universe, PackageScope, nil),
+		},
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Errors
+
+func (r *resolver) position(p lexer.Pos) lexer.Position {
+	if r.fset == nil {
+		return lexer.Position{}
+	}
+	return r.fset.Position(p)
+}
+
+func (r *resolver) errorf(p lexer.Pos, format string, args ...any) {
+	r.errors = append(r.errors, &Error{Pos: r.position(p), Msg: fmt.Sprintf(format, args...)})
+}
+
+// redeclared reports obj as conflicting with alt, declared earlier in the
+// same scope.
+func (r *resolver) redeclared(obj, alt *Object) {
+	msg := obj.Name + " redeclared in this block"
+	if _, ok := obj.Decl.(*ast.Field); ok && obj.Kind == Var && r.scope.Kind == FuncScope {
+		msg = "duplicate argument " + obj.Name
+	}
+	r.errorf(obj.Pos(), "%s\n\t%s: other declaration of %s", msg, r.position(alt.Pos()), alt.Name)
+}
+
+func (r *resolver) sortedErrors() []*Error {
+	sort.SliceStable(r.errors, func(i, j int) bool {
+		a, b := r.errors[i].Pos, r.errors[j].Pos
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		return a.Offset < b.Offset
+	})
+	return r.errors
+}
+
+// ----------------------------------------------------------------------------
+// Scopes and objects
+
+func (r *resolver) openScope(node ast.Node, kind ScopeKind) *Scope {
+	r.scope = NewScope(r.scope, kind, node)
+	r.info.Scopes[node] = r.scope
+	return r.scope
+}
+
+func (r *resolver) closeScope() {
+	r.scope = r.scope.Parent
+}
+
+// declare declares the object named by id in the current scope. Blank
+// identifiers get an object too, but are not declared.
+func (r *resolver) declare(kind ObjKind, id *ast.Identifier, decl ast.Node, data any) *Object {
+	return r.declareIn(r.scope, kind, id, decl, data)
+}
+
+func (r *resolver) declareIn(scope *Scope, kind ObjKind, id *ast.Identifier, decl ast.Node, data any) *Object {
+	obj := &Object{Kind: kind, Name: id.Value, Ident: id, Decl: decl, Data: data}
+	r.info.Defs[id] = obj
+	if id.Value != "_" {
+		if alt := scope.Insert(obj); alt != nil {
+			r.redeclared(obj, alt)
+		}
+	}
+	return obj
+}
+
+// use binds id to the innermost object declared with its name, and reports
+// it as undefined if there is none.
+func (r *resolver) use(id *ast.Identifier) {
+	if !r.tryUse(id) && id.Value != "_" && !r.dotImport {
+		r.errorf(id.Pos(), "undefined: %s", id.Value)
+	}
+}
+
+// tryUse is use for an identifier that may name something other than an
+// object, such as a field name in a composite literal; it reports whether id
+// was bound.
+func (r *resolver) tryUse(id *ast.Identifier) bool {
+	if id.Value == "_" {
+		return false
+	}
+	_, obj := r.scope.LookupParent(id.Value)
+	if obj == nil {
+		return false
+	}
+	r.info.Uses[id] = obj
+	return true
+}
+
+// ----------------------------------------------------------------------------
+// Package level
+
+// files declares the package-level objects of all files before resolving any
+// of them, as package-level declarations may be used in any order and across
+// files.
+func (r *resolver) files(files []*ast.File) {
+	pkg := r.info.Package
+	fileScopes := make([]*Scope, len(files))
+	dotImports := make([]bool, len(files))
+	for i, file := range files {
+		r.scope = pkg
+		fileScopes[i] = r.openScope(file, FileScope)
+		for _, spec := range file.Imports {
+			dotImports[i] = r.importSpec(spec) || dotImports[i]
+		}
+		for _, decl := range file.Decls {
+			r.declarePackageLevel(decl)
+		}
+	}
+
+	// an import and a package-level declaration must not share a name
+	for _, fs := range fileScopes {
+		for _, name := range fs.Names() {
+			imp := fs.Objects[name]
+			if obj := pkg.Lookup(name); obj != nil {
+				r.errorf(obj.Pos(), "%s already declared through import of package %s (%s)\n\t%s: other declaration of %s",
+					name, name, strconv.Quote(imp.Data.(string)), r.position(imp.Pos()), name)
+			}
+		}
+	}
+
+	for i, file := range files {
+		r.scope = fileScopes[i]
+		r.dotImport = dotImports[i]
+		for _, decl := range file.Decls {
+			r.resolvePackageLevel(decl)
+		}
+	}
+	r.scope = nil
+	r.dotImport = false
+}
+
+// importSpec declares the package name of an import in the file scope, and
+// reports whether it is a dot import.
+func (r *resolver) importSpec(spec *ast.ImportSpec) bool {
+	if spec.Path == nil {
+		return false
+	}
+	importPath := spec.Path.Value
+	id := spec.Name
+	switch {
+	case id == nil:
+		// the package name is not known without the package itself; by
+		// convention it is the last element of the path
+		name := path.Base(importPath)
+		if name == "." || name == "/" {
+			return false
+		}
+		obj := &Object{Kind: Pkg, Name: name, Decl: spec, Data: importPath}
+		if alt := r.scope.Insert(obj); alt != nil {
+			r.errorf(spec.Path.Pos(), "%s redeclared in this block\n\t%s: other declaration of %s", name, r.position(alt.Pos()), name)
+		}
+	case id.Value == ".":
+		return true
+	default:
+		r.declare(Pkg, id, spec, importPath)
+	}
+	return false
+}
+
+func (r *resolver) declarePackageLevel(decl ast.Declaration) {
+	pkg := r.info.Package
+	switch d := decl.(type) {
+	case *ast.GenDecl:
+		for iota, spec := range d.Specs {
+			switch s := spec.(type) {
+			case *ast.ValueSpec:
+				kind, data := Var, any(nil)
+				if d.Token.Type == lexer.CONST {
+					kind, data = Con, iota
+				}
+				for _, name := range s.Names {
+					if name != nil {
+						r.declareIn(pkg, kind, name, s, data)
+					}
+				}
+			case *ast.TypeSpec:
+				if s.Name != nil {
+					r.declareIn(pkg, Typ, s.Name, s, nil)
+				}
+			}
+		}
+	case *ast.FuncDecl:
+		if d.Name == nil {
+			return
+		}
+		if d.Recv != nil || d.Name.Value == "init" {
+			// methods and init functions cannot be referred to by name
+			r.info.Defs[d.Name] = &Object{Kind: Fun, Name: d.Name.Value, Ident: d.Name, Decl: d}
+			return
+		}
+		r.declareIn(pkg, Fun, d.Name, d, nil)
+	}
+}
+
+func (r *resolver) resolvePackageLevel(decl ast.Declaration) {
+	switch d := decl.(type) {
+	case *ast.GenDecl:
+		for _, spec := range d.Specs {
+			switch s := spec.(type) {
+			case *ast.ValueSpec:
+				r.walkExpr(s.Type)
+				r.walkExprList(s.Values)
+			case *ast.TypeSpec:
+				r.typeSpec(s)
+			}
+		}
+	case *ast.FuncDecl:
+		r.funcDecl(d)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Functions
+
+func (r *resolver) funcDecl(d *ast.FuncDecl) {
+	if d.Type == nil {
+		return
+	}
+	scope := r.openScope(d.Type, FuncScope)
+	scope.Pos, scope.End = d.Pos(), d.End()
+	if d.Recv != nil {
+		r.receiver(d.Recv)
+	}
+	r.typeParams(d.TypeParams)
+	r.signature(d.Type)
+	if d.Body != nil {
+		r.funcBody(d.Body, d.Body.Statements)
+	}
+	r.closeScope()
+}
+
+// receiver declares the receiver of a method and the type parameters named
+// by its base type, as in func (l *List[T]) Len() int.
+func (r *resolver) receiver(recv *ast.FieldList) {
+	for _, f := range recv.List {
+		typ := f.Type
+		if star, ok := typ.(*ast.StarExpr); ok {
+			typ = star.X
+		}
+		var params []ast.Expression
+		switch t := typ.(type) {
+		case *ast.IndexExpr:
+			typ, params = t.X, []ast.Expression{t.Index}
+		case *ast.IndexListExpr:
+			typ, params = t.X, t.Indices
+		}
+		for _, param := range params {
+			if id, ok := param.(*ast.Identifier); ok {
+				r.declare(Typ, id, f, nil)
+			} else {
+				r.walkExpr(param)
+			}
+		}
+		r.walkExpr(typ)
+		r.declareFields(Var, f)
+	}
+}
+
+// typeParams declares a list of type parameters before resolving their
+// constraints, which may refer to any of them.
+func (r *resolver) typeParams(list *ast.FieldList) {
+	if list == nil {
+		return
+	}
+	for _, f := range list.List {
+		r.declareFields(Typ, f)
+	}
+	for _, f := range list.List {
+		r.walkExpr(f.Type)
+	}
+}
+
+// signature resolves the parameter and result types of a function, and then
+// declares their names, so that a type is never resolved to a parameter.
+func (r *resolver) signature(typ *ast.FuncType) {
+	for _, list := range []*ast.FieldList{typ.Params, typ.Results} {
+		if list != nil {
+			for _, f := range list.List {
+				r.walkExpr(f.Type)
+			}
+		}
+	}
+	for _, list := range []*ast.FieldList{typ.Params, typ.Results} {
+		if list != nil {
+			for _, f := range list.List {
+				r.declareFields(Var, f)
+			}
+		}
+	}
+}
+
+func (r *resolver) declareFields(kind ObjKind, f *ast.Field) {
+	for _, name := range f.Names {
+		if name != nil {
+			r.declare(kind, name, f, nil)
+		}
+	}
+}
+
+// funcBody resolves the statements of a function body in the current scope,
+// which declares the parameters: the body does not open a scope of its own,
+// so that a parameter cannot be redeclared at its top level. Labels have
+// function scope and may be used before they are defined, so they are
+// declared first; they are not visible in nested function literals.
+func (r *resolver) funcBody(body ast.Node, list []ast.Statement) {
+	saved := r.labels
+	r.labels = make(map[string]*Object)
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch s := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.LabeledStmt:
+			// a duplicate label is reported by the parser; the first one wins
+			if s.Label != nil && s.Label.Value != "_" && r.labels[s.Label.Value] == nil {
+				obj := &Object{Kind: Lbl, Name: s.Label.Value, Ident: s.Label, Decl: s}
+				r.labels[obj.Name] = obj
+				r.info.Defs[s.Label] = obj
+			}
+		}
+		return true
+	})
+	r.walkStmtList(list)
+	r.labels = saved
+}
+
+// ----------------------------------------------------------------------------
+// Declarations in functions
+
+func (r *resolver) genDecl(d *ast.GenDecl) {
+	for iota, spec := range d.Specs {
+		switch s := spec.(type) {
+		case *ast.ValueSpec:
+			// the scope of a local constant or variable begins after its spec
+			r.walkExpr(s.Type)
+			r.walkExprList(s.Values)
+			kind, data := Var, any(nil)
+			if d.Token.Type == lexer.CONST {
+				kind, data = Con, iota
+			}
+			for _, name := range s.Names {
+				if name != nil {
+					r.declare(kind, name, s, data)
+				}
+			}
+		case *ast.TypeSpec:
+			// the scope of a local type begins at its name, so that it can
+			// refer to itself
+			if s.Name != nil {
+				r.declare(Typ, s.Name, s, nil)
+			}
+			r.typeSpec(s)
+		}
+	}
+}
+
+func (r *resolver) typeSpec(s *ast.TypeSpec) {
+	if s.TypeParams != nil {
+		r.openScope(s, BlockScope)
+		defer r.closeScope()
+		r.typeParams(s.TypeParams)
+	}
+	r.walkExpr(s.Type)
+}
+
+// shortVarDecl declares the new variables on the left of :=, after resolving
+// the right. A name already declared in the same scope is assigned instead,
+// but at least one name must be new.
+func (r *resolver) shortVarDecl(s *ast.AssignStmt) {
+	r.walkExprList(s.Rhs)
+	anyNew := false
+	for _, x := range s.Lhs {
+		id, ok := x.(*ast.Identifier)
+		if !ok {
+			// reported by the parser
+			r.walkExpr(x)
+			continue
+		}
+		if obj := r.scope.Lookup(id.Value); obj != nil && obj.Kind == Var {
+			r.info.Uses[id] = obj
+			continue
+		}
+		if id.Value != "_" {
+			anyNew = true
+		}
+		r.declare(Var, id, s, nil)
+	}
+	if !anyNew {
+		r.errorf(s.Token.Pos, "no new variables on left side of :=")
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Traversal
+
+// Visit resolves the identifiers of node. The nodes that declare names or
+// open scopes are handled here; for all others, Walk descends into the
+// children and every identifier met is a use.
+func (r *resolver) Visit(node ast.Node) ast.Visitor {
+	switch n := node.(type) {
+	case *ast.Identifier:
+		r.use(n)
+
+	case *ast.SelectorExpr:
+		r.walkExpr(n.X)
+		return nil
+
+	case *ast.KeyValueExpr:
+		// the key of a struct literal is a field name
+		if id, ok := n.Key.(*ast.Identifier); ok {
+			r.tryUse(id)
+		} else {
+			r.walkExpr(n.Key)
+		}
+		r.walkExpr(n.Value)
+		return nil
+
+	case *ast.FuncLit:
+		if n.Type == nil {
+			return nil
+		}
+		r.openScope(n.Type, FuncScope).End = n.End()
+		r.signature(n.Type)
+		if n.Body != nil {
+			r.funcBody(n.Body, n.Body.Statements)
+		}
+		r.closeScope()
+		return nil
+
+	case *ast.FuncType:
+		// a function type literal; its parameter names are only checked for
+		// duplicates
+		r.openScope(n, FuncScope)
+		r.signature(n)
+		r.closeScope()
+		return nil
+
+	case *ast.StructType:
+		if n.Fields != nil {
+			for _, f := range n.Fields.List {
+				r.walkExpr(f.Type)
+			}
+		}
+		return nil
+
+	case *ast.InterfaceType:
+		if n.Methods != nil {
+			for _, f := range n.Methods.List {
+				r.walkExpr(f.Type)
+			}
+		}
+		return nil
+
+	case *ast.BlockStatement:
+		r.openScope(n, BlockScope)
+		r.walkStmtList(n.Statements)
+		r.closeScope()
+		return nil
+
+	case *ast.AssignStmt:
+		if n.Token.Type == lexer.DEFINE {
+			r.shortVarDecl(n)
+			return nil
+		}
+
+	case *ast.DeclStmt:
+		if n.Decl != nil {
+			r.genDecl(n.Decl)
+		}
+		return nil
+
+	case *ast.LabeledStmt:
+		r.walkStmt(n.Stmt)
+		return nil
+
+	case *ast.BranchStmt:
+		// an undefined label is reported by the parser
+		if n.Label != nil {
+			if obj := r.labels[n.Label.Value]; obj != nil {
+				r.info.Uses[n.Label] = obj
+			}
+		}
+		return nil
+
+	case *ast.IfStmt:
+		r.openScope(n, BlockScope)
+		r.walkStmt(n.Init)
+		r.walkExpr(n.Cond)
+		if n.Body != nil {
+			ast.Walk(r, n.Body)
+		}
+		r.walkStmt(n.Else)
+		r.closeScope()
+		return nil
+
+	case *ast.ForStmt:
+		r.openScope(n, BlockScope)
+		r.walkStmt(n.Init)
+		r.walkExpr(n.Cond)
+		r.walkStmt(n.Post)
+		if n.Body != nil {
+			ast.Walk(r, n.Body)
+		}
+		r.closeScope()
+		return nil
+
+	case *ast.RangeStmt:
+		r.openScope(n, BlockScope)
+		r.walkExpr(n.X)
+		if n.Tok.Type == lexer.DEFINE {
+			for _, x := range []ast.Expression{n.Key, n.Value} {
+				if id, ok := x.(*ast.Identifier); ok {
+					r.declare(Var, id, n, nil)
+				} else {
+					r.walkExpr(x)
+				}
+			}
+		} else {
+			r.walkExpr(n.Key)
+			r.walkExpr(n.Value)
+		}
+		if n.Body != nil {
+			ast.Walk(r, n.Body)
+		}
+		r.closeScope()
+		return nil
+
+	case *ast.SwitchStmt:
+		r.openScope(n, BlockScope)
+		r.walkStmt(n.Init)
+		r.walkExpr(n.Tag)
+		for _, c := range n.Cases {
+			r.openScope(c, BlockScope)
+			r.walkExprList(c.List)
+			r.walkStmtList(c.Body)
+			r.closeScope()
+		}
+		r.closeScope()
+		return nil
+
+	case *ast.TypeSwitchStmt:
+		r.openScope(n, BlockScope)
+		r.walkStmt(n.Init)
+		r.walkExpr(n.X)
+		if n.Binding != nil {
+			r.info.Defs[n.Binding] = nil
+		}
+		for _, c := range n.Cases {
+			r.openScope(c, BlockScope)
+			r.walkExprList(c.List)
+			if n.Binding != nil {
+				// each clause declares its own variable, of the type of the
+				// clause
+				obj := &Object{Kind: Var, Name: n.Binding.Value, Ident: n.Binding, Decl: c}
+				if obj.Name != "_" {
+					r.scope.Insert(obj)
+				}
+				r.info.Implicits[c] = obj
+			}
+			r.walkStmtList(c.Body)
+			r.closeScope()
+		}
+		r.closeScope()
+		return nil
+
+	case *ast.CommClause:
+		r.openScope(n, BlockScope)
+		r.walkStmt(n.Comm)
+		r.walkStmtList(n.Body)
+		r.closeScope()
+		return nil
+	}
+	return r
+}
+
+func (r *resolver) walkExpr(x ast.Expression) {
+	if x != nil {
+		ast.Walk(r, x)
+	}
+}
+
+func (r *resolver) walkExprList(list []ast.Expression) {
+	for _, x := range list {
+		r.walkExpr(x)
+	}
+}
+
+func (r *resolver) walkStmt(s ast.Statement) {
+	if s != nil {
+		ast.Walk(r, s)
+	}
+}
+
+func (r *resolver) walkStmtList(list []ast.Statement) {
+	for _, s := range list {
+		r.walkStmt(s)
+	}
+}
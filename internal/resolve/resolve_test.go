@@ -0,0 +1,202 @@
+package resolve
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+	parser "github.com/mohit-bhandari45/Compiler-GO.git/internal/parser"
+)
+
+// resolveSource parses and resolves src as the file p.go. It returns the
+// uses of identifiers, one per line in source order as
+// "name line:col -> object line:col", where the object's position is
+// omitted for predeclared objects, and the errors, one per line.
+func resolveSource(t *testing.T, src string) (uses, errors string) {
+	t.Helper()
+	fset := lexer.NewFileSet()
+	f := fset.AddFile("p.go", src)
+	p := parser.New(lexer.NewInFile(f, src, 0))
+	file := p.ParseFile()
+	if errs := p.Errors(); len(errs) > 0 {
+		t.Fatalf("parse errors: %v", errs)
+	}
+	info, errs := Files(fset, file)
+
+	var ids []*ast.Identifier
+	for id := range info.Uses {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].Pos() < ids[j].Pos() })
+	var lines []string
+	for _, id := range ids {
+		pos := fset.Position(id.Pos())
+		line := fmt.Sprintf("%s %d:%d -> %s", id.Value, pos.Line, pos.Column, info.Uses[id])
+		if obj := info.Uses[id]; obj.Pos().IsValid() {
+			decl := fset.Position(obj.Pos())
+			line += fmt.Sprintf(" %d:%d", decl.Line, decl.Column)
+		}
+		lines = append(lines, line)
+	}
+	uses = strings.Join(lines, "\n")
+
+	lines = nil
+	for _, err := range errs {
+		lines = append(lines, err.Error())
+	}
+	return uses, strings.Join(lines, "\n")
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name, src    string
+		uses, errors string
+	}{
+		{
+			name: "shadowing",
+			src: `package p
+var x = 1
+func f() int {
+	x := x
+	if x := 2; x > 0 {
+		return x
+	}
+	return x
+}
+`,
+			uses: `int 3:10 -> type int
+x 4:7 -> var x 2:5
+x 5:13 -> var x 5:5
+x 6:10 -> var x 5:5
+x 8:9 -> var x 4:2`,
+		},
+		{
+			name: "predeclared shadowed",
+			src: `package p
+func f() {
+	_ = len("")
+	len := 1
+	_ = len
+}
+`,
+			uses: `len 3:6 -> builtin len
+len 5:6 -> var len 4:2`,
+		},
+		{
+			name: "package level order",
+			src: `package p
+func f() T { return g() }
+func g() T { return T(0) }
+type T int
+`,
+			uses: `T 2:10 -> type T 4:6
+g 2:21 -> func g 3:6
+T 3:10 -> type T 4:6
+T 3:21 -> type T 4:6
+int 4:8 -> type int`,
+		},
+		{
+			name: "unresolved",
+			src: `package p
+func f() {
+	_ = y
+	y := 1
+	_ = y
+	_ = z.w
+}
+`,
+			uses: `y 5:6 -> var y 4:2`,
+			errors: `p.go:3:6: undefined: y
+p.go:6:6: undefined: z`,
+		},
+		{
+			name: "labels",
+			src: `package p
+func f() {
+L:
+	for {
+		if true {
+			break L
+		}
+		goto M
+	}
+M:
+	func() {
+	L:
+		for {
+			continue L
+		}
+	}()
+}
+`,
+			uses: `true 5:6 -> const true
+L 6:10 -> label L 3:1
+M 8:8 -> label M 10:1
+L 14:13 -> label L 12:2`,
+		},
+		{
+			name: "method receivers",
+			src: `package p
+type List[T any] struct{ head *T }
+func (l *List[E]) Head() *E { return l.head }
+func (List[_]) Len() int { return 0 }
+`,
+			uses: `any 2:13 -> type any
+T 2:32 -> type T 2:11
+List 3:10 -> type List 2:6
+E 3:27 -> type E 3:15
+l 3:38 -> var l 3:7
+List 4:7 -> type List 2:6
+int 4:22 -> type int`,
+		},
+		{
+			name: "closures",
+			src: `package p
+func f(a int) func() int {
+	b := a
+	return func() int {
+		c := b
+		a := c
+		return a
+	}
+}
+`,
+			uses: `int 2:10 -> type int
+int 2:22 -> type int
+a 3:7 -> var a 2:8
+int 4:16 -> type int
+b 5:8 -> var b 3:2
+c 6:8 -> var c 5:3
+a 7:10 -> var a 6:3`,
+		},
+		{
+			name: "redeclared",
+			src: `package p
+func f(a, a int) {
+	b := 1
+	var b int
+	_ = b
+}
+`,
+			uses: `int 2:13 -> type int
+int 4:8 -> type int
+b 5:6 -> var b 3:2`,
+			errors: "p.go:2:11: duplicate argument a\n\tp.go:2:8: other declaration of a\n" +
+				"p.go:4:6: b redeclared in this block\n\tp.go:3:2: other declaration of b",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uses, errors := resolveSource(t, tt.src)
+			if uses != tt.uses {
+				t.Errorf("uses:\n%s\nwant:\n%s", uses, tt.uses)
+			}
+			if errors != tt.errors {
+				t.Errorf("errors:\n%s\nwant:\n%s", errors, tt.errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package resolve
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+)
+
+// ObjKind describes what an object denotes.
+type ObjKind int
+
+const (
+	Bad     ObjKind = iota // for error handling
+	Pkg                    // package, declared by an import
+	Con                    // constant
+	Typ                    // type, including type parameters
+	Var                    // variable, parameter or result
+	Fun                    // function or method
+	Lbl                    // label
+	Builtin                // predeclared function such as len
+	Nil                    // the predeclared nil
+)
+
+var objKindStrings = [...]string{
+	Bad:     "bad",
+	Pkg:     "package",
+	Con:     "const",
+	Typ:     "type",
+	Var:     "var",
+	Fun:     "func",
+	Lbl:     "label",
+	Builtin: "builtin",
+	Nil:     "nil",
+}
+
+func (kind ObjKind) String() string { return objKindStrings[kind] }
+
+// An Object is a named language entity: a package, constant, type,
+// variable, function or label.
+type Object struct {
+	Kind   ObjKind
+	Name   string          // the declared name
+	Ident  *ast.Identifier // the declaring identifier; nil for predeclared objects and unnamed imports
+	Decl   ast.Node        // the declaring node; see below
+	Data   any             // for a constant: its iota (int); for a package: its import path (string)
+	Parent *Scope          // the scope the object is declared in; nil for blank objects
+}
+
+// Decl is one of
+//
+//	*ast.ImportSpec    Pkg
+//	*ast.ValueSpec     Con, Var
+//	*ast.TypeSpec      Typ
+//	*ast.FuncDecl      Fun
+//	*ast.Field         Var (parameters, results, receivers), Typ (type parameters)
+//	*ast.AssignStmt    Var (short variable declarations)
+//	*ast.RangeStmt     Var (iteration variables declared with :=)
+//	*ast.CaseClause    Var (the variable of a type switch, declared per clause)
+//	*ast.LabeledStmt   Lbl
+//
+// and nil for the predeclared objects of the universe.
+
+// Pos returns the position of the declaring identifier, or, for an import
+// without a name, of the import path. It is NoPos for a predeclared object.
+func (obj *Object) Pos() lexer.Pos {
+	switch {
+	case obj.Ident != nil:
+		return obj.Ident.Pos()
+	case obj.Kind == Pkg:
+		return obj.Decl.(*ast.ImportSpec).Path.Pos()
+	}
+	return lexer.NoPos
+}
+
+func (obj *Object) String() string {
+	return obj.Kind.String() + " " + obj.Name
+}
+
+// ScopeKind describes the construct a scope belongs to.
+type ScopeKind int
+
+const (
+	UniverseScope ScopeKind = iota // the predeclared identifiers
+	PackageScope                   // the package-level declarations of all files
+	FileScope                      // the imports of one file
+	FuncScope                      // the receiver, type parameters, parameters and results of a function
+	BlockScope                     // a block, explicit or implicit (if, for, switch, case clause, ...)
+)
+
+var scopeKindStrings = [...]string{
+	UniverseScope: "universe",
+	PackageScope:  "package",
+	FileScope:     "file",
+	FuncScope:     "function",
+	BlockScope:    "block",
+}
+
+func (kind ScopeKind) String() string { return scopeKindStrings[kind] }
+
+// A Scope maps names to the objects declared in one block, and links to the
+// scope enclosing it.
+type Scope struct {
+	Kind     ScopeKind
+	Parent   *Scope
+	Children []*Scope  // the nested scopes; not recorded for the universe, which all packages share
+	Node     ast.Node  // the node the scope belongs to; nil for the universe and package scopes
+	Pos, End lexer.Pos // the source range of the scope; NoPos for the universe and package scopes
+	Objects  map[string]*Object
+}
+
+// NewScope returns a new, empty scope nested in parent, which may be nil.
+func NewScope(parent *Scope, kind ScopeKind, node ast.Node) *Scope {
+	s := &Scope{Kind: kind, Parent: parent, Node: node, Objects: make(map[string]*Object)}
+	if node != nil {
+		s.Pos, s.End = node.Pos(), node.End()
+	}
+	if parent != nil && parent.Kind != UniverseScope {
+		parent.Children = append(parent.Children, s)
+	}
+	return s
+}
+
+// Lookup returns the object declared in s with the given name, or nil. The
+// enclosing scopes are not searched.
+func (s *Scope) Lookup(name string) *Object {
+	return s.Objects[name]
+}
+
+// LookupParent looks name up in s and then in the scopes enclosing it, and
+// returns the innermost object with that name and the scope declaring it,
+// or nil, nil.
+func (s *Scope) LookupParent(name string) (*Scope, *Object) {
+	for ; s != nil; s = s.Parent {
+		if obj := s.Objects[name]; obj != nil {
+			return s, obj
+		}
+	}
+	return nil, nil
+}
+
+// Insert declares obj in s, unless s already declares an object with the
+// same name; that object is then returned and s is left unchanged.
+func (s *Scope) Insert(obj *Object) (alt *Object) {
+	if alt = s.Objects[obj.Name]; alt == nil {
+		s.Objects[obj.Name] = obj
+		obj.Parent = s
+	}
+	return
+}
+
+// Names returns the names declared in s, sorted.
+func (s *Scope) Names() []string {
+	names := make([]string, 0, len(s.Objects))
+	for name := range s.Objects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// String returns the kind of the scope and its objects, in name order, for
+// debugging.
+func (s *Scope) String() string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "%s scope {", s.Kind)
+	for i, name := range s.Names() {
+		if i > 0 {
+			out.WriteString(";")
+		}
+		fmt.Fprintf(&out, " %s", s.Objects[name])
+	}
+	out.WriteString(" }")
+	return out.String()
+}
+
+// Universe is the scope of the predeclared identifiers. It encloses every
+// package scope.
+var Universe = NewScope(nil, UniverseScope, nil)
+
+func init() {
+	for _, name := range []string{
+		"any", "bool", "byte", "comparable", "complex64", "complex128", "error",
+		"float32", "float64", "int", "int8", "int16", "int32", "int64", "rune",
+		"string", "uint", "uint8", "uint16", "uint32", "uint64", "uintptr",
+	} {
+		Universe.Insert(&Object{Kind: Typ, Name: name})
+	}
+	for _, name := range []string{"true", "false", "iota"} {
+		Universe.Insert(&Object{Kind: Con, Name: name})
+	}
+	for _, name := range []string{
+		"append", "cap", "clear", "close", "complex", "copy", "delete", "imag",
+		"len", "make", "max", "min", "new", "panic", "print", "println", "real",
+		"recover",
+	} {
+		Universe.Insert(&Object{Kind: Builtin, Name: name})
+	}
+	Universe.Insert(&Object{Kind: Nil, Name: "nil"})
+}
@@ -0,0 +1,321 @@
+// Copyright 2013 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE-GO file.
+//
+// Adapted from go/types (cmd/compile/internal/types2).
+
+package types
+
+import (
+	"strconv"
+
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+	resolve "github.com/mohit-bhandari45/Compiler-GO.git/internal/resolve"
+)
+
+// assignment checks that x can be assigned to a variable of type T in the
+// given context, converting an untyped x to T. If T is nil, x takes its
+// default type, as in a short variable declaration.
+func (c *Checker) assignment(x *operand, T Type, context string) {
+	switch x.mode {
+	case invalid:
+		return
+	case constant_, variable, mapindex, value, commaok:
+		// ok
+	default:
+		c.errorf(x.expr, "cannot assign %s to %s in %s", x, T, context)
+		x.mode = invalid
+		return
+	}
+
+	if isUntyped(x.typ) {
+		target := T
+		// an interface context gives an untyped value its default type
+		if T == nil || isInterface(T) {
+			if T == nil && x.typ == Typ[UntypedNil] {
+				c.errorf(x.expr, "use of untyped nil in %s", context)
+				x.mode = invalid
+				return
+			}
+			if T == nil || !x.isNil() {
+				target = Default(x.typ)
+			}
+		}
+		typ, val, cause := c.implicitType(x, target)
+		if typ == nil {
+			msg := "cannot use %s as %s value in %s"
+			if cause != "" {
+				msg += " (" + cause + ")"
+			}
+			c.errorf(x.expr, msg, x, target, context)
+			x.mode = invalid
+			return
+		}
+		if val != nil {
+			x.val = val
+			c.updateExprVal(x.expr, val)
+		}
+		if typ != x.typ {
+			x.typ = typ
+			c.updateExprType(x.expr, typ)
+		}
+	}
+	if T == nil {
+		return
+	}
+
+	if ok, cause := c.assignableTo(x, T); !ok {
+		if cause != "" {
+			c.errorf(x.expr, "cannot use %s as %s value in %s: %s", x, T, context, cause)
+		} else {
+			c.errorf(x.expr, "cannot use %s as %s value in %s", x, T, context)
+		}
+		x.mode = invalid
+	}
+}
+
+// assignableTo reports whether x is assignable to a variable of type T, and
+// if not, possibly why.
+func (c *Checker) assignableTo(x *operand, T Type) (bool, string) {
+	V := x.typ
+	if !isValid(V) || !isValid(T) {
+		return true, "" // avoid follow-up errors
+	}
+	if Identical(V, T) {
+		return true, ""
+	}
+	Vu, Tu := under(V), under(T)
+	_, Vp := V.(*TypeParam)
+	_, Tp := T.(*TypeParam)
+
+	if isUntyped(V) {
+		typ, _, _ := c.implicitType(x, T)
+		return typ != nil, ""
+	}
+
+	// identical underlying types, and one of them is not named
+	if Identical(Vu, Tu) && (!hasName(V) || !hasName(T)) && !Vp && !Tp {
+		return true, ""
+	}
+
+	// T is an interface V implements
+	if _, ok := Tu.(*Interface); ok && !Tp {
+		if cause := implements(V, T, false); cause != "" {
+			return false, cause
+		}
+		return true, ""
+	}
+	if _, ok := Vu.(*Interface); ok && !Vp {
+		return false, "need type assertion"
+	}
+
+	// a bidirectional channel to a channel type with the same element
+	// type, if one of them is not named
+	if vc, ok := Vu.(*Chan); ok && vc.Dir == ast.SEND|ast.RECV {
+		if tc, ok := Tu.(*Chan); ok && Identical(vc.Elem, tc.Elem) {
+			return !hasName(V) || !hasName(T), ""
+		}
+	}
+	return false, ""
+}
+
+// hasName reports whether t has a name: a predeclared, defined or type
+// parameter type.
+func hasName(t Type) bool {
+	switch t.(type) {
+	case *Basic, *Named, *TypeParam:
+		return true
+	}
+	return false
+}
+
+// ----------------------------------------------------------------------------
+// Assignments
+
+// exprListN checks the values assigned to n variables: n expressions, one
+// call with n results, or, if n is 2, one comma-ok expression, whose second
+// value is an untyped boolean.
+func (c *Checker) exprListN(rhs []ast.Expression, n int) []*operand {
+	isCall := false
+	if len(rhs) == 1 {
+		_, isCall = unparen(rhs[0]).(*ast.CallExpr)
+	}
+	if len(rhs) != 1 || n == 1 && !isCall {
+		list := make([]*operand, len(rhs))
+		for i, e := range rhs {
+			list[i] = new(operand)
+			c.expr(list[i], e)
+		}
+		return list
+	}
+
+	x := new(operand)
+	c.rawExpr(x, rhs[0], nil)
+	c.exclude(x, 1<<novalue|1<<builtin|1<<typexpr)
+	if t, ok := x.typ.(*Tuple); ok && x.mode == value {
+		list := make([]*operand, t.Len())
+		for i, v := range t.Vars {
+			list[i] = &operand{mode: value, expr: rhs[0], typ: v.Type}
+		}
+		return list
+	}
+	if n == 2 && (x.mode == commaok || x.mode == mapindex) {
+		x.mode = value
+		return []*operand{x, {mode: value, expr: rhs[0], typ: Typ[UntypedBool]}}
+	}
+	c.nonGeneric(x)
+	return []*operand{x}
+}
+
+// invalidOperands reports whether one of list is invalid.
+func invalidOperands(list []*operand) bool {
+	for _, x := range list {
+		if x.mode == invalid {
+			return true
+		}
+	}
+	return false
+}
+
+// assignError reports the assignment of r values to l variables.
+func (c *Checker) assignError(rhs []ast.Expression, l, r int) {
+	vars := measure(l, "variable")
+	vals := measure(r, "value")
+	if len(rhs) == 1 {
+		if call, ok := unparen(rhs[0]).(*ast.CallExpr); ok {
+			c.errorf(rhs[0], "assignment mismatch: %s but %s returns %s", vars, exprString(call.Fun), vals)
+			return
+		}
+	}
+	c.errorf(rhs[0], "assignment mismatch: %s but %s", vars, vals)
+}
+
+// returnError reports a return statement with the wrong number of values.
+func (c *Checker) returnError(s *ast.ReturnStmt, results []*Var, xs []*operand) {
+	l, r := len(results), len(xs)
+	qualifier := "not enough"
+	var at ast.Node = s
+	if r > l {
+		at = xs[l].expr
+		qualifier = "too many"
+	} else if r > 0 {
+		at = xs[r-1].expr
+	}
+	c.errorf(at, "%s return values\n\thave %s\n\twant %s", qualifier, typesSummary(operandTypes(xs), false), typesSummary(varTypes(results), false))
+}
+
+// measure returns n with the unit, pluralized: 1 value, 2 values.
+func measure(n int, unit string) string {
+	if n != 1 {
+		unit += "s"
+	}
+	return strconv.Itoa(n) + " " + unit
+}
+
+// assignVars checks the assignment lhs = rhs.
+func (c *Checker) assignVars(lhs, rhs []ast.Expression) {
+	xs := c.exprListN(rhs, len(lhs))
+	if len(xs) != len(lhs) {
+		for _, e := range lhs {
+			c.lhsVar(e)
+		}
+		if !invalidOperands(xs) {
+			c.assignError(rhs, len(lhs), len(xs))
+		}
+		return
+	}
+	for i, e := range lhs {
+		c.assignVar(e, xs[i], "assignment")
+	}
+}
+
+// assignVar checks the assignment of x to the operand lhs.
+func (c *Checker) assignVar(lhs ast.Expression, x *operand, context string) {
+	T := c.lhsVar(lhs)
+	switch {
+	case T == nil:
+		c.assignment(x, nil, "assignment to _ identifier")
+	case !isValid(T):
+		// an error has been reported
+	default:
+		c.assignment(x, T, context)
+	}
+}
+
+// lhsVar checks an operand assigned to, and returns its type: nil for the
+// blank identifier, and the invalid type if it cannot be assigned to.
+func (c *Checker) lhsVar(lhs ast.Expression) Type {
+	if id, ok := unparen(lhs).(*ast.Identifier); ok && id.Value == "_" {
+		return nil
+	}
+	// assigning to a variable does not use it
+	var v *resolve.Object
+	if id, ok := unparen(lhs).(*ast.Identifier); ok {
+		if obj := c.info.Uses[id]; obj != nil && obj.Kind == resolve.Var {
+			v = obj
+		}
+	}
+	vUsed := c.used[v]
+	var x operand
+	c.expr(&x, lhs)
+	if v != nil {
+		c.used[v] = vUsed
+	}
+	switch x.mode {
+	case invalid:
+		return Typ[Invalid]
+	case variable, mapindex:
+		return x.typ
+	}
+	if sel, ok := unparen(lhs).(*ast.SelectorExpr); ok {
+		var op operand
+		c.expr(&op, sel.X)
+		if op.mode == mapindex {
+			c.errorf(lhs, "cannot assign to struct field %s in map", exprString(lhs))
+			return Typ[Invalid]
+		}
+	}
+	c.errorf(lhs, "cannot assign to %s (neither addressable nor a map index expression)", exprString(lhs))
+	return Typ[Invalid]
+}
+
+// shortVarDecl checks the short variable declaration lhs := rhs. resolve
+// has bound each new variable in Defs, and each redeclared one in Uses.
+func (c *Checker) shortVarDecl(s *ast.AssignStmt) {
+	xs := c.exprListN(s.Rhs, len(s.Lhs))
+	if len(xs) != len(s.Lhs) {
+		for _, e := range s.Lhs {
+			if id, ok := e.(*ast.Identifier); ok {
+				c.recordObject(c.info.Defs[id], Typ[Invalid])
+			}
+		}
+		if !invalidOperands(xs) {
+			c.assignError(s.Rhs, len(s.Lhs), len(xs))
+		}
+		return
+	}
+	for i, e := range s.Lhs {
+		x := xs[i]
+		id, ok := e.(*ast.Identifier)
+		if !ok {
+			continue // reported by the parser
+		}
+		if obj := c.info.Defs[id]; obj != nil {
+			c.assignment(x, nil, "assignment")
+			c.declareVar(obj, x)
+			continue
+		}
+		if obj := c.info.Uses[id]; obj != nil && obj.Kind == resolve.Var {
+			c.assignment(x, c.objType(obj), "assignment")
+		}
+	}
+}
+
+// declareVar records the type of the new variable obj, initialized to x.
+func (c *Checker) declareVar(obj *resolve.Object, x *operand) {
+	if x.mode == invalid {
+		c.recordObject(obj, Typ[Invalid])
+		return
+	}
+	c.recordObject(obj, x.typ)
+}
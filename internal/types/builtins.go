@@ -0,0 +1,416 @@
+// Copyright 2012 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE-GO file.
+//
+// Adapted from go/types (cmd/compile/internal/types2).
+
+package types
+
+import (
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+	constant "github.com/mohit-bhandari45/Compiler-GO.git/internal/constant"
+)
+
+// builtin checks a call of the predeclared function id, and reports whether
+// it is valid. x is the result of the call if so.
+func (c *Checker) builtin(x *operand, call *ast.CallExpr, id builtinId) bool {
+	bin := predeclaredFuncs[id]
+	nargs := len(call.Args)
+
+	if call.Ellipsis.Type != "" && id != _Append {
+		c.errorfAt(call.Ellipsis.Pos, "invalid operation: invalid use of ... with built-in %s", bin.name)
+		c.use(call.Args...)
+		return false
+	}
+
+	// the arguments of make and new begin with a type, and are checked
+	// below; the others are values
+	var args []*operand
+	switch id {
+	case _Make, _New:
+	default:
+		args = c.callArgs(call.Args)
+		for _, a := range args {
+			if a.mode == invalid {
+				return false
+			}
+		}
+		nargs = len(args)
+	}
+
+	msg := ""
+	if nargs < bin.nargs {
+		msg = "not enough"
+	} else if !bin.variadic && nargs > bin.nargs {
+		msg = "too many"
+	}
+	if msg != "" {
+		c.errorfAt(call.Rparen.Pos, "invalid operation: %s arguments for %s (expected %d, found %d)", msg, exprString(call), bin.nargs, nargs)
+		if id == _Make || id == _New {
+			c.use(call.Args...)
+		}
+		return false
+	}
+	if len(args) > 0 {
+		*x = *args[0]
+	}
+
+	switch id {
+	case _Append:
+		// append(s S, x ...E) S, where E is the element type of S
+		S := x.typ
+		s, ok := coreType(S).(*Slice)
+		if !ok {
+			if x.isNil() {
+				c.errorf(x.expr, "invalid argument: %s (untyped nil) is not a slice", exprString(x.expr))
+			} else {
+				c.errorf(x.expr, "invalid argument: %s is not a slice", x)
+			}
+			return false
+		}
+		// append([]byte, string...) is permitted
+		if nargs == 2 && call.Ellipsis.Type != "" && allBasic(args[1].typ, IsString) {
+			if b, ok := under(s.Elem).(*Basic); ok && b.kind == Byte {
+				c.assignment(args[1], Typ[String], "argument to append")
+				x.mode, x.typ = value, S
+				return true
+			}
+		}
+		sig := &Signature{
+			Params:   &Tuple{Vars: []*Var{{Type: S}, {Type: &Slice{Elem: s.Elem}}}},
+			Results:  &Tuple{Vars: []*Var{{Type: S}}},
+			Variadic: true,
+		}
+		if c.arguments(call, sig, nil, nil, args) == nil {
+			return false
+		}
+		x.mode, x.typ = value, S
+
+	case _Cap, _Len:
+		mode := invalid
+		var val constant.Value
+		switch t := arrayPtrDeref(coreTypeOrString(x.typ)).(type) {
+		case *Basic:
+			if isString(t) && id == _Len {
+				mode = value
+				if x.mode == constant_ {
+					mode = constant_
+					val = constant.MakeInt64(int64(len(constant.StringVal(x.val))))
+				}
+			}
+		case *Array:
+			mode = value
+			// the length of an array is a constant, if finding the
+			// array does not call a function or receive from a channel
+			if !isTypeParam(x.typ) && !hasCallOrRecv(call.Args[0]) {
+				mode = constant_
+				val = constant.MakeUnknown()
+				if t.Len >= 0 {
+					val = constant.MakeInt64(t.Len)
+				}
+			}
+		case *Slice, *Chan:
+			mode = value
+		case *Map:
+			if id == _Len {
+				mode = value
+			}
+		}
+		if mode == invalid {
+			c.errorf(x.expr, "invalid argument: %s for built-in %s", x, bin.name)
+			return false
+		}
+		if isUntyped(x.typ) {
+			c.updateExprType(x.expr, Default(x.typ))
+		}
+		x.mode, x.typ, x.val = mode, Typ[Int], val
+
+	case _Clear:
+		switch coreType(x.typ).(type) {
+		case *Map, *Slice:
+		default:
+			c.errorf(x.expr, "invalid argument: %s must be a map or slice", x)
+			return false
+		}
+		x.mode, x.typ = novalue, nil
+
+	case _Close:
+		ch, ok := coreType(x.typ).(*Chan)
+		if !ok {
+			c.errorf(x.expr, "invalid operation: cannot close non-channel %s", x)
+			return false
+		}
+		if ch.Dir == ast.RECV {
+			c.errorf(x.expr, "invalid operation: cannot close receive-only channel %s", x)
+			return false
+		}
+		x.mode, x.typ = novalue, nil
+
+	case _Complex:
+		// complex(x, y floatT) complexT
+		y := args[1]
+		c.matchTypes(x, y)
+		if x.mode == invalid {
+			c.errorf(call, "invalid operation: %s (mismatched types %s and %s)", exprString(call), args[0].typ, y.typ)
+			return false
+		}
+		// untyped constants convert to untyped float
+		toFloat := func(x *operand) {
+			if isUntyped(x.typ) && isNumeric(x.typ) && x.typ.(*Basic).kind < UntypedFloat {
+				x.typ = Typ[UntypedFloat]
+				if x.mode == constant_ {
+					x.val = constant.ToFloat(x.val)
+				}
+			}
+		}
+		toFloat(x)
+		toFloat(y)
+		if !Identical(x.typ, y.typ) {
+			c.errorf(call, "invalid operation: %s (mismatched types %s and %s)", exprString(call), x.typ, y.typ)
+			return false
+		}
+		var res BasicKind
+		if b, ok := under(x.typ).(*Basic); ok {
+			switch b.kind {
+			case Float32:
+				res = Complex64
+			case Float64:
+				res = Complex128
+			case UntypedFloat:
+				res = UntypedComplex
+			}
+		}
+		if res == Invalid {
+			c.errorf(x.expr, "invalid argument: arguments have type %s, expected floating-point", x.typ)
+			return false
+		}
+		if x.mode == constant_ && y.mode == constant_ {
+			x.val = constant.MakeComplex(x.val, y.val)
+		} else {
+			x.mode = value
+		}
+		if isTyped(x.typ) {
+			c.updateExprType(args[0].expr, x.typ)
+			c.updateExprType(y.expr, y.typ)
+		}
+		x.typ = Typ[res]
+
+	case _Copy:
+		// copy(dst, src []T) int; src may be a string if T is byte
+		y := args[1]
+		dst, _ := coreType(x.typ).(*Slice)
+		var srcElem Type
+		switch t := coreTypeOrString(y.typ).(type) {
+		case *Slice:
+			srcElem = t.Elem
+		case *Basic:
+			if isString(t) {
+				srcElem = universeByte
+			}
+		}
+		if dst == nil || srcElem == nil {
+			c.errorf(x.expr, "invalid argument: copy expects slice arguments; found %s and %s", x, y)
+			return false
+		}
+		if !Identical(dst.Elem, srcElem) {
+			c.errorf(x.expr, "invalid argument: arguments to copy %s and %s have different element types %s and %s", x, y, dst.Elem, srcElem)
+			return false
+		}
+		if isUntyped(y.typ) {
+			c.updateExprType(y.expr, Typ[String])
+		}
+		x.mode, x.typ = value, Typ[Int]
+
+	case _Delete:
+		// delete(m map[K]V, key K)
+		m, ok := coreType(x.typ).(*Map)
+		if !ok {
+			c.errorf(x.expr, "invalid argument: %s is not a map", x)
+			return false
+		}
+		c.assignment(args[1], m.Key, "argument to delete")
+		if args[1].mode == invalid {
+			return false
+		}
+		x.mode, x.typ = novalue, nil
+
+	case _Imag, _Real:
+		// real(complexT) floatT
+		if isUntyped(x.typ) && isNumeric(x.typ) {
+			x.typ = Typ[UntypedComplex]
+			if x.mode == constant_ {
+				x.val = constant.ToComplex(x.val)
+			}
+		}
+		var res BasicKind
+		if b, ok := under(x.typ).(*Basic); ok {
+			switch b.kind {
+			case Complex64:
+				res = Float32
+			case Complex128:
+				res = Float64
+			case UntypedComplex:
+				res = UntypedFloat
+			}
+		}
+		if res == Invalid {
+			c.errorf(x.expr, "invalid argument: argument has type %s, expected complex type", x.typ)
+			return false
+		}
+		if x.mode == constant_ {
+			if id == _Real {
+				x.val = constant.Real(x.val)
+			} else {
+				x.val = constant.Imag(x.val)
+			}
+		} else {
+			x.mode = value
+		}
+		x.typ = Typ[res]
+
+	case _Make:
+		// make(T, args...) T, for a slice, map or channel type T
+		T := c.varType(call.Args[0])
+		if !isValid(T) {
+			c.use(call.Args[1:]...)
+			return false
+		}
+		var min int // the minimum number of arguments
+		switch coreType(T).(type) {
+		case *Slice:
+			min = 2
+		case *Map, *Chan:
+			min = 1
+		default:
+			c.errorf(call.Args[0], "invalid argument: cannot make %s; type must be slice, map, or channel", exprString(call.Args[0]))
+			c.use(call.Args[1:]...)
+			return false
+		}
+		if nargs < min || min+1 < nargs {
+			c.errorf(call, "invalid operation: %s expects %d or %d arguments; found %d", exprString(call), min, min+1, nargs)
+			c.use(call.Args[1:]...)
+			return false
+		}
+		var sizes []int64
+		for _, arg := range call.Args[1:] {
+			if v, ok := c.index(arg, -1); ok {
+				sizes = append(sizes, v)
+			}
+		}
+		if len(sizes) == 2 && sizes[0] > sizes[1] {
+			c.errorf(call.Args[1], "invalid argument: length and capacity swapped")
+		}
+		x.mode, x.typ = value, T
+
+	case _Max, _Min:
+		// max(x, y, ...) and min(x, y, ...) of the same ordered type
+		op := "<"
+		if id == _Max {
+			op = ">"
+		}
+		for i, a := range args {
+			if !isOrdered(a.typ) {
+				c.errorf(a.expr, "invalid argument: %s cannot be ordered", a)
+				return false
+			}
+			if i == 0 {
+				continue
+			}
+			c.matchTypes(x, a)
+			if x.mode == invalid || !Identical(x.typ, a.typ) {
+				c.errorf(a.expr, "invalid argument: mismatched types %s (previous argument) and %s (type of %s)", x.typ, a.typ, exprString(a.expr))
+				return false
+			}
+			if x.mode == constant_ && a.mode == constant_ {
+				if constant.Compare(a.val, op, x.val) {
+					x.val = a.val
+				}
+			} else {
+				x.mode = value
+			}
+		}
+		if x.mode != constant_ {
+			x.mode = value
+			c.assignment(x, universeAny, "argument to built-in "+bin.name)
+			if x.mode == invalid {
+				return false
+			}
+		}
+		for _, a := range args {
+			c.updateExprType(a.expr, x.typ)
+		}
+
+	case _New:
+		// new(T) *T
+		T := c.varType(call.Args[0])
+		if !isValid(T) {
+			return false
+		}
+		x.mode, x.typ = value, &Pointer{Elem: T}
+
+	case _Panic:
+		// panic(x any)
+		c.assignment(x, universeAny, "argument to panic")
+		if x.mode == invalid {
+			return false
+		}
+		x.mode, x.typ = novalue, nil
+
+	case _Print, _Println:
+		for _, a := range args {
+			c.assignment(a, nil, "argument to built-in "+bin.name)
+			if a.mode == invalid {
+				return false
+			}
+		}
+		x.mode, x.typ = novalue, nil
+
+	case _Recover:
+		x.mode, x.typ = value, universeAny
+	}
+	x.expr = call
+	return true
+}
+
+// hasCallOrRecv reports whether evaluating e calls a function or receives
+// from a channel.
+func hasCallOrRecv(e ast.Expression) bool {
+	found := false
+	ast.Inspect(e, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			found = true
+		case *ast.PrefixExpression:
+			if n.Operator == "<-" {
+				found = true
+			}
+		case *ast.FuncLit:
+			return false // not evaluated
+		}
+		return !found
+	})
+	return found
+}
+
+// coreTypeOrString is like coreType, but the core type of a type parameter
+// whose type set holds only byte slices and strings is string.
+func coreTypeOrString(t Type) Type {
+	if u := coreType(t); u != nil {
+		return u
+	}
+	if isString(t) {
+		return Typ[String]
+	}
+	return nil
+}
+
+// arrayPtrDeref returns the array type a pointer to an array points to; any
+// other type is returned unchanged.
+func arrayPtrDeref(t Type) Type {
+	if p, ok := t.(*Pointer); ok {
+		if a, ok := under(p.Elem).(*Array); ok {
+			return a
+		}
+	}
+	return t
+}
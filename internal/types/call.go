@@ -0,0 +1,608 @@
+// Copyright 2013 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE-GO file.
+//
+// Adapted from go/types (cmd/compile/internal/types2).
+
+package types
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+	constant "github.com/mohit-bhandari45/Compiler-GO.git/internal/constant"
+)
+
+// callExpr checks a call: of a function, of a builtin, or a conversion.
+func (c *Checker) callExpr(x *operand, e *ast.CallExpr) exprKind {
+	targs, xlist := c.funExpr(x, e.Fun)
+	switch x.mode {
+	case invalid:
+		c.use(e.Args...)
+		return statement
+	case typexpr:
+		c.nonGeneric(x)
+		if x.mode == invalid {
+			c.use(e.Args...)
+			return conversion
+		}
+		c.conversionCall(x, e)
+		return conversion
+	case builtin:
+		id := x.id
+		if !c.builtin(x, e, id) {
+			x.mode = invalid
+		}
+		// the builtins with results may not be used as statements
+		switch id {
+		case _Append, _Cap, _Complex, _Imag, _Len, _Make, _Max, _Min, _New, _Real:
+			return expression
+		}
+		return statement
+	}
+
+	sig, ok := coreType(x.typ).(*Signature)
+	if !ok {
+		c.errorf(e.Fun, "invalid operation: cannot call non-function %s", x)
+		c.use(e.Args...)
+		x.mode = invalid
+		return statement
+	}
+
+	args := c.callArgs(e.Args)
+	sig = c.arguments(e, sig, targs, xlist, args)
+	if sig == nil {
+		x.mode = invalid
+		return statement
+	}
+	switch sig.Results.Len() {
+	case 0:
+		x.mode, x.typ = novalue, nil
+	case 1:
+		x.mode, x.typ = value, sig.Results.Vars[0].Type
+	default:
+		x.mode, x.typ = value, sig.Results
+	}
+	return statement
+}
+
+// funExpr checks the function of a call. The type arguments of a generic
+// function may be incomplete in a call, and are returned to be completed by
+// inference.
+func (c *Checker) funExpr(x *operand, fun ast.Expression) ([]Type, []ast.Expression) {
+	var xexpr ast.Expression
+	var indices []ast.Expression
+	switch f := fun.(type) {
+	case *ast.IndexExpr:
+		xexpr, indices = f.X, []ast.Expression{f.Index}
+	case *ast.IndexListExpr:
+		xexpr, indices = f.X, f.Indices
+	default:
+		c.genericExpr(x, fun)
+		return nil, nil
+	}
+
+	c.genericExpr(x, xexpr)
+	if sig, ok := x.typ.(*Signature); ok && x.mode == value && len(sig.TypeParams) > 0 {
+		targs := make([]Type, len(indices))
+		for i, index := range indices {
+			targs[i] = c.varType(index)
+		}
+		if len(targs) > len(sig.TypeParams) {
+			c.errorf(indices[len(sig.TypeParams)], "got %d type arguments but %s has %d type parameters", len(targs), exprString(xexpr), len(sig.TypeParams))
+			x.mode = invalid
+		}
+		x.expr = fun
+		return targs, indices
+	}
+
+	if f, ok := fun.(*ast.IndexExpr); ok {
+		c.indexOperand(x, f)
+	} else {
+		c.instantiate(x, fun, indices)
+	}
+	x.expr = fun
+	c.record(x)
+	return nil, nil
+}
+
+// instantiate checks the explicit instantiation of the generic function or
+// type x with the type arguments indices, which must be complete.
+func (c *Checker) instantiate(x *operand, e ast.Expression, indices []ast.Expression) {
+	if x.mode == invalid {
+		c.use(indices...)
+		return
+	}
+	targs := make([]Type, len(indices))
+	for i, index := range indices {
+		targs[i] = c.varType(index)
+	}
+	switch t := x.typ.(type) {
+	case *Named:
+		if x.mode == typexpr && len(t.TypeParams) > 0 && len(t.TypeArgs) == 0 {
+			if !c.validTypeArgs(e, t.Obj.Name, t.TypeParams, targs, indices) {
+				x.mode = invalid
+				return
+			}
+			x.typ = instantiate(t, targs)
+			return
+		}
+	case *Signature:
+		if x.mode == value && len(t.TypeParams) > 0 {
+			if !c.validTypeArgs(e, exprString(x.expr), t.TypeParams, targs, indices) {
+				x.mode = invalid
+				return
+			}
+			x.typ = instantiateSig(t, makeSubstMap(t.TypeParams, targs))
+			return
+		}
+	}
+	if x.mode == typexpr {
+		c.errorf(e, "%s is not a generic type", x.typ)
+	} else {
+		c.errorf(e, "invalid operation: %s is not a generic function", x)
+	}
+	x.mode = invalid
+}
+
+// instantiateSig returns the signature of the instance of a generic
+// function.
+func instantiateSig(sig *Signature, smap substMap) *Signature {
+	inst := *sig
+	inst.TypeParams = nil
+	return subst(&inst, smap).(*Signature)
+}
+
+// callArgs checks the arguments of a call. A single argument may be a call
+// with several results, which are passed as separate arguments.
+func (c *Checker) callArgs(args []ast.Expression) []*operand {
+	if len(args) == 1 {
+		x := new(operand)
+		c.rawExpr(x, args[0], nil)
+		c.exclude(x, 1<<novalue|1<<builtin|1<<typexpr)
+		if t, ok := x.typ.(*Tuple); ok && x.mode == value {
+			list := make([]*operand, t.Len())
+			for i, v := range t.Vars {
+				list[i] = &operand{mode: value, expr: args[0], typ: v.Type}
+			}
+			return list
+		}
+		c.singleValue(x)
+		c.nonGeneric(x)
+		return []*operand{x}
+	}
+	list := make([]*operand, len(args))
+	for i, arg := range args {
+		list[i] = new(operand)
+		c.expr(list[i], arg)
+	}
+	return list
+}
+
+// arguments checks the arguments of a call of a function with signature
+// sig, inferring the type arguments of a generic function that targs does
+// not give. It returns the signature of the function called, or nil if the
+// call is invalid.
+func (c *Checker) arguments(call *ast.CallExpr, sig *Signature, targs []Type, xlist []ast.Expression, args []*operand) *Signature {
+	for _, a := range args {
+		if a.mode == invalid {
+			return nil
+		}
+	}
+	nargs := len(args)
+	ddd := call.Ellipsis.Type != ""
+	if ddd {
+		if !sig.Variadic {
+			c.errorfAt(call.Ellipsis.Pos, "cannot use ... in call to non-variadic %s", exprString(call.Fun))
+			return nil
+		}
+		if len(call.Args) == 1 && nargs > 1 {
+			c.errorf(call.Args[0], "cannot use ... with %d-valued %s", nargs, exprString(call.Args[0]))
+			return nil
+		}
+	}
+
+	// the parameters the arguments are passed to: the variadic parameter
+	// stands for as many arguments as there are, unless it is spread
+	params := append([]*Var(nil), sig.Params.vars()...)
+	nparams := len(params)
+	if sig.Variadic && !ddd && nargs >= nparams-1 {
+		last := params[nparams-1]
+		var elem Type = Typ[Invalid]
+		if s, ok := coreType(last.Type).(*Slice); ok {
+			elem = s.Elem
+		}
+		list := append([]*Var(nil), params[:nparams-1]...)
+		for len(list) < nargs {
+			list = append(list, &Var{Name: last.Name, Type: elem})
+		}
+		params, nparams = list, nargs
+	}
+	if nargs != nparams {
+		qualifier := "not enough"
+		at := call.Rparen.Pos
+		if nargs > nparams {
+			qualifier = "too many"
+			at = args[nparams].expr.Pos()
+		}
+		c.errorfAt(at, "%s arguments in call to %s\n\thave %s\n\twant %s",
+			qualifier, exprString(call.Fun), typesSummary(operandTypes(args), false), typesSummary(varTypes(sig.Params.vars()), sig.Variadic))
+		return nil
+	}
+
+	if len(sig.TypeParams) > 0 {
+		smap := c.infer(call, sig.TypeParams, targs, params, args)
+		if smap == nil {
+			return nil
+		}
+		targs = make([]Type, len(sig.TypeParams))
+		for i, tp := range sig.TypeParams {
+			targs[i] = smap[tp]
+		}
+		if !c.validTypeArgs(call.Fun, exprString(call.Fun), sig.TypeParams, targs, xlist) {
+			return nil
+		}
+		sig = instantiateSig(sig, smap)
+		for i, p := range params {
+			params[i] = &Var{Name: p.Name, Type: subst(p.Type, smap)}
+		}
+		c.info.Types[call.Fun] = sig
+	}
+
+	context := "argument to " + exprString(call.Fun)
+	for i, a := range args {
+		c.assignment(a, params[i].Type, context)
+	}
+	return sig
+}
+
+// infer infers the type arguments of a call of a generic function that
+// targs does not give from the types of the arguments. Untyped arguments
+// only determine the type parameters the typed ones leave open, with their
+// default types.
+func (c *Checker) infer(call *ast.CallExpr, tparams []*TypeParam, targs []Type, params []*Var, args []*operand) substMap {
+	smap := make(substMap, len(tparams))
+	for i, tp := range tparams {
+		smap[tp] = nil
+		if i < len(targs) {
+			smap[tp] = targs[i]
+		}
+	}
+	for i, a := range args {
+		if isUntyped(a.typ) || !isValid(a.typ) {
+			continue
+		}
+		if !unify(params[i].Type, a.typ, smap) {
+			c.errorf(a.expr, "type %s of %s does not match %s", a.typ, exprString(a.expr), subst(params[i].Type, smap))
+			return nil
+		}
+	}
+	for i, a := range args {
+		tp, ok := params[i].Type.(*TypeParam)
+		if !ok || !isUntyped(a.typ) || a.isNil() {
+			continue
+		}
+		if _, ok := smap[tp]; !ok {
+			continue
+		}
+		// the largest untyped kind wins: f(1, 2.5) infers float64
+		if t, ok := smap[tp].(*Basic); ok && isUntyped(t) && t.kind >= a.typ.(*Basic).kind {
+			continue
+		}
+		if smap[tp] == nil || isUntyped(smap[tp]) {
+			smap[tp] = a.typ
+		}
+	}
+	// a constraint with a single type term, as in M ~map[K]V, infers the
+	// type parameters of the term from the type inferred for M, or M
+	// from the term if it has no tilde
+	for changed := true; changed; {
+		changed = false
+		for _, tp := range tparams {
+			terms := tp.iface().typeSet()
+			if len(terms) != 1 {
+				continue
+			}
+			core := terms[0]
+			switch t := smap[tp]; {
+			case t != nil && !isUntyped(t):
+				if core.Tilde {
+					t = under(t)
+				}
+				n := inferred(smap)
+				unify(core.Type, t, smap)
+				changed = changed || inferred(smap) > n
+			case t == nil && !core.Tilde:
+				smap[tp] = core.Type
+				changed = true
+			}
+		}
+	}
+	for _, tp := range tparams {
+		switch t := smap[tp]; {
+		case t == nil:
+			c.errorfAt(call.Rparen.Pos, "in call to %s, cannot infer %s", exprString(call.Fun), tp.Obj.Name)
+			return nil
+		case isUntyped(t):
+			smap[tp] = Default(t)
+		}
+	}
+	return smap
+}
+
+// inferred returns the number of type parameters of smap whose type is known.
+func inferred(smap substMap) int {
+	n := 0
+	for _, t := range smap {
+		if t != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// ----------------------------------------------------------------------------
+// Conversions
+
+// conversionCall checks the conversion T(x) of the call e, where x denotes
+// the type T.
+func (c *Checker) conversionCall(x *operand, e *ast.CallExpr) {
+	T := x.typ
+	switch n := len(e.Args); {
+	case n == 0:
+		c.errorfAt(e.Rparen.Pos, "missing argument in conversion to %s", T)
+		x.mode = invalid
+		return
+	case n > 1:
+		c.errorf(e.Args[n-1], "too many arguments in conversion to %s", T)
+		c.use(e.Args...)
+		x.mode = invalid
+		return
+	}
+	if e.Ellipsis.Type != "" {
+		c.errorfAt(e.Ellipsis.Pos, "invalid use of ... in conversion to %s", T)
+		c.use(e.Args...)
+		x.mode = invalid
+		return
+	}
+	c.expr(x, e.Args[0])
+	if x.mode == invalid {
+		return
+	}
+	c.conversion(x, T)
+}
+
+// conversion converts x to type T. The conversion of a constant to a basic
+// type is a constant.
+func (c *Checker) conversion(x *operand, T Type) {
+	constArg := x.mode == constant_
+	ok := false
+	cause := ""
+	switch {
+	case constArg && isConstType(T) && !isTypeParam(T):
+		// a constant conversion, which yields a constant
+		ok = c.constConvertibleTo(x, T, &x.val)
+		// an integer constant converted to an integer type can only fail
+		// to fit
+		if !ok && isInteger(x.typ) && isInteger(T) {
+			c.errorf(x.expr, "constant %s overflows %s", x.val, T)
+			x.mode = invalid
+			return
+		}
+		if !ok && isNumeric(x.typ) && isInteger(T) && constant.ToInt(x.val).Kind() != constant.Int {
+			c.errorf(x.expr, "cannot convert %s to type %s (truncated)", x, T)
+			x.mode = invalid
+			return
+		}
+	case constArg && isTypeParam(T):
+		// x must be convertible to each type of the type set; the result
+		// is not a constant
+		ok = true
+		for _, term := range T.(*TypeParam).iface().typeSet() {
+			u := under(term.Type)
+			if isString(x.typ) && isBytesOrRunes(u) || c.constConvertibleTo(x, u, nil) {
+				continue
+			}
+			ok = false
+			if isInteger(x.typ) && isInteger(u) {
+				cause = fmt.Sprintf("constant %s overflows %s (in %s)", x.val, u, T)
+			} else {
+				cause = fmt.Sprintf("cannot convert %s to type %s (in %s)", x, u, T)
+			}
+			break
+		}
+		x.mode = value
+	case c.convertibleTo(x, T):
+		ok = true
+		x.mode = value
+	}
+	if !ok {
+		if cause != "" {
+			c.errorf(x.expr, "%s", cause)
+		} else {
+			c.errorf(x.expr, "cannot convert %s to type %s", x, T)
+		}
+		x.mode = invalid
+		return
+	}
+	if isUntyped(x.typ) {
+		final := T
+		// an untyped value converted to an interface, or a constant
+		// converted to a non-constant type, takes its default type; an
+		// integer constant converted to a string keeps its type
+		switch {
+		case isInterface(T) && !isTypeParam(T) || constArg && !isConstType(T) || isTypeParam(T):
+			final = Default(x.typ)
+		case x.mode == constant_ && isInteger(x.typ) && isString(T):
+			final = x.typ
+		}
+		c.updateExprType(x.expr, final)
+	}
+	x.typ = T
+}
+
+// constConvertibleTo reports whether the constant x can be converted to the
+// constant type T, setting val, if it is not nil, to the converted value.
+// An integer converted to a string is the UTF-8 encoding of the code point.
+func (c *Checker) constConvertibleTo(x *operand, T Type, val *constant.Value) bool {
+	t, _ := under(T).(*Basic)
+	switch {
+	case t == nil:
+		return false
+	case representableConst(x.val, t, val):
+		return true
+	case isInteger(x.typ) && isString(t):
+		codepoint := unicode.ReplacementChar
+		if i, ok := constant.Uint64Val(x.val); ok && i <= unicode.MaxRune {
+			codepoint = rune(i)
+		}
+		if val != nil {
+			*val = constant.MakeString(string(codepoint))
+		}
+		return true
+	}
+	return false
+}
+
+// convertibleTo reports whether x can be converted to type T.
+func (c *Checker) convertibleTo(x *operand, T Type) bool {
+	if ok, _ := c.assignableTo(x, T); ok {
+		return true
+	}
+	V := x.typ
+	if !isValid(V) || !isValid(T) {
+		return true
+	}
+
+	// conversions to and from type parameters must be valid for each type
+	// of their type sets
+	if tp, ok := T.(*TypeParam); ok {
+		terms := tp.iface().typeSet()
+		if len(terms) == 0 {
+			return false
+		}
+		for _, term := range terms {
+			if !c.convertibleTo(x, term.Type) {
+				return false
+			}
+		}
+		return true
+	}
+	if tp, ok := V.(*TypeParam); ok {
+		terms := tp.iface().typeSet()
+		if len(terms) == 0 {
+			return false
+		}
+		for _, term := range terms {
+			y := *x
+			y.typ = term.Type
+			if !c.convertibleTo(&y, T) {
+				return false
+			}
+		}
+		return true
+	}
+
+	Vu, Tu := under(V), under(T)
+	if Identical(Vu, Tu) {
+		return true
+	}
+	// unnamed pointers to types with identical underlying types
+	if vp, ok := V.(*Pointer); ok {
+		if tp, ok := T.(*Pointer); ok && Identical(under(vp.Elem), under(tp.Elem)) {
+			return true
+		}
+	}
+	// numeric types
+	if (isInteger(Vu) || isFloat(Vu)) && (isInteger(Tu) || isFloat(Tu)) {
+		return true
+	}
+	if isComplex(Vu) && isComplex(Tu) {
+		return true
+	}
+	// integers, and byte and rune slices, to strings
+	if (isInteger(Vu) || isBytesOrRunes(Vu)) && isString(Tu) {
+		return true
+	}
+	// strings to byte and rune slices
+	if isString(Vu) && isBytesOrRunes(Tu) {
+		return true
+	}
+	// slices to arrays and array pointers
+	if s, ok := Vu.(*Slice); ok {
+		switch t := Tu.(type) {
+		case *Array:
+			return Identical(s.Elem, t.Elem)
+		case *Pointer:
+			if a, ok := under(t.Elem).(*Array); ok {
+				return Identical(s.Elem, a.Elem)
+			}
+		}
+	}
+	return false
+}
+
+func isBytesOrRunes(t Type) bool {
+	if s, ok := t.(*Slice); ok {
+		if b, ok := under(s.Elem).(*Basic); ok {
+			return b.kind == Byte || b.kind == Rune
+		}
+	}
+	return false
+}
+
+// ----------------------------------------------------------------------------
+// Summaries
+
+func operandTypes(list []*operand) []Type {
+	types := make([]Type, len(list))
+	for i, x := range list {
+		types[i] = x.typ
+	}
+	return types
+}
+
+func varTypes(list []*Var) []Type {
+	types := make([]Type, len(list))
+	for i, v := range list {
+		types[i] = v.Type
+	}
+	return types
+}
+
+// typesSummary returns a list of types as in the have and want lines of
+// gc's errors: (int, string). Untyped numeric types are summarized as
+// number.
+func typesSummary(list []Type, variadic bool) string {
+	var buf strings.Builder
+	buf.WriteString("(")
+	for i, t := range list {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		var s string
+		switch {
+		case t == nil:
+			s = "<T>"
+		case isUntyped(t):
+			if isNumeric(t) {
+				s = "number"
+			} else {
+				s = strings.TrimPrefix(t.(*Basic).name, "untyped ")
+			}
+		case variadic && i == len(list)-1:
+			if sl, ok := t.(*Slice); ok {
+				s = "..." + sl.Elem.String()
+				break
+			}
+			s = t.String()
+		default:
+			s = t.String()
+		}
+		buf.WriteString(s)
+	}
+	buf.WriteString(")")
+	return buf.String()
+}
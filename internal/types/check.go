@@ -0,0 +1,251 @@
+// Copyright 2011 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE-GO file.
+//
+// Adapted from go/types (cmd/compile/internal/types2).
+
+// Package types type-checks the syntax trees of a package. It computes the
+// type of every expression and reports operands that do not fit their
+// operators, values that are not assignable where they are used, invalid
+// conversions and calls with the wrong number of arguments. The identifiers
+// are first bound to their objects by package resolve. Constant expressions
+// are evaluated exactly, with package constant, and a constant that does not
+// fit the sized type it is given is reported. As in gc, a local variable
+// that is never used, or an import whose package is never used, is an
+// error.
+//
+// The error messages follow those of gc:
+//
+//	cannot use x (variable of type int) as string value in assignment
+//
+// Imported packages are not loaded: an identifier qualified by a package
+// name has an unknown type, which is accepted wherever a type is expected.
+package types
+
+import (
+	"fmt"
+	"sort"
+
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+	constant "github.com/mohit-bhandari45/Compiler-GO.git/internal/constant"
+	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+	resolve "github.com/mohit-bhandari45/Compiler-GO.git/internal/resolve"
+)
+
+// Info holds the results of type-checking a package, in addition to those
+// of resolving it.
+type Info struct {
+	*resolve.Info
+
+	// Types maps each expression, including type expressions, to its
+	// type. An untyped constant is recorded with the type it takes from
+	// its context, or with its default type if the context gives none.
+	Types map[ast.Expression]Type
+
+	// ObjectTypes maps the objects of the package to their types: the
+	// type of a constant or variable, the type a type name denotes, and
+	// the signature of a function.
+	ObjectTypes map[*resolve.Object]Type
+
+	// Values maps each constant expression to its value. The value of an
+	// untyped constant that is converted to a typed one is the converted
+	// value, rounded to the type.
+	Values map[ast.Expression]constant.Value
+
+	// ObjectValues maps each constant of the package to its value.
+	ObjectValues map[*resolve.Object]constant.Value
+}
+
+// TypeOf returns the type of x, or nil if it is not known.
+func (info *Info) TypeOf(x ast.Expression) Type {
+	if t, ok := info.Types[x]; ok {
+		return t
+	}
+	if id, ok := x.(*ast.Identifier); ok {
+		if obj := info.ObjectOf(id); obj != nil {
+			return info.ObjectTypes[obj]
+		}
+	}
+	return nil
+}
+
+// An Error is a type error, or an error reported by the resolve pass.
+type Error struct {
+	Pos lexer.Position // the decoded position of the offending expression
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return e.Pos.String() + ": " + e.Msg
+}
+
+// Check resolves and type-checks the files of one package. fset decodes the
+// positions of the errors; it may be nil, leaving them unknown. The errors,
+// including those of the resolve pass, are sorted by position.
+func Check(fset *lexer.FileSet, files ...*ast.File) (*Info, []*Error) {
+	rinfo, rerrs := resolve.Files(fset, files...)
+	c := newChecker(fset, rinfo, rerrs)
+	c.files(files)
+	c.unusedImports(files)
+	c.unusedVars()
+	return c.info, c.sortedErrors()
+}
+
+// CheckProgram resolves and type-checks the statements of prog as the body
+// of a function without results.
+func CheckProgram(fset *lexer.FileSet, prog *ast.Program) (*Info, []*Error) {
+	rinfo, rerrs := resolve.Program(fset, prog)
+	c := newChecker(fset, rinfo, rerrs)
+	c.sig = &Signature{}
+	c.stmtList(prog.Statements)
+	c.recordUntyped()
+	c.unusedVars()
+	return c.info, c.sortedErrors()
+}
+
+// A Checker holds the state of type-checking one package.
+type Checker struct {
+	fset   *lexer.FileSet
+	info   *Info
+	errors []*Error
+
+	// package-level declarations
+	specs   map[*ast.ValueSpec]*ast.GenDecl     // the declaration of each value spec
+	methods map[*resolve.Object][]*ast.FuncDecl // the methods of each type name, by receiver base type
+	objPath []*resolve.Object                   // objects whose declaration is being checked, in order, to detect cycles
+	untyped map[ast.Expression]*Basic           // untyped expressions whose final type is not yet known
+	funcs   []func()                            // function bodies, checked after all package-level declarations
+	delayed *[]func()                           // checks delayed until the type parameter list being declared is complete, or nil
+	used    map[*resolve.Object]bool            // the variables whose value is read
+
+	// the function being checked
+	sig  *Signature
+	iota constant.Value // the value of iota in a constant declaration, or nil
+}
+
+func newChecker(fset *lexer.FileSet, rinfo *resolve.Info, rerrs []*resolve.Error) *Checker {
+	c := &Checker{
+		fset: fset,
+		info: &Info{
+			Info:         rinfo,
+			Types:        make(map[ast.Expression]Type),
+			ObjectTypes:  make(map[*resolve.Object]Type),
+			Values:       make(map[ast.Expression]constant.Value),
+			ObjectValues: make(map[*resolve.Object]constant.Value),
+		},
+		specs:   make(map[*ast.ValueSpec]*ast.GenDecl),
+		methods: make(map[*resolve.Object][]*ast.FuncDecl),
+		untyped: make(map[ast.Expression]*Basic),
+		used:    make(map[*resolve.Object]bool),
+	}
+	for _, err := range rerrs {
+		c.errors = append(c.errors, &Error{Pos: err.Pos, Msg: err.Msg})
+	}
+	return c
+}
+
+// ----------------------------------------------------------------------------
+// Errors
+
+func (c *Checker) position(p lexer.Pos) lexer.Position {
+	if c.fset == nil {
+		return lexer.Position{}
+	}
+	return c.fset.Position(p)
+}
+
+// errorf reports an error at the start of node.
+func (c *Checker) errorf(at ast.Node, format string, args ...any) {
+	c.errors = append(c.errors, &Error{Pos: c.position(at.Pos()), Msg: fmt.Sprintf(format, args...)})
+}
+
+// errorfAt reports an error at a position.
+func (c *Checker) errorfAt(p lexer.Pos, format string, args ...any) {
+	c.errors = append(c.errors, &Error{Pos: c.position(p), Msg: fmt.Sprintf(format, args...)})
+}
+
+func (c *Checker) sortedErrors() []*Error {
+	sort.SliceStable(c.errors, func(i, j int) bool {
+		a, b := c.errors[i].Pos, c.errors[j].Pos
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		return a.Offset < b.Offset
+	})
+	return c.errors
+}
+
+// ----------------------------------------------------------------------------
+// Recording
+
+func (c *Checker) record(x *operand) {
+	if x.expr == nil || x.mode == invalid {
+		return
+	}
+	switch x.mode {
+	case novalue:
+		c.info.Types[x.expr] = &Tuple{}
+		return
+	case builtin:
+		return // a builtin has no type of its own
+	}
+	if x.mode == constant_ {
+		c.info.Values[x.expr] = x.val
+	}
+	if isUntyped(x.typ) {
+		// the final type is recorded when it is known
+		c.untyped[x.expr] = x.typ.(*Basic)
+		return
+	}
+	c.info.Types[x.expr] = x.typ
+}
+
+// updateExprType records the final type of the untyped expression x, and of
+// the untyped operands it is built from.
+func (c *Checker) updateExprType(x ast.Expression, typ Type) {
+	old, ok := c.untyped[x]
+	if !ok {
+		return
+	}
+	switch e := x.(type) {
+	case *ast.ParenExpr:
+		c.updateExprType(e.X, typ)
+	case *ast.PrefixExpression:
+		c.updateExprType(e.Right, typ)
+	case *ast.InfixExpression:
+		if !isComparison(e.Operator) {
+			c.updateExprType(e.Left, typ)
+			c.updateExprType(e.Right, typ)
+		}
+	}
+	if isUntyped(typ) && typ.(*Basic).kind >= old.kind {
+		// the untyped value took a larger untyped kind
+		c.untyped[x] = typ.(*Basic)
+		return
+	}
+	delete(c.untyped, x)
+	c.info.Types[x] = typ
+}
+
+// updateExprVal records the value of the untyped constant x, converted to
+// its final type.
+func (c *Checker) updateExprVal(x ast.Expression, val constant.Value) {
+	if _, ok := c.info.Values[x]; ok {
+		c.info.Values[x] = val
+	}
+}
+
+// recordUntyped records the untyped expressions whose final type was never
+// determined with their default type.
+func (c *Checker) recordUntyped() {
+	for x, typ := range c.untyped {
+		c.info.Types[x] = Default(typ)
+	}
+	c.untyped = make(map[ast.Expression]*Basic)
+}
+
+func (c *Checker) recordObject(obj *resolve.Object, typ Type) {
+	if obj != nil {
+		c.info.ObjectTypes[obj] = typ
+	}
+}
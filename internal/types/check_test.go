@@ -0,0 +1,95 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+	parser "github.com/mohit-bhandari45/Compiler-GO.git/internal/parser"
+)
+
+// checkSource parses and type-checks src as the file p.go, and returns its
+// errors, one message per line prefixed with its position.
+func checkSource(t *testing.T, src string) string {
+	t.Helper()
+	fset := lexer.NewFileSet()
+	f := fset.AddFile("p.go", src)
+	p := parser.New(lexer.NewInFile(f, src, 0))
+	file := p.ParseFile()
+	if errs := p.Errors(); len(errs) > 0 {
+		t.Fatalf("parse errors: %v", errs)
+	}
+	_, errs := Check(fset, file)
+	var lines []string
+	for _, err := range errs {
+		lines = append(lines, err.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// A checkTest is a source file and the errors checking it reports, as
+// returned by checkSource.
+type checkTest struct {
+	name, src, want string
+}
+
+func runCheckTests(t *testing.T, tests []checkTest) {
+	t.Helper()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := checkSource(t, tt.src); got != tt.want {
+				t.Errorf("errors:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckFuncDecls(t *testing.T) {
+	tests := []checkTest{
+		{"init", "package p\nfunc init() {}\n", ""},
+		{"init with parameter", "package p\nfunc init(x int) {}\n", "p.go:2:6: func init must have no arguments and no return values"},
+		{"redeclared func", "package p\nfunc main() {}\nfunc main() {}\n", "p.go:3:6: main redeclared in this block\n\tp.go:2:6: other declaration of main"},
+		{"func redeclares var", "package p\nvar f int\nfunc f() {}\n", "p.go:3:6: f redeclared in this block\n\tp.go:2:5: other declaration of f"},
+	}
+	runCheckTests(t, tests)
+}
+
+func TestCheckBlankDecls(t *testing.T) {
+	tests := []checkTest{
+		{"var", "package p\nvar _ = \"a\" + 1\n", "p.go:2:9: invalid operation: \"a\" + 1 (mismatched types untyped string and untyped int)"},
+		{"conversion", "package p\nvar _ = int16(70000)\n", "p.go:2:15: constant 70000 overflows int16"},
+		{"const", "package p\nconst _ = int8(300)\n", "p.go:2:16: constant 300 overflows int8"},
+		{"interface assertion", "package p\ntype I interface{ M() }\ntype T struct{}\nvar _ I = T{}\n", "p.go:4:11: cannot use T{} (value of type T) as I value in variable declaration: T does not implement I (missing method M)"},
+		{"valid interface assertion", "package p\ntype I interface{ M() }\ntype T struct{}\nfunc (T) M() {}\nvar _ I = T{}\n", ""},
+		{"assignment mismatch", "package p\nvar _, _ = 1\n", "p.go:2:12: assignment mismatch: 2 variables but 1 value"},
+	}
+	runCheckTests(t, tests)
+}
+
+func TestCheckTypeParamConstraints(t *testing.T) {
+	runCheckTests(t, []checkTest{
+		{"own constraint", "package p\nfunc F[T T](x T) {}\n", "p.go:2:10: cannot use a type parameter as constraint"},
+		{"own constraint of type", "package p\ntype A[T T] struct{}\n", "p.go:2:10: cannot use a type parameter as constraint"},
+		{"own constraint unused", "package p\nfunc F[T T]() {}\n", "p.go:2:10: cannot use a type parameter as constraint"},
+		{"other type parameter", "package p\nfunc F[P any, T P]() {}\n", "p.go:2:17: cannot use a type parameter as constraint"},
+		{"union term", "package p\nfunc F[P any, T int | P]() {}\n", "p.go:2:23: term cannot be a type parameter"},
+		{"own union term", "package p\nfunc F[T int | T]() {}\n", "p.go:2:16: term cannot be a type parameter"},
+		{"embedded", "package p\nfunc F[P any, T interface{ P }]() {}\n", "p.go:2:28: term cannot be a type parameter"},
+		{"valid", "package p\nfunc F[P any, T interface{ ~int | ~string }](x T, y P) {}\n", ""},
+	})
+}
+
+func TestCheckUnused(t *testing.T) {
+	runCheckTests(t, []checkTest{
+		{"imports", "package p\nimport \"fmt\"\nimport m \"math\"\nfunc f() {}\n", "p.go:2:8: \"fmt\" imported and not used\np.go:3:8: \"math\" imported as m and not used"},
+		{"blank and dot imports", "package p\nimport _ \"os\"\nimport . \"strings\"\nfunc f() {}\n", ""},
+		{"used import", "package p\nimport \"fmt\"\nfunc f() { fmt.Println() }\n", ""},
+		{"locals", "package p\nfunc f() {\n\tx := 1\n\tvar y, _ = 2, 3\n\t_ = 4\n}\n", "p.go:3:2: declared and not used: x\np.go:4:6: declared and not used: y"},
+		{"assigned only", "package p\nfunc f() {\n\tx := 1\n\tx = 2\n}\n", "p.go:3:2: declared and not used: x"},
+		{"incremented", "package p\nfunc f() {\n\tv := 0\n\tv++\n}\n", ""},
+		{"closures", "package p\nfunc f() {\n\tx := 1\n\tfunc() { _ = x }()\n\ty := 2\n\tfunc() { y = 3 }()\n}\n", "p.go:5:2: declared and not used: y"},
+		{"parameters, results and globals", "package p\nvar g = 1\nfunc f(a int) (r int) { return }\n", ""},
+		{"range", "package p\nfunc f() {\n\tvar x int\n\tfor x = range 3 {\n\t}\n\tfor i := range 3 {\n\t}\n}\n", "p.go:3:6: declared and not used: x\np.go:6:6: declared and not used: i"},
+		{"type switch", "package p\nfunc f(i interface{}) {\n\tswitch v := i.(type) {\n\tcase int:\n\t}\n\tswitch w := i.(type) {\n\tcase int:\n\t\t_ = w\n\tcase string:\n\t}\n}\n", "p.go:3:9: v declared and not used"},
+	})
+}
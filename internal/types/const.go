@@ -0,0 +1,199 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE-GO file.
+//
+// Adapted from go/types (cmd/compile/internal/types2).
+
+package types
+
+import (
+	"math"
+
+	constant "github.com/mohit-bhandari45/Compiler-GO.git/internal/constant"
+	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+)
+
+// Constant values are exact: untyped constants keep their full precision,
+// and are only rounded, or found not to fit, when they are converted to a
+// typed basic type. The sized types are those of a 64-bit platform.
+
+// maxUntypedBits bounds the size of untyped integer constants, which could
+// otherwise grow without limit.
+const maxUntypedBits = 512
+
+// overflow checks the result x of a constant operation whose operator is
+// at opPos: a typed constant must fit its type, and an untyped integer must
+// not grow beyond maxUntypedBits.
+func (c *Checker) overflow(x *operand, opPos lexer.Pos) {
+	if x.val.Kind() == constant.Unknown {
+		c.errorfAt(opPos, "constant result is not representable")
+		return
+	}
+	if isTyped(x.typ) {
+		c.representable(x, under(x.typ).(*Basic))
+		return
+	}
+	if x.val.Kind() == constant.Int && constant.BitLen(x.val) > maxUntypedBits {
+		c.errorfAt(opPos, "constant overflow")
+		x.val = constant.MakeUnknown()
+	}
+}
+
+// representable checks that the constant x fits the basic type typ, and
+// replaces its value with the value rounded to typ.
+func (c *Checker) representable(x *operand, typ *Basic) {
+	v, cause := representation(x, typ)
+	if v == nil {
+		c.invalidConversion(x, typ, cause)
+		x.mode = invalid
+		return
+	}
+	x.val = v
+}
+
+// representation returns the value of the constant x rounded to the basic
+// type typ, or nil if it does not fit, with the cause for a numeric
+// constant: "truncated" for a float converted to an integer type, and
+// "overflows" otherwise.
+func representation(x *operand, typ *Basic) (constant.Value, string) {
+	v := x.val
+	if representableConst(x.val, typ, &v) {
+		return v, ""
+	}
+	if isNumeric(x.typ) && isNumeric(typ) {
+		if !isInteger(x.typ) && isInteger(typ) {
+			return nil, "truncated"
+		}
+		return nil, "overflows"
+	}
+	return nil, ""
+}
+
+// invalidConversion reports the constant x, which does not fit typ for the
+// cause returned by representation.
+func (c *Checker) invalidConversion(x *operand, typ Type, cause string) {
+	switch cause {
+	case "truncated":
+		c.errorf(x.expr, "%s truncated to %s", x, typ)
+	case "overflows":
+		c.errorf(x.expr, "%s overflows %s", x, typ)
+	default:
+		c.errorf(x.expr, "cannot convert %s to type %s", x, typ)
+	}
+}
+
+// representableConst reports whether the value x fits the basic type typ.
+// If rounded is not nil, it is set to x rounded to typ: an integer for the
+// integer types, and a float rounded to the precision of the sized float
+// and complex types. Unknown values fit any type, to avoid follow-up
+// errors.
+func representableConst(x constant.Value, typ *Basic, rounded *constant.Value) bool {
+	if x.Kind() == constant.Unknown {
+		return true
+	}
+	switch {
+	case isInteger(typ):
+		x := constant.ToInt(x)
+		if x.Kind() != constant.Int {
+			return false
+		}
+		if rounded != nil {
+			*rounded = x
+		}
+		if x, ok := constant.Int64Val(x); ok {
+			switch typ.kind {
+			case Int8:
+				return math.MinInt8 <= x && x <= math.MaxInt8
+			case Int16:
+				return math.MinInt16 <= x && x <= math.MaxInt16
+			case Int32:
+				return math.MinInt32 <= x && x <= math.MaxInt32
+			case Int, Int64, UntypedInt, UntypedRune:
+				return true
+			case Uint8:
+				return 0 <= x && x <= math.MaxUint8
+			case Uint16:
+				return 0 <= x && x <= math.MaxUint16
+			case Uint32:
+				return 0 <= x && x <= math.MaxUint32
+			case Uint, Uint64, Uintptr:
+				return 0 <= x
+			}
+			return false
+		}
+		// x does not fit an int64
+		switch typ.kind {
+		case Uint, Uint64, Uintptr:
+			return constant.Sign(x) >= 0 && constant.BitLen(x) <= 64
+		case UntypedInt, UntypedRune:
+			return true
+		}
+
+	case isFloat(typ):
+		x := constant.ToFloat(x)
+		if x.Kind() != constant.Float {
+			return false
+		}
+		var r constant.Value
+		switch typ.kind {
+		case Float32:
+			r = roundFloat32(x)
+		case Float64:
+			r = roundFloat64(x)
+		case UntypedFloat:
+			r = x
+		}
+		if r != nil && rounded != nil {
+			*rounded = r
+		}
+		return r != nil
+
+	case isComplex(typ):
+		x := constant.ToComplex(x)
+		if x.Kind() != constant.Complex {
+			return false
+		}
+		round := func(x constant.Value) constant.Value { return x }
+		switch typ.kind {
+		case Complex64:
+			round = roundFloat32
+		case Complex128:
+			round = roundFloat64
+		}
+		re, im := round(constant.Real(x)), round(constant.Imag(x))
+		if re == nil || im == nil {
+			return false
+		}
+		if rounded != nil {
+			*rounded = constant.MakeComplex(re, im)
+		}
+		return true
+
+	case isString(typ):
+		return x.Kind() == constant.String
+
+	case isBoolean(typ):
+		return x.Kind() == constant.Bool
+	}
+	return false
+}
+
+// roundFloat32 returns the Float x rounded to a float32, or nil if it is
+// too large for one.
+func roundFloat32(x constant.Value) constant.Value {
+	f, _ := constant.Float32Val(x)
+	if math.IsInf(float64(f), 0) {
+		return nil
+	}
+	return constant.MakeFloat64(float64(f))
+}
+
+// roundFloat64 returns the Float x rounded to a float64, or nil if it is
+// too large for one.
+func roundFloat64(x constant.Value) constant.Value {
+	f, _ := constant.Float64Val(x)
+	if math.IsInf(f, 0) {
+		return nil
+	}
+	return constant.MakeFloat64(f)
+}
@@ -0,0 +1,774 @@
+// Copyright 2014 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE-GO file.
+//
+// Adapted from go/types (cmd/compile/internal/types2).
+
+package types
+
+import (
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+	constant "github.com/mohit-bhandari45/Compiler-GO.git/internal/constant"
+	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+	resolve "github.com/mohit-bhandari45/Compiler-GO.git/internal/resolve"
+)
+
+// files checks the files of a package. Package-level objects may be used
+// before they are declared, so each is checked when first needed; the
+// function bodies are checked last, when all of them are known.
+func (c *Checker) files(files []*ast.File) {
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					if s, ok := spec.(*ast.ValueSpec); ok {
+						c.specs[s] = d
+					}
+				}
+			case *ast.FuncDecl:
+				if obj := c.recvBaseObj(d); obj != nil && obj.Parent == c.info.Package {
+					c.methods[obj] = append(c.methods[obj], d)
+				}
+			}
+		}
+	}
+
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					switch s := spec.(type) {
+					case *ast.ValueSpec:
+						if allBlank(s.Names) {
+							// blank objects are not package-level
+							// objects, and are checked here
+							if d.Token.Type == lexer.CONST {
+								c.constSpec(s, d)
+							} else {
+								c.varSpec(s)
+							}
+							continue
+						}
+						for _, name := range s.Names {
+							c.objType(c.info.Defs[name])
+						}
+					case *ast.TypeSpec:
+						c.objType(c.info.Defs[s.Name])
+					}
+				}
+			case *ast.FuncDecl:
+				c.funcDecl(d)
+			}
+		}
+	}
+
+	for i := 0; i < len(c.funcs); i++ {
+		c.funcs[i]()
+	}
+	c.recordUntyped()
+}
+
+// allBlank reports whether all of names are the blank identifier.
+func allBlank(names []*ast.Identifier) bool {
+	for _, name := range names {
+		if name != nil && name.Value != "_" {
+			return false
+		}
+	}
+	return true
+}
+
+// recvBaseObj returns the type name of the receiver base type of a method,
+// or nil.
+func (c *Checker) recvBaseObj(d *ast.FuncDecl) *resolve.Object {
+	base, _, _ := d.RecvBase()
+	if base == nil {
+		return nil
+	}
+	if obj := c.info.Uses[base]; obj != nil && obj.Kind == resolve.Typ {
+		return obj
+	}
+	return nil
+}
+
+// objType returns the type of obj, checking the declaration of a
+// package-level object first if needed. Local objects are typed when their
+// declaration is checked, before any use.
+func (c *Checker) objType(obj *resolve.Object) Type {
+	if obj == nil {
+		return Typ[Invalid]
+	}
+	if typ, ok := c.info.ObjectTypes[obj]; ok {
+		return typ
+	}
+	if typ, ok := universeTypes[obj]; ok {
+		return typ
+	}
+	if obj.Parent != c.info.Package {
+		return Typ[Invalid]
+	}
+	for i, p := range c.objPath {
+		if p == obj {
+			// defined types may refer to themselves, and are recorded
+			// before their underlying type is checked; an alias may
+			// refer to itself through one, and values may not
+			cycle := c.objPath[i:]
+			if spec, ok := obj.Decl.(*ast.TypeSpec); ok && hasTypeDef(cycle) {
+				return c.typExpr(spec.Type)
+			}
+			c.cycleError(cycle)
+			c.recordObject(obj, Typ[Invalid])
+			return Typ[Invalid]
+		}
+	}
+	c.objPath = append(c.objPath, obj)
+	defer func() { c.objPath = c.objPath[:len(c.objPath)-1] }()
+
+	saved := c.sig
+	c.sig = nil // package-level expressions are not in a function
+	defer func() { c.sig = saved }()
+
+	switch decl := obj.Decl.(type) {
+	case *ast.ValueSpec:
+		if obj.Kind == resolve.Con {
+			c.constSpec(decl, c.specs[decl])
+		} else {
+			c.varSpec(decl)
+		}
+	case *ast.TypeSpec:
+		c.typeDecl(obj, decl)
+	case *ast.FuncDecl:
+		c.recordObject(obj, c.funcType(decl.Recv, decl.TypeParams, decl.Type))
+	}
+	if typ, ok := c.info.ObjectTypes[obj]; ok {
+		return typ
+	}
+	return Typ[Invalid]
+}
+
+// hasTypeDef reports whether one of the objects in cycle is a defined type.
+func hasTypeDef(cycle []*resolve.Object) bool {
+	for _, obj := range cycle {
+		if spec, ok := obj.Decl.(*ast.TypeSpec); ok && spec.Assign.Type == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// cycleError reports a cycle of declarations, each referring to the next
+// and the last to the first, at the first.
+func (c *Checker) cycleError(cycle []*resolve.Object) {
+	isType := false
+	for _, obj := range cycle {
+		if obj.Kind == resolve.Typ {
+			isType = true
+		}
+	}
+	first := cycle[0]
+	if len(cycle) == 1 {
+		if isType {
+			c.errorf(first.Ident, "invalid recursive type: %s refers to itself", first.Name)
+		} else {
+			c.errorf(first.Ident, "initialization cycle: %s refers to itself", first.Name)
+		}
+		return
+	}
+	msg := "initialization cycle for " + first.Name
+	if isType {
+		msg = "invalid recursive type " + first.Name
+	}
+	for i, obj := range cycle {
+		msg += "\n\t" + obj.Name + " refers to " + cycle[(i+1)%len(cycle)].Name
+	}
+	c.errorf(first.Ident, "%s", msg)
+}
+
+// ----------------------------------------------------------------------------
+// Constants and variables
+
+// constSpec checks a constant spec. A spec without values in a group repeats
+// the type and values of the last one with values, in which iota is the
+// index of the spec in the group.
+func (c *Checker) constSpec(spec *ast.ValueSpec, decl *ast.GenDecl) {
+	typExpr, values := spec.Type, spec.Values
+	iota := 0 // the index of spec in its group
+	if decl != nil {
+		for i, s := range decl.Specs {
+			s := s.(*ast.ValueSpec)
+			if s == spec {
+				iota = i
+				break
+			}
+			if spec.Type == nil && len(spec.Values) == 0 && (s.Type != nil || len(s.Values) > 0) {
+				typExpr, values = s.Type, s.Values
+			}
+		}
+	}
+
+	var typ Type
+	if typExpr != nil {
+		typ = c.typExpr(typExpr)
+		if isValid(typ) && !isConstType(typ) {
+			c.errorf(typExpr, "invalid constant type %s", typ)
+			typ = Typ[Invalid]
+		}
+	}
+
+	saved := c.iota
+	c.iota = constant.MakeInt64(int64(iota))
+	defer func() { c.iota = saved }()
+
+	for i, name := range spec.Names {
+		obj := c.info.Defs[name]
+		if i >= len(values) {
+			if i == len(values) {
+				c.errorf(name, "missing init expr for const declaration")
+			}
+			c.recordObject(obj, Typ[Invalid])
+			continue
+		}
+		var x operand
+		c.expr(&x, values[i])
+		c.constDecl(obj, &x, typ)
+	}
+	if len(values) > len(spec.Names) && spec.Values != nil {
+		c.errorf(values[len(spec.Names)], "extra init expr")
+	}
+}
+
+// constDecl gives the constant obj the value x, converted to typ if it is
+// not nil.
+func (c *Checker) constDecl(obj *resolve.Object, x *operand, typ Type) {
+	if x.mode != invalid && x.mode != constant_ {
+		c.errorf(x.expr, "%s is not constant", x)
+		x.mode = invalid
+	}
+	if x.mode != invalid && typ != nil {
+		c.assignment(x, typ, "constant declaration")
+	}
+	if x.mode == invalid {
+		c.recordObject(obj, Typ[Invalid])
+		if obj != nil {
+			c.info.ObjectValues[obj] = constant.MakeUnknown()
+		}
+		return
+	}
+	c.recordObject(obj, x.typ)
+	if obj != nil {
+		c.info.ObjectValues[obj] = x.val
+	}
+}
+
+// varSpec checks a package-level variable spec, declaring all its names.
+func (c *Checker) varSpec(spec *ast.ValueSpec) {
+	var typ Type
+	if spec.Type != nil {
+		typ = c.varType(spec.Type)
+	}
+	lhs := make([]*resolve.Object, len(spec.Names))
+	for i, name := range spec.Names {
+		lhs[i] = c.info.Defs[name]
+	}
+	c.initVars(lhs, typ, spec.Values, nil)
+}
+
+// initVars declares the variables lhs with type typ, or the types of their
+// initial values rhs if typ is nil. A return statement names the results
+// returned by a bare return; it is nil for declarations.
+func (c *Checker) initVars(lhs []*resolve.Object, typ Type, rhs []ast.Expression, returnStmt *ast.ReturnStmt) {
+	context := "variable declaration"
+	if typ != nil {
+		for _, obj := range lhs {
+			c.recordObject(obj, typ)
+		}
+	}
+	if len(rhs) == 0 {
+		if typ == nil {
+			for _, obj := range lhs {
+				c.recordObject(obj, Typ[Invalid])
+			}
+		}
+		return
+	}
+
+	xs := c.exprListN(rhs, len(lhs))
+	if len(xs) != len(lhs) {
+		for _, obj := range lhs {
+			if typ == nil {
+				c.recordObject(obj, Typ[Invalid])
+			}
+		}
+		if !invalidOperands(xs) {
+			c.assignError(rhs, len(lhs), len(xs))
+		}
+		return
+	}
+	for i, x := range xs {
+		obj := lhs[i]
+		if typ != nil {
+			c.assignment(x, typ, context)
+			continue
+		}
+		c.assignment(x, nil, context)
+		t := x.typ
+		if x.mode == invalid {
+			t = Typ[Invalid]
+		}
+		c.recordObject(obj, t)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Types
+
+// typeDecl checks the declaration of the type name obj.
+func (c *Checker) typeDecl(obj *resolve.Object, spec *ast.TypeSpec) {
+	if spec.Assign.Type != "" {
+		// an alias denotes the type itself
+		if spec.TypeParams != nil {
+			c.errorf(spec.Name, "generic type alias %s is not supported", obj.Name)
+		}
+		typ := c.typExpr(spec.Type)
+		c.recordObject(obj, typ)
+		if n, ok := typ.(*Named); ok && n.Obj.Parent == c.info.Package {
+			// methods may be declared with an alias of a local type
+			for _, d := range c.methods[obj] {
+				c.methodDecl(n, d)
+			}
+		}
+		return
+	}
+
+	named := &Named{Obj: obj}
+	named.Orig = named
+	c.recordObject(obj, named)
+	if spec.TypeParams != nil {
+		named.TypeParams = c.collectTypeParams(spec.TypeParams)
+	}
+
+	rhs := c.typExpr(spec.Type)
+	if _, ok := rhs.(*TypeParam); ok {
+		c.errorf(spec.Type, "cannot use a type parameter as RHS in type declaration")
+		rhs = Typ[Invalid]
+	}
+	named.underlying = rhs
+	named.underlying = c.resolveUnderlying(named)
+	c.validType(named)
+
+	for _, d := range c.methods[obj] {
+		c.methodDecl(named, d)
+	}
+}
+
+// resolveUnderlying follows the chain of named types a type declaration
+// refers to, as in type A B; type B struct{}, to the underlying type. A
+// chain may end in a type that is still being declared, and is then left
+// to be resolved by Underlying; a chain leading back to n is invalid.
+func (c *Checker) resolveUnderlying(n *Named) Type {
+	u := n.underlying
+	cycle := []*resolve.Object{n.Obj}
+	for {
+		m, ok := u.(*Named)
+		if !ok {
+			return u
+		}
+		if m == n {
+			c.cycleError(cycle)
+			return Typ[Invalid]
+		}
+		for _, obj := range cycle {
+			if m.Obj == obj {
+				return Typ[Invalid] // a cycle not involving n, reported for its own type
+			}
+		}
+		cycle = append(cycle, m.Obj)
+		if len(m.TypeArgs) > 0 {
+			// an instance: its underlying type is substituted
+			return m.Underlying()
+		}
+		if m.underlying == nil {
+			return u
+		}
+		u = m.underlying
+	}
+}
+
+// validType reports n if it contains itself, through fields, array
+// elements or embedded interfaces, and so would have infinite size.
+func (c *Checker) validType(n *Named) {
+	var path []*Named
+	var visit func(t Type) bool
+	visit = func(t Type) bool {
+		switch t := t.(type) {
+		case *Named:
+			if t.Orig == n {
+				cycle := []*resolve.Object{n.Obj}
+				for _, p := range path {
+					cycle = append(cycle, p.Obj)
+				}
+				c.cycleError(cycle)
+				return false
+			}
+			for _, p := range path {
+				if p == t {
+					return true // a cycle not involving n, reported for its own type
+				}
+			}
+			if t.underlying == nil {
+				return true
+			}
+			path = append(path, t)
+			ok := visit(t.Underlying())
+			path = path[:len(path)-1]
+			return ok
+		case *Array:
+			return visit(t.Elem)
+		case *Struct:
+			for _, f := range t.Fields {
+				if !visit(f.Type) {
+					return false
+				}
+			}
+		case *Interface:
+			for _, e := range t.Embeddeds {
+				if !visit(e) {
+					return false
+				}
+			}
+		case *Union:
+			for _, term := range t.Terms {
+				if !visit(term.Type) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	if !visit(n.underlying) {
+		n.underlying = Typ[Invalid]
+	}
+}
+
+// collectTypeParams declares a list of type parameters. The parameters are
+// declared before their constraints are checked, as the constraints may
+// refer to them.
+func (c *Checker) collectTypeParams(list *ast.FieldList) []*TypeParam {
+	var tparams []*TypeParam
+	for _, f := range list.List {
+		for _, name := range f.Names {
+			obj := c.info.Defs[name]
+			tp := &TypeParam{Obj: obj, Index: len(tparams), Constraint: universeAny}
+			c.recordObject(obj, tp)
+			tparams = append(tparams, tp)
+		}
+	}
+	// a constraint may refer to a type parameter declared after it, whose
+	// own constraint is not known yet
+	saved := c.delayed
+	var delayed []func()
+	c.delayed = &delayed
+	i := 0
+	for _, f := range list.List {
+		bound := c.boundType(f.Type)
+		for range f.Names {
+			tparams[i].Constraint = bound
+			i++
+		}
+	}
+	c.delayed = saved
+	for _, f := range delayed {
+		f()
+	}
+	return tparams
+}
+
+// later calls f once the type parameter list being declared, if any, is
+// complete.
+func (c *Checker) later(f func()) {
+	if c.delayed != nil {
+		*c.delayed = append(*c.delayed, f)
+		return
+	}
+	f()
+}
+
+// boundType checks the constraint of a type parameter: an interface, or a
+// type or union standing for an interface with that type set.
+func (c *Checker) boundType(e ast.Expression) Type {
+	if isUnionExpr(e) {
+		u := c.unionType(e)
+		c.info.Types[e] = u
+		return &Interface{Embeddeds: []Type{u}}
+	}
+	typ := c.typExpr(e)
+	if isTypeParam(typ) {
+		c.errorf(e, "cannot use a type parameter as constraint")
+		return Typ[Invalid]
+	}
+	if _, ok := under(typ).(*Interface); ok || !isValid(typ) {
+		return typ
+	}
+	return &Interface{Embeddeds: []Type{typ}}
+}
+
+// ----------------------------------------------------------------------------
+// Functions
+
+// funcDecl checks the signature of a function or method, and queues its body
+// to be checked once all package-level declarations are.
+func (c *Checker) funcDecl(d *ast.FuncDecl) {
+	obj := c.info.Defs[d.Name]
+	if obj == nil || d.Type == nil {
+		return
+	}
+	if base := c.recvBaseObj(d); base != nil {
+		c.objType(base) // declares the methods of a package-level type
+	}
+	var sig *Signature
+	if t, ok := c.info.ObjectTypes[obj].(*Signature); ok {
+		// a method declared with its receiver base type
+		sig = t
+	} else if d.Recv != nil {
+		sig = c.funcType(d.Recv, d.TypeParams, d.Type)
+		c.recordObject(obj, sig)
+		c.invalidRecv(d)
+	} else if t, ok := c.objType(obj).(*Signature); ok {
+		sig = t
+	} else {
+		// init, and a function whose name is declared twice, are not
+		// package-level objects
+		sig = c.funcType(nil, d.TypeParams, d.Type)
+		c.recordObject(obj, sig)
+	}
+
+	if d.Recv == nil && d.Name.Value == "init" && (d.TypeParams != nil && len(d.TypeParams.List) > 0 || sig.Params.Len() > 0 || sig.Results.Len() > 0) {
+		c.errorf(d.Name, "func init must have no arguments and no return values")
+	}
+	if d.Body == nil {
+		return
+	}
+	c.funcs = append(c.funcs, func() { c.funcBody(sig, d.Body) })
+}
+
+// invalidRecv reports the receiver of a method whose base type is not a
+// type declared in the package.
+func (c *Checker) invalidRecv(d *ast.FuncDecl) {
+	base, _, _ := d.RecvBase()
+	if base == nil {
+		c.errorf(d.Recv.List[0].Type, "invalid receiver type %s", exprString(d.Recv.List[0].Type))
+		return
+	}
+	obj := c.info.Uses[base]
+	switch {
+	case obj == nil:
+		// undefined, reported by resolve
+	case obj.Kind != resolve.Typ:
+		c.errorf(base, "%s is not a type", base.Value)
+	case obj.Parent == resolve.Universe:
+		c.errorf(base, "cannot define new methods on non-local type %s", base.Value)
+	default:
+		c.errorf(base, "invalid receiver type %s", base.Value)
+	}
+}
+
+// methodDecl checks the signature of the method d of the named type n, and
+// adds it to the methods of n.
+func (c *Checker) methodDecl(n *Named, d *ast.FuncDecl) {
+	obj := c.info.Defs[d.Name]
+	if obj == nil || d.Type == nil {
+		return
+	}
+	sig := c.funcType(d.Recv, d.TypeParams, d.Type)
+	c.recordObject(obj, sig)
+	if obj.Name == "_" {
+		return
+	}
+	if alt := findMethod(n.Methods, obj.Name); alt != nil {
+		c.errorf(d.Name, "method %s.%s already declared at %s", n.Obj.Name, obj.Name, c.position(alt.Obj.Pos()))
+		return
+	}
+	if s, ok := n.underlying.(*Struct); ok {
+		for _, f := range s.Fields {
+			if f.Name == obj.Name {
+				c.errorf(d.Name, "field and method with the same name %s", obj.Name)
+				return
+			}
+		}
+	}
+	switch under(n).(type) {
+	case *Pointer, *Interface:
+		c.errorf(d.Recv.List[0].Type, "invalid receiver type %s (pointer or interface type)", n.Obj.Name)
+	}
+	_, ptr := unparen(d.Recv.List[0].Type).(*ast.StarExpr)
+	n.Methods = append(n.Methods, &Func{Name: obj.Name, Sig: sig, PtrRecv: ptr, Obj: obj})
+}
+
+// funcType returns the signature of a function, method or function literal.
+func (c *Checker) funcType(recv *ast.FieldList, tparams *ast.FieldList, ftyp *ast.FuncType) *Signature {
+	sig := &Signature{}
+	if recv != nil && len(recv.List) > 0 {
+		sig.Recv, sig.RecvTypeParams = c.receiver(recv.List[0])
+		if len(recv.List) > 1 || len(recv.List[0].Names) > 1 {
+			c.errorf(recv.List[len(recv.List)-1], "method has multiple receivers")
+		}
+	}
+	if tparams != nil && len(tparams.List) > 0 {
+		if recv != nil {
+			c.errorf(tparams.List[0], "methods cannot have type parameters")
+		}
+		sig.TypeParams = c.collectTypeParams(tparams)
+	}
+	if ftyp == nil {
+		sig.Params, sig.Results = &Tuple{}, &Tuple{}
+		return sig
+	}
+	sig.Params, sig.Variadic = c.collectParams(ftyp.Params, true)
+	sig.Results, _ = c.collectParams(ftyp.Results, false)
+	return sig
+}
+
+// receiver checks the receiver of a method. The receiver type of a method
+// of a generic type names the type parameters, which are declared anew for
+// the method: func (l *List[T]) Len() int.
+func (c *Checker) receiver(f *ast.Field) (*Var, []*TypeParam) {
+	typ := unparen(f.Type)
+	ptr := false
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ, ptr = unparen(star.X), true
+	}
+	var params []ast.Expression
+	switch t := typ.(type) {
+	case *ast.IndexExpr:
+		typ, params = t.X, []ast.Expression{t.Index}
+	case *ast.IndexListExpr:
+		typ, params = t.X, t.Indices
+	}
+
+	var rtype Type = Typ[Invalid]
+	var tparams []*TypeParam
+	if id, ok := typ.(*ast.Identifier); ok {
+		if obj := c.info.Uses[id]; obj != nil && obj.Kind == resolve.Typ {
+			rtype = c.objType(obj)
+		}
+		c.info.Types[id] = rtype
+	}
+	if n, ok := rtype.(*Named); ok && len(n.TypeParams) > 0 {
+		if len(params) != len(n.TypeParams) {
+			c.errorf(f.Type, "receiver declares %d type parameters, but receiver base type declares %d", len(params), len(n.TypeParams))
+		}
+		targs := make([]Type, len(n.TypeParams))
+		for i := range n.TypeParams {
+			if i < len(params) {
+				if id, ok := params[i].(*ast.Identifier); ok {
+					obj := c.info.Defs[id]
+					tp := &TypeParam{Obj: obj, Index: i}
+					c.recordObject(obj, tp)
+					tparams = append(tparams, tp)
+					targs[i] = tp
+					continue
+				}
+				c.errorf(params[i], "receiver type parameter %s must be an identifier", exprString(params[i]))
+			}
+			targs[i] = Typ[Invalid]
+		}
+		// the constraints are those of the type, with the method's type
+		// parameters substituted
+		smap := makeSubstMap(n.TypeParams, targs)
+		for i, tp := range tparams {
+			tp.Constraint = subst(n.TypeParams[i].Constraint, smap)
+		}
+		rtype = instantiate(n, targs)
+	} else if len(params) > 0 && isValid(rtype) {
+		c.errorf(typ, "%s is not a generic type", exprString(typ))
+	} else if n, ok := rtype.(*Named); ok && len(n.TypeParams) > 0 {
+		c.errorf(typ, "cannot use generic type %s without instantiation", n.Obj.Name)
+	}
+	if ptr {
+		rtype = &Pointer{Elem: rtype}
+	}
+
+	v := &Var{Type: rtype}
+	if len(f.Names) > 0 {
+		obj := c.info.Defs[f.Names[0]]
+		v.Name, v.Obj = f.Names[0].Value, obj
+		c.recordObject(obj, rtype)
+	}
+	return v, tparams
+}
+
+// collectParams checks a parameter or result list. Only the last parameter
+// may be variadic; its type is then a slice.
+func (c *Checker) collectParams(list *ast.FieldList, variadicOk bool) (*Tuple, bool) {
+	t := &Tuple{}
+	if list == nil {
+		return t, false
+	}
+	variadic := false
+	for i, f := range list.List {
+		ftype := f.Type
+		if e, ok := ftype.(*ast.Ellipsis); ok {
+			ftype = e.Elt
+			if variadicOk && i == len(list.List)-1 && len(f.Names) <= 1 {
+				variadic = true
+			} else {
+				c.errorf(e, "can only use ... with final parameter in list")
+			}
+		}
+		typ := c.varType(ftype)
+		if variadic && i == len(list.List)-1 {
+			typ = &Slice{Elem: typ}
+			c.info.Types[f.Type] = typ
+		}
+		if len(f.Names) == 0 {
+			t.Vars = append(t.Vars, &Var{Type: typ})
+			continue
+		}
+		for _, name := range f.Names {
+			obj := c.info.Defs[name]
+			c.recordObject(obj, typ)
+			t.Vars = append(t.Vars, &Var{Name: name.Value, Type: typ, Obj: obj})
+		}
+	}
+	return t, variadic
+}
+
+// funcBody checks the body of a function with the given signature.
+func (c *Checker) funcBody(sig *Signature, body *ast.BlockStatement) {
+	saved, savedIota := c.sig, c.iota
+	c.sig, c.iota = sig, nil
+	defer func() { c.sig, c.iota = saved, savedIota }()
+
+	c.stmtList(body.Statements)
+	if sig.Results.Len() > 0 && !c.isTerminatingList(body.Statements, "") {
+		c.errorfAt(body.Rbrace.Pos, "missing return")
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Declarations in functions
+
+func (c *Checker) declStmt(d *ast.GenDecl) {
+	for _, spec := range d.Specs {
+		switch s := spec.(type) {
+		case *ast.ValueSpec:
+			if d.Token.Type == lexer.CONST {
+				c.constSpec(s, d)
+				continue
+			}
+			var typ Type
+			if s.Type != nil {
+				typ = c.varType(s.Type)
+			}
+			lhs := make([]*resolve.Object, len(s.Names))
+			for i, name := range s.Names {
+				lhs[i] = c.info.Defs[name]
+			}
+			c.initVars(lhs, typ, s.Values, nil)
+		case *ast.TypeSpec:
+			c.typeDecl(c.info.Defs[s.Name], s)
+		}
+	}
+}
@@ -0,0 +1,1223 @@
+// Copyright 2012 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE-GO file.
+//
+// Adapted from go/types (cmd/compile/internal/types2).
+
+package types
+
+import (
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+	constant "github.com/mohit-bhandari45/Compiler-GO.git/internal/constant"
+	resolve "github.com/mohit-bhandari45/Compiler-GO.git/internal/resolve"
+)
+
+// Expressions are checked into operands. rawExpr checks any expression; the
+// other entry points restrict what the expression may denote:
+//
+//	expr          a single value
+//	exprOrType    a single value or a type
+//	genericExpr   a single value or a type, which may be generic
+//	exprWithHint  a single value; an untyped composite literal takes the hint
+
+// An exprKind describes the kind of an expression: whether it may be used as
+// a statement.
+type exprKind int
+
+const (
+	conversion exprKind = iota
+	expression
+	statement
+)
+
+// expr checks e, which must denote a single value.
+func (c *Checker) expr(x *operand, e ast.Expression) {
+	c.rawExpr(x, e, nil)
+	c.exclude(x, 1<<novalue|1<<builtin|1<<typexpr)
+	c.singleValue(x)
+	c.nonGeneric(x)
+}
+
+// exprOrType checks e, which must denote a single value or a type.
+func (c *Checker) exprOrType(x *operand, e ast.Expression) {
+	c.rawExpr(x, e, nil)
+	c.exclude(x, 1<<novalue)
+	c.singleValue(x)
+	c.nonGeneric(x)
+}
+
+// genericExpr is like exprOrType, but permits generic functions and types,
+// which are about to be instantiated or called.
+func (c *Checker) genericExpr(x *operand, e ast.Expression) {
+	c.rawExpr(x, e, nil)
+	c.exclude(x, 1<<novalue)
+	c.singleValue(x)
+}
+
+// exprWithHint is like expr, but a composite literal without a type, as an
+// element of another, has the type hint.
+func (c *Checker) exprWithHint(x *operand, e ast.Expression, hint Type) {
+	c.rawExpr(x, e, hint)
+	c.exclude(x, 1<<novalue|1<<builtin|1<<typexpr)
+	c.singleValue(x)
+	c.nonGeneric(x)
+}
+
+// use checks expressions whose values are not needed, because an error has
+// been reported for their context, to report the errors they contain.
+func (c *Checker) use(list ...ast.Expression) {
+	for _, e := range list {
+		if e == nil {
+			continue
+		}
+		var x operand
+		c.rawExpr(&x, e, nil)
+	}
+}
+
+// rawExpr checks e and records its type. hint is the type of an untyped
+// composite literal, or nil.
+func (c *Checker) rawExpr(x *operand, e ast.Expression, hint Type) exprKind {
+	x.mode, x.typ, x.expr = invalid, Typ[Invalid], e
+	kind := c.exprInternal(x, e, hint)
+	x.expr = e
+	c.record(x)
+	return kind
+}
+
+// exclude reports x if its mode is one of the set modes.
+func (c *Checker) exclude(x *operand, modeset uint) {
+	if modeset&(1<<x.mode) == 0 {
+		return
+	}
+	var msg string
+	switch x.mode {
+	case novalue:
+		msg = "%s used as value"
+	case builtin:
+		msg = "%s must be called"
+	case typexpr:
+		msg = "%s is not an expression"
+	default:
+		return
+	}
+	c.errorf(x.expr, msg, x)
+	x.mode = invalid
+}
+
+// singleValue reports x if it is the value of a call with several results.
+// A comma-ok expression used as a single value is an ordinary value.
+func (c *Checker) singleValue(x *operand) {
+	switch x.mode {
+	case value:
+		if _, ok := x.typ.(*Tuple); ok {
+			c.errorf(x.expr, "multiple-value %s in single-value context", x)
+			x.mode = invalid
+		}
+	case commaok:
+		x.mode = value
+	}
+}
+
+// nonGeneric reports x if it denotes a generic function or type that is not
+// instantiated.
+func (c *Checker) nonGeneric(x *operand) {
+	switch t := x.typ.(type) {
+	case *Signature:
+		if x.mode == value && len(t.TypeParams) > 0 {
+			c.errorf(x.expr, "cannot use generic function %s without instantiation", exprString(x.expr))
+			x.mode = invalid
+		}
+	case *Named:
+		if x.mode == typexpr && len(t.TypeParams) > 0 && len(t.TypeArgs) == 0 {
+			c.errorf(x.expr, "cannot use generic type %s without instantiation", t.Obj.Name)
+			x.mode = invalid
+		}
+	}
+}
+
+func (c *Checker) exprInternal(x *operand, e ast.Expression, hint Type) exprKind {
+	switch e := e.(type) {
+	case nil:
+		// an error has been reported by the parser
+
+	case *ast.Identifier:
+		c.ident(x, e)
+
+	case *ast.IntegerLiteral:
+		// a malformed literal has been reported by the parser
+		if e.Int != nil {
+			x.mode, x.typ, x.val = constant_, Typ[UntypedInt], constant.MakeInt(e.Int)
+		}
+
+	case *ast.FloatLiteral:
+		if e.Rat != nil {
+			x.mode, x.typ, x.val = constant_, Typ[UntypedFloat], constant.MakeRat(e.Rat)
+		}
+
+	case *ast.StringLiteral:
+		x.mode, x.typ, x.val = constant_, Typ[UntypedString], constant.MakeString(e.Value)
+
+	case *ast.RuneLiteral:
+		x.mode, x.typ, x.val = constant_, Typ[UntypedRune], constant.MakeInt64(int64(e.Value))
+
+	case *ast.FuncLit:
+		if e.Type == nil {
+			break
+		}
+		sig := c.funcType(nil, nil, e.Type)
+		if e.Body != nil {
+			c.funcBody(sig, e.Body)
+		}
+		x.mode, x.typ = value, sig
+
+	case *ast.CompositeLit:
+		c.compositeLit(x, e, hint)
+
+	case *ast.ParenExpr:
+		return c.rawExpr(x, e.X, hint)
+
+	case *ast.SelectorExpr:
+		c.selector(x, e)
+
+	case *ast.IndexExpr:
+		c.indexExpr(x, e)
+
+	case *ast.IndexListExpr:
+		c.genericExpr(x, e.X)
+		c.instantiate(x, e, e.Indices)
+
+	case *ast.SliceExpr:
+		c.sliceExpr(x, e)
+
+	case *ast.TypeAssertExpr:
+		c.typeAssertExpr(x, e)
+
+	case *ast.CallExpr:
+		return c.callExpr(x, e)
+
+	case *ast.StarExpr:
+		c.exprOrType(x, e.X)
+		switch x.mode {
+		case invalid:
+			break
+		case typexpr:
+			x.typ = &Pointer{Elem: x.typ}
+		default:
+			if x.isNil() {
+				c.errorf(e, "invalid operation: cannot indirect nil")
+				x.mode = invalid
+				break
+			}
+			p, ok := coreType(x.typ).(*Pointer)
+			if !ok {
+				c.errorf(e, "invalid operation: cannot indirect %s", x)
+				x.mode = invalid
+				break
+			}
+			x.mode, x.typ = variable, p.Elem
+		}
+
+	case *ast.PrefixExpression:
+		c.unary(x, e)
+		if e.Operator == "<-" {
+			return statement
+		}
+
+	case *ast.InfixExpression:
+		c.binary(x, e)
+
+	case *ast.KeyValueExpr:
+		c.errorf(e, "unexpected key:value expression")
+		c.use(e.Key, e.Value)
+
+	case *ast.ArrayType, *ast.MapType, *ast.ChanType, *ast.FuncType, *ast.StructType, *ast.InterfaceType:
+		x.mode, x.typ = typexpr, c.typExpr(e)
+
+	case *ast.Ellipsis:
+		c.errorf(e, "invalid use of ...")
+		c.use(e.Elt)
+
+	default:
+		c.errorf(e, "%s is not an expression", exprString(e))
+	}
+	return expression
+}
+
+// ident checks an identifier, which denotes the object resolve bound it to.
+func (c *Checker) ident(x *operand, e *ast.Identifier) {
+	if e.Value == "_" {
+		c.errorf(e, "cannot use _ as value")
+		return
+	}
+	obj := c.info.ObjectOf(e)
+	if obj == nil {
+		return // undefined, reported by resolve
+	}
+	switch obj.Kind {
+	case resolve.Pkg:
+		c.errorf(e, "use of package %s without selector", obj.Name)
+		return
+	case resolve.Con:
+		if obj.Parent == resolve.Universe {
+			if obj.Name == "iota" {
+				if c.iota == nil {
+					c.errorf(e, "cannot use iota outside constant declaration")
+					return
+				}
+				x.mode, x.typ, x.val = constant_, Typ[UntypedInt], c.iota
+				return
+			}
+			x.mode, x.typ, x.val = constant_, Typ[UntypedBool], constant.MakeBool(obj.Name == "true")
+			return
+		}
+		x.typ = c.objType(obj)
+		if !isValid(x.typ) {
+			x.mode = invalid
+			return
+		}
+		x.mode, x.val = constant_, c.info.ObjectValues[obj]
+		if x.val == nil {
+			x.val = constant.MakeUnknown()
+		}
+		return
+	case resolve.Typ:
+		x.mode = typexpr
+	case resolve.Var:
+		x.mode = variable
+		c.used[obj] = true
+	case resolve.Fun:
+		x.mode = value
+	case resolve.Builtin:
+		x.mode, x.typ, x.id = builtin, Typ[Invalid], builtinIds[obj.Name]
+		return
+	case resolve.Nil:
+		x.mode, x.typ = value, Typ[UntypedNil]
+		return
+	default:
+		return
+	}
+	x.typ = c.objType(obj)
+	if !isValid(x.typ) {
+		x.mode = invalid
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Operators
+
+// unary checks a prefix operation: !x, -x, &x or <-x.
+func (c *Checker) unary(x *operand, e *ast.PrefixExpression) {
+	if e.Operator == "~" {
+		c.errorf(e, "cannot use ~ outside of interface or type constraint")
+		c.use(e.Right)
+		return
+	}
+	c.expr(x, e.Right)
+	if x.mode == invalid {
+		return
+	}
+	switch e.Operator {
+	case "&":
+		if _, ok := unparen(e.Right).(*ast.CompositeLit); !ok && !x.addressable() {
+			c.errorf(e, "invalid operation: cannot take address of %s", x)
+			x.mode = invalid
+			return
+		}
+		x.mode, x.typ = value, &Pointer{Elem: x.typ}
+		return
+
+	case "<-":
+		ch, ok := coreType(x.typ).(*Chan)
+		switch {
+		case !ok:
+			c.errorf(e, "invalid operation: cannot receive from non-channel %s", x)
+		case ch.Dir == ast.SEND:
+			c.errorf(e, "invalid operation: cannot receive from send-only channel %s", x)
+		default:
+			x.mode, x.typ = commaok, ch.Elem
+			return
+		}
+		x.mode = invalid
+		return
+
+	case "!":
+		if !isBoolean(x.typ) {
+			c.errorf(e, "invalid operation: operator ! not defined on %s", x)
+			x.mode = invalid
+			return
+		}
+
+	case "-":
+		if !isNumeric(x.typ) {
+			c.errorf(e, "invalid operation: operator - not defined on %s", x)
+			x.mode = invalid
+			return
+		}
+	}
+	if x.mode == constant_ {
+		if x.val.Kind() == constant.Unknown {
+			return // an error has been reported
+		}
+		x.val = constant.UnaryOp(e.Operator, x.val)
+		x.expr = e
+		c.overflow(x, e.Token.Pos)
+		return
+	}
+	x.mode = value
+}
+
+// isComparison reports whether op is a comparison operator.
+func isComparison(op string) bool {
+	switch op {
+	case "==", "!=", "<", "<=", ">", ">=":
+		return true
+	}
+	return false
+}
+
+// binary checks an infix operation.
+func (c *Checker) binary(x *operand, e *ast.InfixExpression) {
+	var y operand
+	c.expr(x, e.Left)
+	c.expr(&y, e.Right)
+	if x.mode == invalid {
+		return
+	}
+	if y.mode == invalid {
+		x.mode = invalid
+		return
+	}
+
+	c.matchTypes(x, &y)
+	if x.mode == invalid {
+		return
+	}
+
+	if isComparison(e.Operator) {
+		c.comparison(x, &y, e)
+		return
+	}
+
+	if !Identical(x.typ, y.typ) {
+		// the types may be identical but invalid
+		if isValid(x.typ) && isValid(y.typ) {
+			c.errorf(e, "invalid operation: %s (mismatched types %s and %s)", exprString(e), x.typ, y.typ)
+		}
+		x.mode = invalid
+		return
+	}
+
+	if !c.opAllowed(x, e.Operator, e) {
+		x.mode = invalid
+		return
+	}
+
+	if e.Operator == "/" && (x.mode == constant_ || allBasic(x.typ, IsInteger)) && y.mode == constant_ && constant.Sign(y.val) == 0 {
+		c.errorf(y.expr, "invalid operation: division by zero")
+		x.mode = invalid
+		return
+	}
+
+	if x.mode == constant_ && y.mode == constant_ {
+		if x.val.Kind() == constant.Unknown || y.val.Kind() == constant.Unknown {
+			x.val = constant.MakeUnknown() // an error has been reported
+			return
+		}
+		x.val = constant.BinaryOp(x.val, e.Operator, y.val)
+		x.expr = e
+		c.overflow(x, e.Token.Pos)
+		return
+	}
+	x.mode = value
+}
+
+// opAllowed reports whether the arithmetic operator op is defined on the
+// type of x, reporting it if not.
+func (c *Checker) opAllowed(x *operand, op string, e ast.Expression) bool {
+	var ok bool
+	switch op {
+	case "+":
+		ok = allBasic(x.typ, IsNumeric|IsString)
+	case "-", "*", "/":
+		ok = isNumeric(x.typ)
+	case "&", "|":
+		ok = isInteger(x.typ)
+	default:
+		c.errorf(e, "invalid operation: unknown operator %s", op)
+		return false
+	}
+	if !ok {
+		c.errorf(e, "invalid operation: operator %s not defined on %s", op, x)
+	}
+	return ok
+}
+
+// matchTypes converts the untyped one of the operands x and y of a binary
+// operation to the type of the other, if that is possible. x is invalid if
+// it is not.
+func (c *Checker) matchTypes(x, y *operand) {
+	mayConvert := func(x, y *operand) bool {
+		if isTyped(x.typ) && isTyped(y.typ) {
+			return false
+		}
+		// an untyped operand cannot mix with a typed operand of a
+		// different kind
+		if allBasic(x.typ, IsBoolean) != allBasic(y.typ, IsBoolean) {
+			return false
+		}
+		if allBasic(x.typ, IsString) != allBasic(y.typ, IsString) {
+			return false
+		}
+		if x.isNil() {
+			return hasNil(y.typ)
+		}
+		if y.isNil() {
+			return hasNil(x.typ)
+		}
+		if _, ok := under(x.typ).(*Pointer); ok {
+			return false
+		}
+		if _, ok := under(y.typ).(*Pointer); ok {
+			return false
+		}
+		return true
+	}
+	if !mayConvert(x, y) {
+		return
+	}
+	c.convertUntyped(x, y.typ)
+	if x.mode == invalid {
+		return
+	}
+	c.convertUntyped(y, x.typ)
+	if y.mode == invalid {
+		x.mode = invalid
+	}
+}
+
+// comparison checks a comparison; its result is an untyped boolean.
+func (c *Checker) comparison(x, y *operand, e *ast.InfixExpression) {
+	op := e.Operator
+	errOp := x
+	cause := ""
+
+	ok, _ := c.assignableTo(x, y.typ)
+	if !ok {
+		ok, _ = c.assignableTo(y, x.typ)
+	}
+	if !ok {
+		errOp = y
+		cause = "mismatched types " + x.typ.String() + " and " + y.typ.String()
+		goto Error
+	}
+
+	switch op {
+	case "==", "!=":
+		switch {
+		case x.isNil() || y.isNil():
+			typ := x.typ
+			if x.isNil() {
+				typ = y.typ
+			}
+			if !hasNil(typ) || typ == Typ[UntypedNil] {
+				errOp = y
+				goto Error
+			}
+		case !comparable(x.typ):
+			errOp = x
+			cause = incomparableCause(x.typ)
+			goto Error
+		case !comparable(y.typ):
+			errOp = y
+			cause = incomparableCause(y.typ)
+			goto Error
+		}
+	default:
+		switch {
+		case !isOrdered(x.typ):
+			errOp = x
+			goto Error
+		case !isOrdered(y.typ):
+			errOp = y
+			goto Error
+		}
+	}
+
+	if x.mode == constant_ && y.mode == constant_ {
+		x.val = constant.MakeBool(constant.Compare(x.val, op, y.val))
+	} else {
+		x.mode = value
+		// the operands are materialized with their default types
+		c.updateExprType(x.expr, Default(x.typ))
+		c.updateExprType(y.expr, Default(y.typ))
+	}
+	x.typ = Typ[UntypedBool]
+	return
+
+Error:
+	if cause == "" {
+		if isTypeParam(x.typ) || isTypeParam(y.typ) {
+			if !isTypeParam(x.typ) {
+				errOp = y
+			}
+			cause = "type parameter " + errOp.typ.String() + " cannot use operator " + op
+		} else {
+			what := compositeKind(errOp.typ)
+			if what == "" {
+				what = errOp.typ.String()
+				if errOp.isNil() {
+					what = "nil"
+				}
+			}
+			cause = "operator " + op + " not defined on " + what
+		}
+	}
+	c.errorf(e, "invalid operation: %s (%s)", exprString(e), cause)
+	x.mode = invalid
+}
+
+// incomparableCause returns why values of type t cannot be compared.
+func incomparableCause(t Type) string {
+	switch u := under(t).(type) {
+	case *Slice, *Signature, *Map:
+		return compositeKind(t) + " can only be compared to nil"
+	case *Struct:
+		for _, f := range u.Fields {
+			if !comparable(f.Type) {
+				return "struct containing " + f.Type.String() + " cannot be compared"
+			}
+		}
+	case *Array:
+		return t.String() + " cannot be compared"
+	}
+	return "operator == not defined on " + t.String()
+}
+
+// compositeKind returns the kind of a composite type, for error messages,
+// or "" for other types.
+func compositeKind(t Type) string {
+	switch under(t).(type) {
+	case *Array:
+		return "array"
+	case *Slice:
+		return "slice"
+	case *Struct:
+		return "struct"
+	case *Pointer:
+		return "pointer"
+	case *Signature:
+		return "func"
+	case *Interface:
+		if !isTypeParam(t) {
+			return "interface"
+		}
+	case *Map:
+		return "map"
+	case *Chan:
+		return "chan"
+	case *Tuple:
+		return "tuple"
+	case *Union:
+		return "union"
+	}
+	return ""
+}
+
+// ----------------------------------------------------------------------------
+// Untyped operands
+
+// convertUntyped converts the untyped operand x to the type target, or to
+// the larger of two untyped numeric types, recording its final type and
+// value. x is invalid, and the error reported, if it cannot be converted.
+func (c *Checker) convertUntyped(x *operand, target Type) {
+	if x.mode == invalid || isTyped(x.typ) || !isValid(target) {
+		return
+	}
+	if isUntyped(target) {
+		if isNumeric(x.typ) && isNumeric(target) {
+			if t := target.(*Basic); x.typ.(*Basic).kind < t.kind {
+				if x.mode == constant_ {
+					representableConst(x.val, t, &x.val)
+				}
+				x.typ = target
+				c.updateExprType(x.expr, target)
+			}
+			return
+		}
+		if x.typ != target {
+			c.invalidConversion(x, target, "")
+			x.mode = invalid
+		}
+		return
+	}
+	typ, val, cause := c.implicitType(x, target)
+	if typ == nil {
+		t := target
+		if !isTypeParam(target) {
+			t = under(target)
+		}
+		c.invalidConversion(x, t, cause)
+		x.mode = invalid
+		return
+	}
+	if val != nil {
+		x.val = val
+		c.updateExprVal(x.expr, val)
+	}
+	if typ != x.typ {
+		x.typ = typ
+		c.updateExprType(x.expr, typ)
+	}
+}
+
+// implicitType returns the type the untyped operand x takes in a context of
+// the typed type target, and the value of a constant x converted to it, or
+// a nil type if x cannot take it. An untyped value in an interface context
+// takes its default type. A numeric constant that does not fit target gives
+// the cause returned by representation.
+func (c *Checker) implicitType(x *operand, target Type) (Type, constant.Value, string) {
+	if x.isNil() {
+		if hasNil(target) {
+			return target, nil, ""
+		}
+		return nil, nil, ""
+	}
+	if tp, ok := target.(*TypeParam); ok {
+		// x must fit each type of the type set
+		terms := tp.iface().typeSet()
+		if len(terms) == 0 {
+			return nil, nil, ""
+		}
+		for _, term := range terms {
+			if t, _, cause := c.implicitType(x, term.Type); t == nil {
+				return nil, nil, cause
+			}
+		}
+		// a type parameter is not a constant type: x is materialized
+		return target, nil, ""
+	}
+	switch u := under(target).(type) {
+	case *Basic:
+		if u.kind == Invalid {
+			return target, nil, ""
+		}
+		if x.mode == constant_ {
+			v, cause := representation(x, u)
+			if v == nil {
+				return nil, nil, cause
+			}
+			return target, v, ""
+		}
+		// a non-constant untyped value is a boolean, from a comparison
+		if !isBoolean(u) || !isBoolean(x.typ) {
+			return nil, nil, ""
+		}
+	case *Interface:
+		// values in interfaces have concrete dynamic types
+		if len(u.methodSet()) > 0 || u.isConstraint() {
+			return nil, nil, ""
+		}
+		return Default(x.typ), nil, ""
+	default:
+		return nil, nil, ""
+	}
+	return target, nil, ""
+}
+
+// ----------------------------------------------------------------------------
+// Selectors, index and slice expressions
+
+// selector checks a selector expression: a field or method of a value, a
+// method expression T.m, or a qualified identifier pkg.Name. Imported
+// packages are not loaded, so a qualified identifier is accepted silently
+// with an unknown type.
+func (c *Checker) selector(x *operand, e *ast.SelectorExpr) {
+	if id, ok := e.X.(*ast.Identifier); ok {
+		if obj := c.info.Uses[id]; obj != nil && obj.Kind == resolve.Pkg {
+			return
+		}
+	}
+	c.exprOrType(x, e.X)
+	if x.mode == invalid || e.Sel == nil {
+		x.mode = invalid
+		return
+	}
+	name := e.Sel.Value
+
+	if x.mode == typexpr {
+		c.methodExpr(x, e)
+		return
+	}
+
+	sel, ambiguous, found := lookupFieldOrMethod(x.typ, name)
+	if !found {
+		switch {
+		case ambiguous:
+			c.errorf(e.Sel, "ambiguous selector %s", exprString(e))
+		case isPointerToInterface(x.typ):
+			c.errorf(e.Sel, "%s undefined (type %s is pointer to interface, not interface)", exprString(e), x.typ)
+		default:
+			what := "field or method"
+			if isInterface(x.typ) || isTypeParam(x.typ) {
+				what = "method"
+			}
+			c.errorf(e.Sel, "%s undefined (type %s has no %s %s)", exprString(e), x.typ, what, name)
+		}
+		x.mode = invalid
+		return
+	}
+
+	if sel.field != nil {
+		if x.mode != variable && !sel.indirect {
+			x.mode = value
+		} else {
+			x.mode = variable
+		}
+		x.typ = sel.field.Type
+		return
+	}
+
+	m := sel.method
+	if m.PtrRecv && !sel.indirect && !isPointer(x.typ) && !x.addressable() {
+		c.errorf(e, "cannot call pointer method %s on %s", name, x.typ)
+		x.mode = invalid
+		return
+	}
+	x.mode, x.typ = value, m.Sig
+}
+
+// methodExpr checks the method expression T.m, a function whose first
+// parameter is the receiver.
+func (c *Checker) methodExpr(x *operand, e *ast.SelectorExpr) {
+	name := e.Sel.Value
+	sel, _, found := lookupFieldOrMethod(x.typ, name)
+	if !found || sel.method == nil {
+		c.errorf(e.Sel, "%s undefined (type %s has no method %s)", exprString(e), x.typ, name)
+		x.mode = invalid
+		return
+	}
+	m := sel.method
+	if m.PtrRecv && !sel.indirect && !isPointer(x.typ) {
+		c.errorf(e, "invalid method expression %s (needs pointer receiver (*%s).%s)", exprString(e), x.typ, name)
+		x.mode = invalid
+		return
+	}
+	params := append([]*Var{{Type: x.typ}}, m.Sig.Params.vars()...)
+	x.mode = value
+	x.typ = &Signature{Params: &Tuple{Vars: params}, Results: m.Sig.Results, Variadic: m.Sig.Variadic}
+}
+
+func isPointer(t Type) bool {
+	_, ok := under(t).(*Pointer)
+	return ok
+}
+
+func isPointerToInterface(t Type) bool {
+	p, ok := under(t).(*Pointer)
+	return ok && isInterface(p.Elem)
+}
+
+// indexExpr checks an index expression: an index or map lookup, or the
+// instantiation of a generic function or type with one type argument.
+func (c *Checker) indexExpr(x *operand, e *ast.IndexExpr) {
+	c.genericExpr(x, e.X)
+	c.indexOperand(x, e)
+}
+
+// indexOperand checks the index expression e of the operand x, which
+// denotes e.X.
+func (c *Checker) indexOperand(x *operand, e *ast.IndexExpr) {
+	if x.mode == invalid {
+		c.use(e.Index)
+		return
+	}
+	if x.mode == typexpr {
+		c.instantiate(x, e, []ast.Expression{e.Index})
+		return
+	}
+	if sig, ok := x.typ.(*Signature); ok && len(sig.TypeParams) > 0 {
+		c.instantiate(x, e, []ast.Expression{e.Index})
+		return
+	}
+	c.nonGeneric(x)
+
+	length := int64(-1)
+	valid := false
+	switch t := coreType(x.typ).(type) {
+	case *Basic:
+		if isString(t) {
+			valid = true
+			x.mode, x.typ = value, universeByte
+		}
+	case *Array:
+		valid, length = true, t.Len
+		if x.mode != variable {
+			x.mode = value
+		}
+		x.typ = t.Elem
+	case *Pointer:
+		if a, ok := under(t.Elem).(*Array); ok {
+			valid, length = true, a.Len
+			x.mode, x.typ = variable, a.Elem
+		}
+	case *Slice:
+		valid = true
+		x.mode, x.typ = variable, t.Elem
+	case *Map:
+		var key operand
+		c.expr(&key, e.Index)
+		c.assignment(&key, t.Key, "map index")
+		x.mode, x.typ = mapindex, t.Elem
+		return
+	}
+	if !valid {
+		c.errorf(e, "invalid operation: cannot index %s", x)
+		c.use(e.Index)
+		x.mode = invalid
+		return
+	}
+	c.index(e.Index, length)
+}
+
+// index checks an index of an array of the given length (-1 if unknown), a
+// slice or a string, and returns its value if it is a constant.
+func (c *Checker) index(e ast.Expression, length int64) (int64, bool) {
+	var x operand
+	c.expr(&x, e)
+	if x.mode == invalid {
+		return 0, false
+	}
+	c.convertUntyped(&x, Typ[Int])
+	if x.mode == invalid {
+		return 0, false
+	}
+	if !isInteger(x.typ) {
+		c.errorf(e, "invalid argument: index %s must be integer", &x)
+		return 0, false
+	}
+	if x.mode != constant_ || x.val.Kind() == constant.Unknown {
+		return 0, false
+	}
+	if constant.Sign(x.val) < 0 {
+		c.errorf(e, "invalid argument: index %s must not be negative", &x)
+		return 0, false
+	}
+	if !representableConst(x.val, Typ[Int], &x.val) {
+		c.errorf(e, "invalid argument: index %s overflows int", &x)
+		return 0, false
+	}
+	v, _ := constant.Int64Val(x.val)
+	if length >= 0 && v >= length {
+		c.errorf(e, "invalid argument: index %s out of bounds [0:%d]", x.val, length)
+		return 0, false
+	}
+	return v, true
+}
+
+// sliceExpr checks a slice expression a[lo:hi] or a[lo:hi:max].
+func (c *Checker) sliceExpr(x *operand, e *ast.SliceExpr) {
+	c.expr(x, e.X)
+	if x.mode == invalid {
+		c.use(e.Low, e.High, e.Max)
+		return
+	}
+	valid := false
+	length := int64(-1)
+	switch t := coreType(x.typ).(type) {
+	case *Basic:
+		if isString(t) {
+			if e.Slice3 {
+				c.errorf(e, "invalid operation: 3-index slice of string")
+				x.mode = invalid
+				return
+			}
+			valid = true
+			if isUntyped(x.typ) {
+				x.typ = Typ[String]
+			}
+		}
+	case *Array:
+		valid, length = true, t.Len
+		if x.mode != variable {
+			c.errorf(e, "invalid operation: %s (slice of unaddressable value)", exprString(e))
+			x.mode = invalid
+			return
+		}
+		x.typ = &Slice{Elem: t.Elem}
+	case *Pointer:
+		if a, ok := under(t.Elem).(*Array); ok {
+			valid, length = true, a.Len
+			x.typ = &Slice{Elem: a.Elem}
+		}
+	case *Slice:
+		valid = true
+	}
+	if !valid {
+		c.errorf(e, "cannot slice %s", x)
+		c.use(e.Low, e.High, e.Max)
+		x.mode = invalid
+		return
+	}
+	x.mode = value
+
+	// the constant indices must be in order
+	var last int64 = -1
+	for _, i := range []ast.Expression{e.Low, e.High, e.Max} {
+		if i == nil {
+			continue
+		}
+		max := length
+		if max >= 0 {
+			max++ // a[:len(a)] is valid
+		}
+		v, ok := c.index(i, max)
+		if !ok {
+			continue
+		}
+		if v < last {
+			c.errorf(i, "invalid slice indices: %d < %d", v, last)
+		}
+		last = v
+	}
+}
+
+// typeAssertExpr checks the type assertion x.(T).
+func (c *Checker) typeAssertExpr(x *operand, e *ast.TypeAssertExpr) {
+	c.expr(x, e.X)
+	if e.Type == nil {
+		c.errorf(e, "invalid syntax tree: use of .(type) outside type switch")
+		x.mode = invalid
+		return
+	}
+	if x.mode == invalid {
+		c.use(e.Type)
+		return
+	}
+	if isTypeParam(x.typ) {
+		c.errorf(e.X, "invalid operation: cannot use type assertion on type parameter value %s", x)
+		c.use(e.Type)
+		x.mode = invalid
+		return
+	}
+	if !isInterface(x.typ) {
+		c.errorf(e.X, "invalid operation: %s is not an interface", x)
+		c.use(e.Type)
+		x.mode = invalid
+		return
+	}
+	T := c.varType(e.Type)
+	if !isValid(T) {
+		x.mode = invalid
+		return
+	}
+	c.typeAssertion(e, x, T, false)
+	x.mode, x.typ = commaok, T
+}
+
+// typeAssertion reports the assertion of the interface value x to type T if
+// it can never succeed: T is not an interface and does not implement the
+// type of x. The case of a type switch is reported likewise.
+func (c *Checker) typeAssertion(e ast.Expression, x *operand, T Type, typeSwitch bool) {
+	if isInterface(T) {
+		return
+	}
+	cause := missingMethod(T, under(x.typ).(*Interface))
+	if cause == "" {
+		return
+	}
+	if typeSwitch {
+		c.errorf(e, "impossible type switch case: %s\n\t%s cannot have dynamic type %s %s", exprString(e), x, T, cause)
+		return
+	}
+	c.errorf(e, "impossible type assertion: %s\n\t%s does not implement %s %s", exprString(e), T, x.typ, cause)
+}
+
+// ----------------------------------------------------------------------------
+// Composite literals
+
+// compositeLit checks a composite literal. A literal without a type is an
+// element of another, whose element type is the hint.
+func (c *Checker) compositeLit(x *operand, e *ast.CompositeLit, hint Type) {
+	var typ, base Type
+	openArray := false
+	switch {
+	case e.Type != nil:
+		// [...]T has the length of its elements
+		if atyp, ok := e.Type.(*ast.ArrayType); ok {
+			if _, ok := atyp.Len.(*ast.Ellipsis); ok {
+				typ = &Array{Len: -1, Elem: c.varType(atyp.Elt)}
+				openArray = true
+				break
+			}
+		}
+		typ = c.typExpr(e.Type)
+	case hint != nil:
+		typ = hint
+	default:
+		c.errorf(e, "invalid composite literal type: missing type")
+		c.use(e.Elts...)
+		return
+	}
+	base = typ
+	if p, ok := coreType(typ).(*Pointer); ok && e.Type == nil {
+		// &T is elided in an element of type *T
+		base = p.Elem
+	}
+
+	switch t := coreType(base).(type) {
+	case *Struct:
+		c.structLit(e, t, base)
+	case *Array:
+		n := c.indexedElts(e.Elts, t.Elem, t.Len)
+		if openArray {
+			t.Len = n
+			c.info.Types[e.Type] = t
+		}
+	case *Slice:
+		c.indexedElts(e.Elts, t.Elem, -1)
+	case *Map:
+		c.mapLit(e, t)
+	default:
+		if isValid(base) && t != nil {
+			c.errorf(e, "invalid composite literal type %s", typ)
+		} else if isValid(base) {
+			c.errorf(e, "invalid composite literal type %s (no core type)", typ)
+		}
+		for _, elt := range e.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				elt = kv.Value
+			}
+			c.use(elt)
+		}
+		if isValid(base) {
+			return
+		}
+	}
+	x.mode, x.typ = value, typ
+}
+
+func (c *Checker) structLit(e *ast.CompositeLit, s *Struct, typ Type) {
+	if len(e.Elts) == 0 {
+		return
+	}
+	if _, ok := e.Elts[0].(*ast.KeyValueExpr); ok {
+		seen := make(map[string]bool)
+		for _, elt := range e.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				c.errorf(elt, "mixture of field:value and value elements in struct literal")
+				c.use(elt)
+				continue
+			}
+			key, ok := kv.Key.(*ast.Identifier)
+			if !ok {
+				c.errorf(kv.Key, "invalid field name %s in struct literal", exprString(kv.Key))
+				c.use(kv.Value)
+				continue
+			}
+			var field *Var
+			for _, f := range s.Fields {
+				if f.Name == key.Value {
+					field = f
+					break
+				}
+			}
+			if field == nil {
+				c.errorf(key, "unknown field %s in struct literal of type %s", key.Value, typ)
+				c.use(kv.Value)
+				continue
+			}
+			if seen[key.Value] {
+				c.errorf(key, "duplicate field name %s in struct literal", key.Value)
+				c.use(kv.Value)
+				continue
+			}
+			seen[key.Value] = true
+			c.info.Types[key] = field.Type
+			var x operand
+			c.exprWithHint(&x, kv.Value, field.Type)
+			c.assignment(&x, field.Type, "struct literal")
+		}
+		return
+	}
+	for i, elt := range e.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			c.errorf(kv, "mixture of field:value and value elements in struct literal")
+			c.use(kv.Value)
+			continue
+		}
+		var x operand
+		if i >= len(s.Fields) {
+			c.errorf(elt, "too many values in struct literal of type %s", typ)
+			c.use(e.Elts[i:]...)
+			return
+		}
+		c.exprWithHint(&x, elt, s.Fields[i].Type)
+		c.assignment(&x, s.Fields[i].Type, "struct literal")
+	}
+	if len(e.Elts) < len(s.Fields) {
+		c.errorfAt(e.Rbrace.Pos, "too few values in struct literal of type %s", typ)
+	}
+}
+
+// indexedElts checks the elements of an array or slice literal, and returns
+// the length they give it. The length of an array is -1 if it is unknown.
+func (c *Checker) indexedElts(elts []ast.Expression, elem Type, length int64) int64 {
+	seen := make(map[int64]bool)
+	var index, max int64
+	for _, elt := range elts {
+		validIndex := false
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if i, ok := c.index(kv.Key, length); ok {
+				index, validIndex = i, true
+			}
+			elt = kv.Value
+		} else if length >= 0 && index >= length {
+			c.errorf(elt, "index %d is out of bounds (>= %d)", index, length)
+		} else {
+			validIndex = true
+		}
+		if validIndex {
+			if seen[index] {
+				c.errorf(elt, "duplicate index %d in array or slice literal", index)
+			}
+			seen[index] = true
+		}
+		index++
+		if index > max {
+			max = index
+		}
+		var x operand
+		c.exprWithHint(&x, elt, elem)
+		c.assignment(&x, elem, "array or slice literal")
+	}
+	return max
+}
+
+func (c *Checker) mapLit(e *ast.CompositeLit, m *Map) {
+	for _, elt := range e.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			c.errorf(elt, "missing key in map literal")
+			c.use(elt)
+			continue
+		}
+		var x operand
+		c.exprWithHint(&x, kv.Key, m.Key)
+		c.assignment(&x, m.Key, "map literal")
+		c.exprWithHint(&x, kv.Value, m.Elem)
+		c.assignment(&x, m.Elem, "map literal")
+	}
+}
+
+// unparen returns e with any enclosing parentheses removed.
+func unparen(e ast.Expression) ast.Expression {
+	for {
+		p, ok := e.(*ast.ParenExpr)
+		if !ok {
+			return e
+		}
+		e = p.X
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright 2013 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE-GO file.
+//
+// Adapted from go/types (cmd/compile/internal/types2).
+
+package types
+
+import (
+	"strconv"
+	"strings"
+
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+)
+
+// exprString returns the (possibly shortened) source form of x, as used in
+// error messages: function literals and composite literal elements are
+// elided.
+func exprString(x ast.Expression) string {
+	var buf strings.Builder
+	writeExpr(&buf, x)
+	return buf.String()
+}
+
+func writeExpr(buf *strings.Builder, x ast.Expression) {
+	switch x := x.(type) {
+	case nil:
+		// nothing to do
+	case *ast.Identifier:
+		buf.WriteString(x.Value)
+	case *ast.IntegerLiteral:
+		buf.WriteString(x.Value)
+	case *ast.FloatLiteral:
+		buf.WriteString(x.Value)
+	case *ast.StringLiteral:
+		buf.WriteString(x.Token.Literal)
+	case *ast.RuneLiteral:
+		buf.WriteString(x.Token.Literal)
+	case *ast.FuncLit:
+		buf.WriteString("func")
+		writeSigExpr(buf, x.Type)
+		buf.WriteString(" {…}")
+	case *ast.CompositeLit:
+		writeExpr(buf, x.Type)
+		buf.WriteString("{")
+		if len(x.Elts) > 0 {
+			buf.WriteString("…")
+		}
+		buf.WriteString("}")
+	case *ast.ParenExpr:
+		buf.WriteString("(")
+		writeExpr(buf, x.X)
+		buf.WriteString(")")
+	case *ast.SelectorExpr:
+		writeExpr(buf, x.X)
+		buf.WriteString(".")
+		if x.Sel != nil {
+			buf.WriteString(x.Sel.Value)
+		}
+	case *ast.IndexExpr:
+		writeExpr(buf, x.X)
+		buf.WriteString("[")
+		writeExpr(buf, x.Index)
+		buf.WriteString("]")
+	case *ast.IndexListExpr:
+		writeExpr(buf, x.X)
+		buf.WriteString("[")
+		writeExprList(buf, x.Indices)
+		buf.WriteString("]")
+	case *ast.SliceExpr:
+		writeExpr(buf, x.X)
+		buf.WriteString("[")
+		writeExpr(buf, x.Low)
+		buf.WriteString(":")
+		writeExpr(buf, x.High)
+		if x.Slice3 {
+			buf.WriteString(":")
+			writeExpr(buf, x.Max)
+		}
+		buf.WriteString("]")
+	case *ast.TypeAssertExpr:
+		writeExpr(buf, x.X)
+		buf.WriteString(".(")
+		if x.Type == nil {
+			buf.WriteString("type")
+		} else {
+			writeExpr(buf, x.Type)
+		}
+		buf.WriteString(")")
+	case *ast.CallExpr:
+		writeExpr(buf, x.Fun)
+		buf.WriteString("(")
+		writeExprList(buf, x.Args)
+		if x.Ellipsis.Type != "" {
+			buf.WriteString("...")
+		}
+		buf.WriteString(")")
+	case *ast.StarExpr:
+		buf.WriteString("*")
+		writeExpr(buf, x.X)
+	case *ast.PrefixExpression:
+		buf.WriteString(x.Operator)
+		writeExpr(buf, x.Right)
+	case *ast.InfixExpression:
+		writeExpr(buf, x.Left)
+		buf.WriteString(" " + x.Operator + " ")
+		writeExpr(buf, x.Right)
+	case *ast.KeyValueExpr:
+		writeExpr(buf, x.Key)
+		buf.WriteString(": ")
+		writeExpr(buf, x.Value)
+	case *ast.Ellipsis:
+		buf.WriteString("...")
+		writeExpr(buf, x.Elt)
+	case *ast.ArrayType:
+		buf.WriteString("[")
+		writeExpr(buf, x.Len)
+		buf.WriteString("]")
+		writeExpr(buf, x.Elt)
+	case *ast.MapType:
+		buf.WriteString("map[")
+		writeExpr(buf, x.Key)
+		buf.WriteString("]")
+		writeExpr(buf, x.Value)
+	case *ast.ChanType:
+		switch x.Dir {
+		case ast.SEND:
+			buf.WriteString("chan<- ")
+		case ast.RECV:
+			buf.WriteString("<-chan ")
+		default:
+			buf.WriteString("chan ")
+		}
+		writeExpr(buf, x.Value)
+	case *ast.FuncType:
+		buf.WriteString("func")
+		writeSigExpr(buf, x)
+	case *ast.StructType:
+		buf.WriteString("struct{")
+		writeFieldList(buf, x.Fields, "; ", false)
+		buf.WriteString("}")
+	case *ast.InterfaceType:
+		buf.WriteString("interface{")
+		writeFieldList(buf, x.Methods, "; ", true)
+		buf.WriteString("}")
+	default:
+		buf.WriteString("(bad expr)")
+	}
+}
+
+func writeExprList(buf *strings.Builder, list []ast.Expression) {
+	for i, x := range list {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		writeExpr(buf, x)
+	}
+}
+
+func writeSigExpr(buf *strings.Builder, sig *ast.FuncType) {
+	if sig == nil {
+		buf.WriteString("()")
+		return
+	}
+	buf.WriteString("(")
+	writeFieldList(buf, sig.Params, ", ", false)
+	buf.WriteString(")")
+	if sig.Results == nil || len(sig.Results.List) == 0 {
+		return
+	}
+	buf.WriteString(" ")
+	if res := sig.Results.List; len(res) == 1 && len(res[0].Names) == 0 {
+		writeExpr(buf, res[0].Type)
+		return
+	}
+	buf.WriteString("(")
+	writeFieldList(buf, sig.Results, ", ", false)
+	buf.WriteString(")")
+}
+
+func writeFieldList(buf *strings.Builder, list *ast.FieldList, sep string, iface bool) {
+	if list == nil {
+		return
+	}
+	for i, f := range list.List {
+		if i > 0 {
+			buf.WriteString(sep)
+		}
+		for j, name := range f.Names {
+			if j > 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteString(name.Value)
+		}
+		if ft, ok := f.Type.(*ast.FuncType); ok && iface && len(f.Names) > 0 {
+			writeSigExpr(buf, ft)
+			continue
+		}
+		if len(f.Names) > 0 {
+			buf.WriteString(" ")
+		}
+		writeExpr(buf, f.Type)
+		if f.Tag != nil {
+			buf.WriteString(" " + strconv.Quote(f.Tag.Value))
+		}
+	}
+}
@@ -0,0 +1,322 @@
+// Copyright 2013 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE-GO file.
+//
+// Adapted from go/types (cmd/compile/internal/types2).
+
+package types
+
+import (
+	"sort"
+)
+
+// ----------------------------------------------------------------------------
+// Interfaces
+
+// complete computes the method set and type set of t from its methods and
+// embedded elements.
+func (t *Interface) complete() {
+	if t.completed {
+		return
+	}
+	t.completed = true // guards against invalid recursive embedding
+	methods := append([]*Func(nil), t.Methods...)
+	var terms []*Term
+	restricted := false
+	for _, e := range t.Embeddeds {
+		var eterms []*Term
+		switch u := under(e).(type) {
+		case *Interface:
+			u.complete()
+			methods = append(methods, u.allMethods...)
+			if u.comparable {
+				t.comparable = true
+			}
+			if u.terms == nil {
+				continue
+			}
+			eterms = u.terms
+		case *Union:
+			eterms = u.Terms
+		default:
+			if !isValid(e) {
+				continue
+			}
+			eterms = []*Term{{Type: e}}
+		}
+		if !restricted {
+			terms, restricted = eterms, true
+		} else {
+			terms = intersectTerms(terms, eterms)
+		}
+	}
+	sort.SliceStable(methods, func(i, j int) bool { return methods[i].Name < methods[j].Name })
+	// an embedded method may repeat an explicit one with an identical
+	// signature
+	t.allMethods = methods[:0:0]
+	for i, m := range methods {
+		if i == 0 || m.Name != methods[i-1].Name {
+			t.allMethods = append(t.allMethods, m)
+		}
+	}
+	if restricted && terms == nil {
+		terms = []*Term{} // the empty type set
+	}
+	t.terms = terms
+}
+
+// methodSet returns the methods of t, including embedded ones, sorted by name.
+func (t *Interface) methodSet() []*Func {
+	t.complete()
+	return t.allMethods
+}
+
+// typeSet returns the terms the types of t are restricted to, or nil if t
+// only restricts them by its methods.
+func (t *Interface) typeSet() []*Term {
+	t.complete()
+	return t.terms
+}
+
+// isComparable reports whether t is or embeds comparable.
+func (t *Interface) isComparable() bool {
+	t.complete()
+	return t.comparable
+}
+
+// isConstraint reports whether t can only be used as a constraint, because
+// it restricts its type set by other means than methods.
+func (t *Interface) isConstraint() bool {
+	t.complete()
+	return t.terms != nil || t.comparable
+}
+
+func intersectTerms(x, y []*Term) []*Term {
+	var r []*Term
+	for _, s := range x {
+		for _, t := range y {
+			if termIncludes(s, t.Type) {
+				r = append(r, t)
+			} else if termIncludes(t, s.Type) {
+				r = append(r, s)
+			}
+		}
+	}
+	return r
+}
+
+// termIncludes reports whether t is in the type set of term.
+func termIncludes(term *Term, t Type) bool {
+	if term.Tilde {
+		return Identical(under(term.Type), under(t))
+	}
+	return Identical(term.Type, t)
+}
+
+// ----------------------------------------------------------------------------
+// Methods and fields
+
+// methods returns the methods declared for a named type, with the type
+// arguments of an instance substituted for the receiver type parameters.
+func methods(n *Named) []*Func {
+	orig := n.Orig
+	if orig == n || len(n.TypeArgs) == 0 {
+		return n.Methods
+	}
+	list := make([]*Func, len(orig.Methods))
+	for i, m := range orig.Methods {
+		smap := makeSubstMap(m.Sig.RecvTypeParams, n.TypeArgs)
+		sig := subst(m.Sig, smap).(*Signature)
+		list[i] = &Func{Name: m.Name, Sig: sig, PtrRecv: m.PtrRecv, Obj: m.Obj}
+	}
+	return list
+}
+
+func findMethod(list []*Func, name string) *Func {
+	for _, m := range list {
+		if m.Name == name {
+			return m
+		}
+	}
+	return nil
+}
+
+// A selection is the result of looking up a field or method.
+type selection struct {
+	field    *Var
+	method   *Func
+	indirect bool // a pointer was followed on the way to the field or method
+}
+
+// lookupFieldOrMethod looks up the field or method name of T, searching the
+// embedded fields breadth first: a name at a shallower depth hides the same
+// name at a deeper one, and two at the same depth make the selector
+// ambiguous.
+func lookupFieldOrMethod(T Type, name string) (sel selection, ambiguous bool, found bool) {
+	if name == "_" {
+		return
+	}
+	type entry struct {
+		typ      Type
+		indirect bool
+	}
+	if p, ok := T.(*Pointer); ok {
+		// a pointer to a named type has the fields and methods of the type
+		if _, ok := p.Elem.(*Named); ok {
+			T = p.Elem
+			sel.indirect = true
+		}
+	} else if p, ok := under(T).(*Pointer); ok {
+		// a named pointer type has no methods, but its base type's fields
+		if _, ok := under(p.Elem).(*Struct); ok {
+			T = p.Elem
+			sel.indirect = true
+		}
+	}
+	current := []entry{{T, sel.indirect}}
+	seen := make(map[*Named]bool)
+	for len(current) > 0 {
+		var next []entry
+		count := 0
+		for _, e := range current {
+			typ := e.typ
+			if n, ok := typ.(*Named); ok {
+				if seen[n.Orig] {
+					continue
+				}
+				seen[n.Orig] = true
+				if m := findMethod(methods(n), name); m != nil {
+					count++
+					sel = selection{method: m, indirect: e.indirect}
+					continue
+				}
+			}
+			switch u := under(typ).(type) {
+			case *Struct:
+				for _, f := range u.Fields {
+					if f.Name == name {
+						count++
+						sel = selection{field: f, indirect: e.indirect}
+						continue
+					}
+					if f.Embedded {
+						ft, indirect := f.Type, e.indirect
+						if p, ok := ft.(*Pointer); ok {
+							ft, indirect = p.Elem, true
+						}
+						next = append(next, entry{ft, indirect})
+					}
+				}
+			case *Interface:
+				if m := findMethod(u.methodSet(), name); m != nil {
+					count++
+					sel = selection{method: m, indirect: e.indirect}
+				}
+			}
+		}
+		if count > 1 {
+			return selection{}, true, false
+		}
+		if count == 1 {
+			return sel, false, true
+		}
+		current = next
+	}
+	return selection{}, false, false
+}
+
+// missingMethod returns a description of why V does not have the methods of
+// the interface T, or "" if it has all of them.
+func missingMethod(V Type, T *Interface) string {
+	for _, m := range T.methodSet() {
+		var have *Func
+		ptrRecv := false
+		if vi, ok := under(V).(*Interface); ok && !isTypeParam(V) {
+			have = findMethod(vi.methodSet(), m.Name)
+		} else if tp, ok := V.(*TypeParam); ok {
+			have = findMethod(tp.iface().methodSet(), m.Name)
+		} else {
+			sel, _, found := lookupFieldOrMethod(V, m.Name)
+			if found && sel.method != nil {
+				have = sel.method
+				// the method set of a non-pointer type excludes methods
+				// with a pointer receiver, unless reached through an
+				// embedded pointer
+				_, isPtr := V.(*Pointer)
+				ptrRecv = have.PtrRecv && !isPtr && !sel.indirect
+			}
+		}
+		switch {
+		case have == nil:
+			return "(missing method " + m.Name + ")"
+		case !Identical(have.Sig, m.Sig):
+			return "(wrong type for method " + m.Name + ")\n\t\thave " + m.Name + sigString(have.Sig) + "\n\t\twant " + m.Name + sigString(m.Sig)
+		case ptrRecv:
+			return "(method " + m.Name + " has pointer receiver)"
+		}
+	}
+	return ""
+}
+
+// sigString returns the signature sig without the func keyword.
+func sigString(sig *Signature) string {
+	s := sig.String()
+	return s[len("func"):]
+}
+
+// implements returns why V does not implement the interface T, or "" if it
+// does. A constraint is said to be satisfied, rather than implemented.
+func implements(V, T Type, constraint bool) string {
+	Ti, ok := under(T).(*Interface)
+	if !ok || !isValid(V) || Ti == universeAny {
+		return "" // an error has been reported elsewhere
+	}
+	verb := "implement"
+	if constraint {
+		verb = "satisfy"
+	}
+	if cause := missingMethod(V, Ti); cause != "" {
+		return V.String() + " does not " + verb + " " + T.String() + " " + cause
+	}
+	if Ti.isComparable() && !comparable(V) {
+		return V.String() + " does not " + verb + " comparable"
+	}
+	terms := Ti.typeSet()
+	if terms == nil {
+		return ""
+	}
+	if tp, ok := V.(*TypeParam); ok {
+		// each type of V's type set must be in T's
+		vterms := tp.iface().typeSet()
+		for _, vt := range vterms {
+			if !inTermList(terms, vt.Type, vt.Tilde) {
+				vterms = nil
+				break
+			}
+		}
+		if len(vterms) == 0 {
+			return V.String() + " does not " + verb + " " + T.String()
+		}
+		return ""
+	}
+	if !inTermList(terms, V, false) {
+		return V.String() + " does not " + verb + " " + T.String() + " (" + V.String() + " missing in " + termListString(terms) + ")"
+	}
+	return ""
+}
+
+func inTermList(terms []*Term, t Type, tilde bool) bool {
+	for _, term := range terms {
+		if tilde && !term.Tilde {
+			continue
+		}
+		if termIncludes(term, t) {
+			return true
+		}
+	}
+	return false
+}
+
+func termListString(terms []*Term) string {
+	return (&Union{Terms: terms}).String()
+}
@@ -0,0 +1,121 @@
+// Copyright 2012 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE-GO file.
+//
+// Adapted from go/types (cmd/compile/internal/types2).
+
+package types
+
+import (
+	"strings"
+
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+	constant "github.com/mohit-bhandari45/Compiler-GO.git/internal/constant"
+)
+
+// An operandMode describes what an operand denotes, and so how it may be used.
+type operandMode int
+
+const (
+	invalid   operandMode = iota // the operand is invalid; an error has been reported
+	novalue                      // the call of a function without results
+	builtin                      // a predeclared function
+	typexpr                      // a type
+	constant_                    // an untyped or typed constant
+	variable                     // an addressable variable
+	mapindex                     // a map index expression: assignable, not addressable
+	value                        // any other value
+	commaok                      // a value that may be assigned to two variables, as in v, ok := <-ch
+)
+
+var operandModeString = [...]string{
+	invalid:   "invalid operand",
+	novalue:   "no value",
+	builtin:   "built-in",
+	typexpr:   "type",
+	constant_: "constant",
+	variable:  "variable",
+	mapindex:  "map index expression",
+	value:     "value",
+	commaok:   "comma, ok expression",
+}
+
+// An operand is the result of checking an expression: its mode and type,
+// and its value if it is a constant.
+type operand struct {
+	mode operandMode
+	expr ast.Expression
+	typ  Type
+	val  constant.Value // for a constant
+	id   builtinId      // for a builtin
+}
+
+// String describes x as in the error messages of gc:
+//
+//	x (variable of type int)
+//	1 (untyped int constant)
+//	c (untyped int constant 300)
+//	c + 1 (constant 301 of type int16)
+//	f() (value of type (int, error))
+//	int (type)
+//	len (built-in)
+//	nil
+func (x *operand) String() string {
+	if x.mode == value && x.typ == Typ[UntypedNil] {
+		return "nil"
+	}
+	var buf strings.Builder
+	expr := exprString(x.expr)
+	if expr != "" {
+		buf.WriteString(expr)
+		buf.WriteString(" (")
+	}
+	hasType := false
+	switch x.mode {
+	case invalid, novalue, builtin, typexpr:
+		// no type
+	default:
+		if x.typ != nil {
+			if isUntyped(x.typ) {
+				buf.WriteString(x.typ.(*Basic).name)
+				buf.WriteString(" ")
+				break
+			}
+			hasType = true
+		}
+	}
+	buf.WriteString(operandModeString[x.mode])
+	if x.mode == constant_ && x.val != nil {
+		// the value, unless the expression spells it out
+		if s := x.val.String(); s != expr {
+			buf.WriteString(" ")
+			buf.WriteString(s)
+		}
+	}
+	if hasType {
+		if x.typ != Typ[Invalid] {
+			buf.WriteString(" of type ")
+			buf.WriteString(x.typ.String())
+			if tp, ok := x.typ.(*TypeParam); ok {
+				buf.WriteString(" constrained by ")
+				buf.WriteString(tp.Constraint.String())
+			}
+		} else {
+			buf.WriteString(" with invalid type")
+		}
+	}
+	if expr != "" {
+		buf.WriteString(")")
+	}
+	return buf.String()
+}
+
+// isNil reports whether x is the predeclared nil.
+func (x *operand) isNil() bool {
+	return x.mode == value && x.typ == Typ[UntypedNil]
+}
+
+// addressable reports whether &x is permitted.
+func (x *operand) addressable() bool {
+	return x.mode == variable
+}
@@ -0,0 +1,319 @@
+// Copyright 2012 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE-GO file.
+//
+// Adapted from go/types (cmd/compile/internal/types2).
+
+package types
+
+import (
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+)
+
+// under returns the underlying type of t.
+func under(t Type) Type {
+	if t == nil {
+		return Typ[Invalid]
+	}
+	return t.Underlying()
+}
+
+// coreType returns the single underlying type shared by all types of the
+// type set of a type parameter, or nil if there is none. For any other type
+// it is the underlying type.
+func coreType(t Type) Type {
+	tp, ok := t.(*TypeParam)
+	if !ok {
+		return under(t)
+	}
+	terms := tp.iface().typeSet()
+	if len(terms) == 0 {
+		return nil
+	}
+	var core Type
+	for _, term := range terms {
+		u := under(term.Type)
+		if core != nil && !Identical(core, u) {
+			return nil
+		}
+		core = u
+	}
+	return core
+}
+
+// allBasic reports whether t, or each type in the type set of a type
+// parameter t, is a basic type with one of the properties in info.
+func allBasic(t Type, info BasicInfo) bool {
+	if tp, ok := t.(*TypeParam); ok {
+		terms := tp.iface().typeSet()
+		if len(terms) == 0 {
+			return false
+		}
+		for _, term := range terms {
+			if !allBasic(term.Type, info) {
+				return false
+			}
+		}
+		return true
+	}
+	b, ok := under(t).(*Basic)
+	return ok && b.info&info != 0
+}
+
+func isBoolean(t Type) bool   { return allBasic(t, IsBoolean) }
+func isInteger(t Type) bool   { return allBasic(t, IsInteger) }
+func isUnsigned(t Type) bool  { return allBasic(t, IsUnsigned) }
+func isFloat(t Type) bool     { return allBasic(t, IsFloat) }
+func isComplex(t Type) bool   { return allBasic(t, IsComplex) }
+func isNumeric(t Type) bool   { return allBasic(t, IsNumeric) }
+func isString(t Type) bool    { return allBasic(t, IsString) }
+func isOrdered(t Type) bool   { return allBasic(t, IsOrdered) }
+func isConstType(t Type) bool { return allBasic(t, IsConstType) }
+
+// isUntyped reports whether t is the type of an untyped value.
+func isUntyped(t Type) bool {
+	b, ok := t.(*Basic)
+	return ok && b.info&IsUntyped != 0
+}
+
+// isTyped reports whether t is not the type of an untyped value.
+func isTyped(t Type) bool { return !isUntyped(t) }
+
+// isValid reports whether t is not the invalid type.
+func isValid(t Type) bool { return t != Typ[Invalid] && t != nil }
+
+// isInterface reports whether t is an interface type, and not a type
+// parameter.
+func isInterface(t Type) bool {
+	if _, ok := t.(*TypeParam); ok {
+		return false
+	}
+	_, ok := under(t).(*Interface)
+	return ok
+}
+
+// isTypeParam reports whether t is a type parameter.
+func isTypeParam(t Type) bool {
+	_, ok := t.(*TypeParam)
+	return ok
+}
+
+// hasNil reports whether nil is a value of type t.
+func hasNil(t Type) bool {
+	if tp, ok := t.(*TypeParam); ok {
+		terms := tp.iface().typeSet()
+		if len(terms) == 0 {
+			return false
+		}
+		for _, term := range terms {
+			if !hasNil(term.Type) {
+				return false
+			}
+		}
+		return true
+	}
+	switch under(t).(type) {
+	case *Pointer, *Slice, *Map, *Chan, *Signature, *Interface:
+		return true
+	}
+	return false
+}
+
+// comparable reports whether values of type t can be compared with ==.
+func comparable(t Type) bool {
+	return comparableSeen(t, nil)
+}
+
+func comparableSeen(t Type, seen map[Type]bool) bool {
+	if seen[t] {
+		return true
+	}
+	if seen == nil {
+		seen = make(map[Type]bool)
+	}
+	seen[t] = true
+	if tp, ok := t.(*TypeParam); ok {
+		iface := tp.iface()
+		if iface.isComparable() {
+			return true
+		}
+		terms := iface.typeSet()
+		if len(terms) == 0 {
+			return false
+		}
+		for _, term := range terms {
+			if !comparableSeen(term.Type, seen) {
+				return false
+			}
+		}
+		return true
+	}
+	switch u := under(t).(type) {
+	case *Basic:
+		return u.kind != UntypedNil
+	case *Pointer, *Chan, *Interface:
+		return true
+	case *Struct:
+		for _, f := range u.Fields {
+			if !comparableSeen(f.Type, seen) {
+				return false
+			}
+		}
+		return true
+	case *Array:
+		return comparableSeen(u.Elem, seen)
+	}
+	return false
+}
+
+// Identical reports whether x and y are the same type.
+func Identical(x, y Type) bool {
+	if x == y {
+		return true
+	}
+	switch x := x.(type) {
+	case *Basic:
+		if y, ok := y.(*Basic); ok {
+			return x.kind == y.kind
+		}
+	case *Pointer:
+		if y, ok := y.(*Pointer); ok {
+			return Identical(x.Elem, y.Elem)
+		}
+	case *Array:
+		if y, ok := y.(*Array); ok {
+			return (x.Len == y.Len || x.Len < 0 || y.Len < 0) && Identical(x.Elem, y.Elem)
+		}
+	case *Slice:
+		if y, ok := y.(*Slice); ok {
+			return Identical(x.Elem, y.Elem)
+		}
+	case *Map:
+		if y, ok := y.(*Map); ok {
+			return Identical(x.Key, y.Key) && Identical(x.Elem, y.Elem)
+		}
+	case *Chan:
+		if y, ok := y.(*Chan); ok {
+			return x.Dir == y.Dir && Identical(x.Elem, y.Elem)
+		}
+	case *Struct:
+		if y, ok := y.(*Struct); ok && len(x.Fields) == len(y.Fields) {
+			for i, f := range x.Fields {
+				g := y.Fields[i]
+				if f.Name != g.Name || f.Embedded != g.Embedded || tagOf(x, i) != tagOf(y, i) || !Identical(f.Type, g.Type) {
+					return false
+				}
+			}
+			return true
+		}
+	case *Tuple:
+		if y, ok := y.(*Tuple); ok {
+			return identicalTuples(x, y)
+		}
+	case *Signature:
+		if y, ok := y.(*Signature); ok {
+			return x.Variadic == y.Variadic && len(x.TypeParams) == len(y.TypeParams) &&
+				identicalTuples(x.Params, y.Params) && identicalTuples(x.Results, y.Results)
+		}
+	case *Interface:
+		if y, ok := y.(*Interface); ok {
+			xm, ym := x.methodSet(), y.methodSet()
+			if len(xm) != len(ym) || x.isComparable() != y.isComparable() {
+				return false
+			}
+			for i, m := range xm {
+				if m.Name != ym[i].Name || !Identical(m.Sig, ym[i].Sig) {
+					return false
+				}
+			}
+			return identicalTerms(x.typeSet(), y.typeSet())
+		}
+	case *Union:
+		if y, ok := y.(*Union); ok {
+			return identicalTerms(x.Terms, y.Terms)
+		}
+	case *Named:
+		if y, ok := y.(*Named); ok && x.Orig == y.Orig && len(x.TypeArgs) == len(y.TypeArgs) {
+			for i, arg := range x.TypeArgs {
+				if !Identical(arg, y.TypeArgs[i]) {
+					return false
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
+
+func identicalTuples(x, y *Tuple) bool {
+	if x.Len() != y.Len() {
+		return false
+	}
+	for i, v := range x.vars() {
+		if !Identical(v.Type, y.Vars[i].Type) {
+			return false
+		}
+	}
+	return true
+}
+
+func identicalTerms(x, y []*Term) bool {
+	if len(x) != len(y) {
+		return false
+	}
+	for _, s := range x {
+		found := false
+		for _, t := range y {
+			if s.Tilde == t.Tilde && Identical(s.Type, t.Type) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func tagOf(s *Struct, i int) string {
+	if s.Tags == nil {
+		return ""
+	}
+	return s.Tags[i]
+}
+
+// Default returns the type an untyped value takes when the context does not
+// give it one: int for an untyped integer constant, and so on. Any other
+// type is returned unchanged.
+func Default(t Type) Type {
+	if b, ok := t.(*Basic); ok {
+		switch b.kind {
+		case UntypedBool:
+			return Typ[Bool]
+		case UntypedInt:
+			return Typ[Int]
+		case UntypedRune:
+			return universeRune
+		case UntypedFloat:
+			return Typ[Float64]
+		case UntypedComplex:
+			return Typ[Complex128]
+		case UntypedString:
+			return Typ[String]
+		}
+	}
+	return t
+}
+
+// chanDirString describes the operations a channel type permits, for error
+// messages.
+func chanDirString(dir ast.ChanDir) string {
+	switch dir {
+	case ast.SEND:
+		return "send-only"
+	case ast.RECV:
+		return "receive-only"
+	}
+	return "bidirectional"
+}
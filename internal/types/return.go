@@ -0,0 +1,164 @@
+// Copyright 2013 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE-GO file.
+//
+// Adapted from go/types (cmd/compile/internal/types2).
+
+package types
+
+import (
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+	resolve "github.com/mohit-bhandari45/Compiler-GO.git/internal/resolve"
+)
+
+// Terminating statements, as defined by the spec: a function with results
+// must end in one.
+
+// isTerminating reports whether s is a terminating statement. label is the
+// label of s, if any, which a break in s may target.
+func (c *Checker) isTerminating(s ast.Statement, label string) bool {
+	switch s := s.(type) {
+	case *ast.ReturnStmt:
+		return true
+
+	case *ast.BranchStmt:
+		return s.Token.Type == lexer.GOTO || s.Token.Type == lexer.FALLTHROUGH
+
+	case *ast.ExprStmt:
+		// a call of the predeclared panic
+		if call, ok := unparen(s.X).(*ast.CallExpr); ok {
+			if id, ok := unparen(call.Fun).(*ast.Identifier); ok {
+				obj := c.info.Uses[id]
+				return obj != nil && obj.Kind == resolve.Builtin && obj.Name == "panic"
+			}
+		}
+
+	case *ast.LabeledStmt:
+		if s.Label != nil {
+			return c.isTerminating(s.Stmt, s.Label.Value)
+		}
+
+	case *ast.BlockStatement:
+		return c.isTerminatingList(s.Statements, "")
+
+	case *ast.IfStmt:
+		return s.Else != nil && s.Body != nil && c.isTerminating(s.Body, "") && c.isTerminating(s.Else, "")
+
+	case *ast.SwitchStmt:
+		return c.isTerminatingSwitch(s.Cases, label)
+
+	case *ast.TypeSwitchStmt:
+		return c.isTerminatingSwitch(s.Cases, label)
+
+	case *ast.SelectStmt:
+		for _, clause := range s.Cases {
+			if !c.isTerminatingList(clause.Body, "") || hasBreakList(clause.Body, label, true) {
+				return false
+			}
+		}
+		return true
+
+	case *ast.ForStmt:
+		return s.Cond == nil && s.Body != nil && !hasBreak(s.Body, label, true)
+	}
+	return false
+}
+
+func (c *Checker) isTerminatingList(list []ast.Statement, label string) bool {
+	if len(list) == 0 {
+		return false
+	}
+	return c.isTerminating(list[len(list)-1], label)
+}
+
+// isTerminatingSwitch reports whether a switch with the clauses list is
+// terminating: it has a default clause, and each clause ends in a
+// terminating statement or a fallthrough, and does not break.
+func (c *Checker) isTerminatingSwitch(list []*ast.CaseClause, label string) bool {
+	hasDefault := false
+	for _, clause := range list {
+		if clause.List == nil {
+			hasDefault = true
+		}
+		if !c.isTerminatingList(clause.Body, "") || hasBreakList(clause.Body, label, true) {
+			return false
+		}
+	}
+	return hasDefault
+}
+
+// hasBreak reports whether s contains a break that targets the statement
+// labeled label, or, if implicit is set, an unlabeled break that targets the
+// enclosing statement.
+func hasBreak(s ast.Statement, label string, implicit bool) bool {
+	switch s := s.(type) {
+	case *ast.BranchStmt:
+		if s.Token.Type == lexer.BREAK {
+			if s.Label == nil {
+				return implicit
+			}
+			return s.Label.Value == label
+		}
+
+	case *ast.LabeledStmt:
+		return hasBreak(s.Stmt, label, implicit)
+
+	case *ast.BlockStatement:
+		return hasBreakList(s.Statements, label, implicit)
+
+	case *ast.IfStmt:
+		return s.Body != nil && hasBreak(s.Body, label, implicit) || s.Else != nil && hasBreak(s.Else, label, implicit)
+
+	case *ast.CaseClause:
+		return hasBreakList(s.Body, label, implicit)
+
+	case *ast.CommClause:
+		return hasBreakList(s.Body, label, implicit)
+
+	// an unlabeled break in a nested breakable statement targets that
+	// statement
+	case *ast.SwitchStmt:
+		if label != "" {
+			for _, clause := range s.Cases {
+				if hasBreakList(clause.Body, label, false) {
+					return true
+				}
+			}
+		}
+
+	case *ast.TypeSwitchStmt:
+		if label != "" {
+			for _, clause := range s.Cases {
+				if hasBreakList(clause.Body, label, false) {
+					return true
+				}
+			}
+		}
+
+	case *ast.SelectStmt:
+		if label != "" {
+			for _, clause := range s.Cases {
+				if hasBreakList(clause.Body, label, false) {
+					return true
+				}
+			}
+		}
+
+	case *ast.ForStmt:
+		return label != "" && s.Body != nil && hasBreak(s.Body, label, false)
+
+	case *ast.RangeStmt:
+		return label != "" && s.Body != nil && hasBreak(s.Body, label, false)
+	}
+	return false
+}
+
+func hasBreakList(list []ast.Statement, label string, implicit bool) bool {
+	for _, s := range list {
+		if hasBreak(s, label, implicit) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,464 @@
+// Copyright 2012 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE-GO file.
+//
+// Adapted from go/types (cmd/compile/internal/types2).
+
+package types
+
+import (
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+	constant "github.com/mohit-bhandari45/Compiler-GO.git/internal/constant"
+	lexer "github.com/mohit-bhandari45/Compiler-GO.git/internal/lexer"
+	resolve "github.com/mohit-bhandari45/Compiler-GO.git/internal/resolve"
+)
+
+func (c *Checker) stmtList(list []ast.Statement) {
+	for _, s := range list {
+		c.stmt(s)
+	}
+}
+
+// stmt checks a statement of the current function.
+func (c *Checker) stmt(s ast.Statement) {
+	switch s := s.(type) {
+	case nil:
+		// an error has been reported by the parser
+
+	case *ast.DeclStmt:
+		if s.Decl != nil {
+			c.declStmt(s.Decl)
+		}
+
+	case *ast.LabeledStmt:
+		c.stmt(s.Stmt)
+
+	case *ast.ExprStmt:
+		// calls and receives may be used as statements; builtins that
+		// have results may not
+		var x operand
+		kind := c.rawExpr(&x, s.X, nil)
+		msg := ""
+		switch x.mode {
+		case invalid:
+			return
+		case builtin:
+			msg = "must be called"
+		case typexpr:
+			msg = "is not an expression"
+		default:
+			if kind == statement {
+				return
+			}
+			msg = "is not used"
+		}
+		c.errorf(s.X, "%s %s", &x, msg)
+
+	case *ast.SendStmt:
+		var ch, val operand
+		c.expr(&ch, s.Chan)
+		c.expr(&val, s.Value)
+		if ch.mode == invalid || val.mode == invalid {
+			return
+		}
+		u, ok := coreType(ch.typ).(*Chan)
+		switch {
+		case !ok:
+			c.errorf(s, "invalid operation: cannot send to non-channel %s", &ch)
+		case u.Dir == ast.RECV:
+			c.errorf(s, "invalid operation: cannot send to receive-only channel %s", &ch)
+		default:
+			c.assignment(&val, u.Elem, "send")
+		}
+
+	case *ast.IncDecStmt:
+		var x operand
+		c.expr(&x, s.X)
+		if x.mode == invalid {
+			return
+		}
+		if !isNumeric(x.typ) {
+			c.errorf(s.X, "invalid operation: %s%s (non-numeric type %s)", exprString(s.X), s.Token.Literal, x.typ)
+			return
+		}
+		c.assignVar(s.X, &x, "assignment")
+
+	case *ast.AssignStmt:
+		if s.Token.Type == lexer.DEFINE {
+			c.shortVarDecl(s)
+			return
+		}
+		c.assignVars(s.Lhs, s.Rhs)
+
+	case *ast.GoStmt:
+		c.suspendedCall("go", s.Call)
+
+	case *ast.DeferStmt:
+		c.suspendedCall("defer", s.Call)
+
+	case *ast.ReturnStmt:
+		c.returnStmt(s)
+
+	case *ast.BranchStmt:
+		// the targets of labels are checked by the parser
+
+	case *ast.BlockStatement:
+		c.stmtList(s.Statements)
+
+	case *ast.IfStmt:
+		c.stmt(s.Init)
+		var x operand
+		c.expr(&x, s.Cond)
+		if x.mode != invalid && !isBoolean(x.typ) {
+			c.errorf(s.Cond, "non-boolean condition in if statement")
+		}
+		if s.Body != nil {
+			c.stmt(s.Body)
+		}
+		c.stmt(s.Else)
+
+	case *ast.SwitchStmt:
+		c.switchStmt(s)
+
+	case *ast.TypeSwitchStmt:
+		c.typeSwitchStmt(s)
+
+	case *ast.SelectStmt:
+		for _, clause := range s.Cases {
+			c.stmt(clause.Comm)
+			c.stmtList(clause.Body)
+		}
+
+	case *ast.ForStmt:
+		c.stmt(s.Init)
+		if s.Cond != nil {
+			var x operand
+			c.expr(&x, s.Cond)
+			if x.mode != invalid && !isBoolean(x.typ) {
+				c.errorf(s.Cond, "non-boolean condition in for statement")
+			}
+		}
+		c.stmt(s.Post)
+		if s.Body != nil {
+			c.stmt(s.Body)
+		}
+
+	case *ast.RangeStmt:
+		c.rangeStmt(s)
+
+	default:
+		c.errorf(s, "invalid statement")
+	}
+}
+
+// suspendedCall checks the call of a go or defer statement, which must not
+// be a conversion or a builtin whose result is discarded.
+func (c *Checker) suspendedCall(keyword string, call *ast.CallExpr) {
+	if call == nil {
+		return
+	}
+	var x operand
+	var msg string
+	switch c.rawExpr(&x, call, nil) {
+	case conversion:
+		msg = "requires function call, not conversion"
+	case expression:
+		msg = "discards result of"
+	}
+	if msg != "" && x.mode != invalid {
+		c.errorf(call, "%s %s %s", keyword, msg, exprString(call))
+	}
+}
+
+// returnStmt checks the values returned by a return statement. A function
+// with named results may return them with a bare return.
+func (c *Checker) returnStmt(s *ast.ReturnStmt) {
+	results := c.sig.Results.vars()
+	if len(s.Results) == 0 && len(results) > 0 && results[0].Name != "" {
+		return
+	}
+	if len(s.Results) == 0 && len(results) == 0 {
+		return
+	}
+	xs := c.exprListN(s.Results, len(results))
+	if len(xs) != len(results) {
+		if !invalidOperands(xs) {
+			c.returnError(s, results, xs)
+		}
+		return
+	}
+	for i, x := range xs {
+		c.assignment(x, results[i].Type, "return statement")
+	}
+}
+
+// switchStmt checks an expression switch. Each case value is compared with
+// the tag, which is true if there is none.
+func (c *Checker) switchStmt(s *ast.SwitchStmt) {
+	c.stmt(s.Init)
+	var x operand
+	if s.Tag != nil {
+		c.expr(&x, s.Tag)
+		c.assignment(&x, nil, "switch expression")
+		if x.mode != invalid && !comparable(x.typ) && !hasNil(x.typ) {
+			c.errorf(s.Tag, "cannot switch on %s (%s is not comparable)", &x, x.typ)
+			x.mode = invalid
+		}
+	} else {
+		x.mode, x.typ, x.val = constant_, Typ[Bool], constant.MakeBool(true)
+	}
+
+	seen := make(map[string][]*operand) // the constant case values, by value
+	for _, clause := range s.Cases {
+		for _, e := range clause.List {
+			var v operand
+			c.expr(&v, e)
+			if x.mode == invalid || v.mode == invalid {
+				continue
+			}
+			c.convertUntyped(&v, x.typ)
+			if v.mode == invalid || !c.caseComparison(&v, &x, s.Tag) || v.mode != constant_ {
+				continue
+			}
+			key := v.val.ExactString()
+			if prev := duplicateCase(&v, seen[key]); prev != nil {
+				c.errorf(e, "duplicate case %s in expression switch\n\t%s: previous case", &v, c.position(prev.expr.Pos()))
+				continue
+			}
+			seen[key] = append(seen[key], &v)
+		}
+		c.stmtList(clause.Body)
+	}
+}
+
+// duplicateCase returns the case value of list with the type of v, if any;
+// list holds the earlier case values equal to v.
+func duplicateCase(v *operand, list []*operand) *operand {
+	for _, prev := range list {
+		if Identical(v.typ, prev.typ) {
+			return prev
+		}
+	}
+	return nil
+}
+
+// caseComparison checks the comparison of the case value v with the tag x of
+// a switch, and reports whether it is valid.
+func (c *Checker) caseComparison(v, x *operand, tag ast.Expression) bool {
+	res := *v
+	cause := ""
+	if ok, _ := c.assignableTo(&res, x.typ); !ok {
+		if ok, _ := c.assignableTo(x, res.typ); !ok {
+			cause = "mismatched types " + res.typ.String() + " and " + x.typ.String()
+		}
+	}
+	if cause == "" {
+		switch {
+		case res.isNil() && !hasNil(x.typ):
+			cause = "mismatched types untyped nil and " + x.typ.String()
+		case !res.isNil() && !comparable(res.typ):
+			cause = incomparableCause(res.typ)
+		default:
+			if isUntyped(v.typ) {
+				c.updateExprType(v.expr, Default(v.typ))
+			}
+			return true
+		}
+	}
+	if tag == nil {
+		c.errorf(v.expr, "invalid case %s in switch (%s)", exprString(v.expr), cause)
+		return false
+	}
+	c.errorf(v.expr, "invalid case %s in switch on %s (%s)", exprString(v.expr), exprString(tag), cause)
+	return false
+}
+
+// typeSwitchStmt checks a type switch. The variable it declares has, in each
+// clause, the type of the clause's only case, or the type of the switched
+// expression.
+func (c *Checker) typeSwitchStmt(s *ast.TypeSwitchStmt) {
+	c.stmt(s.Init)
+	var x operand
+	c.expr(&x, s.X)
+	if x.mode != invalid {
+		switch {
+		case isTypeParam(x.typ):
+			c.errorf(s.X, "cannot use type switch on type parameter value %s", &x)
+			x.mode = invalid
+		case !isInterface(x.typ):
+			c.errorf(s.X, "%s is not an interface", &x)
+			x.mode = invalid
+		}
+	}
+
+	var seen []Type
+	var seenAt []ast.Expression
+	for _, clause := range s.Cases {
+		var T Type
+		for _, e := range clause.List {
+			if id, ok := unparen(e).(*ast.Identifier); ok {
+				if obj := c.info.Uses[id]; obj != nil && obj.Kind == resolve.Nil {
+					T = Typ[UntypedNil]
+					c.info.Types[e] = T
+				} else {
+					T = c.varType(e)
+				}
+			} else {
+				T = c.varType(e)
+			}
+			if !isValid(T) {
+				continue
+			}
+			for i, t := range seen {
+				if Identical(t, T) {
+					name := T.String()
+					if T == Typ[UntypedNil] {
+						name = "nil"
+					}
+					c.errorf(e, "duplicate case %s in type switch\n\t%s: previous case", name, c.position(seenAt[i].Pos()))
+					break
+				}
+			}
+			seen, seenAt = append(seen, T), append(seenAt, e)
+			if x.mode != invalid && T != Typ[UntypedNil] {
+				c.typeAssertion(e, &x, T, true)
+			}
+		}
+
+		if obj := c.info.Implicits[clause]; obj != nil {
+			typ := x.typ
+			if len(clause.List) == 1 && T != nil && T != Typ[UntypedNil] {
+				typ = T
+			}
+			if x.mode == invalid {
+				typ = Typ[Invalid]
+			}
+			c.recordObject(obj, typ)
+		}
+		c.stmtList(clause.Body)
+	}
+
+	// the variable is declared in each clause, and used if it is used in
+	// any of them
+	if s.Binding != nil && s.Binding.Value != "_" {
+		used := false
+		for _, clause := range s.Cases {
+			if obj := c.info.Implicits[clause]; obj != nil && c.used[obj] {
+				used = true
+			}
+		}
+		if !used {
+			c.errorf(s.Binding, "%s declared and not used", s.Binding.Value)
+		}
+	}
+}
+
+// rangeStmt checks a range loop and declares or assigns its iteration
+// variables.
+func (c *Checker) rangeStmt(s *ast.RangeStmt) {
+	var x operand
+	c.expr(&x, s.X)
+
+	var key, val Type
+	if x.mode != invalid {
+		k, v, cause, ok := rangeKeyVal(Default(x.typ))
+		switch {
+		case !ok && cause != "":
+			c.errorf(s.X, "cannot range over %s: %s", &x, cause)
+		case !ok:
+			c.errorf(s.X, "cannot range over %s", &x)
+		case s.Key != nil && k == nil:
+			c.errorf(s.Key, "range over %s permits no iteration variables", &x)
+		case s.Value != nil && v == nil:
+			c.errorf(s.Value, "range over %s permits only one iteration variable", &x)
+		default:
+			if isUntyped(x.typ) {
+				c.assignment(&x, nil, "range clause")
+			}
+			key, val = k, v
+		}
+	}
+
+	lhs := []ast.Expression{s.Key, s.Value}
+	rhs := []Type{key, val}
+	if s.Tok.Type == lexer.DEFINE {
+		for i, e := range lhs {
+			id, ok := e.(*ast.Identifier)
+			if !ok {
+				continue
+			}
+			typ := rhs[i]
+			if typ == nil {
+				typ = Typ[Invalid]
+			}
+			c.recordObject(c.info.Defs[id], typ)
+		}
+	} else {
+		for i, e := range lhs {
+			if e == nil || rhs[i] == nil {
+				continue
+			}
+			x := operand{mode: value, expr: e, typ: rhs[i]}
+			c.assignVar(e, &x, "range clause")
+		}
+	}
+	if s.Body != nil {
+		c.stmt(s.Body)
+	}
+}
+
+// rangeKeyVal returns the types of the iteration variables of a range over
+// a value of type typ, which are nil if it permits fewer than two; ok is
+// false if typ cannot be ranged over, possibly with a cause.
+func rangeKeyVal(typ Type) (key, val Type, cause string, ok bool) {
+	u := coreTypeOrString(typ)
+	if u == nil {
+		return nil, nil, "no core type", false
+	}
+	switch t := arrayPtrDeref(u).(type) {
+	case *Basic:
+		if isString(t) {
+			return Typ[Int], universeRune, "", true
+		}
+		if isInteger(t) {
+			return typ, nil, "", true
+		}
+	case *Array:
+		return Typ[Int], t.Elem, "", true
+	case *Slice:
+		return Typ[Int], t.Elem, "", true
+	case *Map:
+		return t.Key, t.Elem, "", true
+	case *Chan:
+		if t.Dir == ast.SEND {
+			return nil, nil, "receive from send-only channel", false
+		}
+		return t.Elem, nil, "", true
+	case *Signature:
+		// func(yield func(K, V) bool)
+		const want = "func must be func(yield func(...) bool)"
+		switch {
+		case t.Params.Len() != 1:
+			return nil, nil, want + ": wrong argument count", false
+		case t.Results.Len() != 0:
+			return nil, nil, want + ": func returns values", false
+		}
+		yield, ok := coreType(t.Params.Vars[0].Type).(*Signature)
+		switch {
+		case !ok:
+			return nil, nil, want + ": argument is not func", false
+		case yield.Params.Len() > 2:
+			return nil, nil, want + ": yield func has too many parameters", false
+		case yield.Results.Len() != 1 || !isBoolean(yield.Results.Vars[0].Type):
+			return nil, nil, want + ": yield func does not return bool", false
+		}
+		if yield.Params.Len() >= 1 {
+			key = yield.Params.Vars[0].Type
+		}
+		if yield.Params.Len() >= 2 {
+			val = yield.Params.Vars[1].Type
+		}
+		return key, val, "", true
+	}
+	return nil, nil, "", false
+}
@@ -0,0 +1,224 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE-GO file.
+//
+// Adapted from go/types (cmd/compile/internal/types2).
+
+package types
+
+// Generics: the substitution of type arguments for type parameters, and the
+// inference of the type arguments of a call from its arguments.
+
+// A substMap maps type parameters to the types substituted for them.
+type substMap map[*TypeParam]Type
+
+func makeSubstMap(params []*TypeParam, args []Type) substMap {
+	smap := make(substMap, len(params))
+	for i, tp := range params {
+		if i < len(args) {
+			smap[tp] = args[i]
+		}
+	}
+	return smap
+}
+
+// instantiate returns the instance of the generic type orig with the given
+// type arguments. Its underlying type is substituted when first needed, so
+// that a generic type can refer to its own instances.
+func instantiate(orig *Named, targs []Type) *Named {
+	return &Named{Obj: orig.Obj, TypeParams: orig.TypeParams, TypeArgs: targs, Orig: orig}
+}
+
+// subst returns t with the types of smap substituted for its type
+// parameters. Parts of t without type parameters are shared, not copied.
+func subst(t Type, smap substMap) Type {
+	if len(smap) == 0 {
+		return t
+	}
+	switch t := t.(type) {
+	case *TypeParam:
+		if u, ok := smap[t]; ok {
+			return u
+		}
+	case *Pointer:
+		if elem := subst(t.Elem, smap); elem != t.Elem {
+			return &Pointer{Elem: elem}
+		}
+	case *Array:
+		if elem := subst(t.Elem, smap); elem != t.Elem {
+			return &Array{Len: t.Len, Elem: elem}
+		}
+	case *Slice:
+		if elem := subst(t.Elem, smap); elem != t.Elem {
+			return &Slice{Elem: elem}
+		}
+	case *Map:
+		key, elem := subst(t.Key, smap), subst(t.Elem, smap)
+		if key != t.Key || elem != t.Elem {
+			return &Map{Key: key, Elem: elem}
+		}
+	case *Chan:
+		if elem := subst(t.Elem, smap); elem != t.Elem {
+			return &Chan{Dir: t.Dir, Elem: elem}
+		}
+	case *Struct:
+		if fields, changed := substVars(t.Fields, smap); changed {
+			return &Struct{Fields: fields, Tags: t.Tags}
+		}
+	case *Tuple:
+		if t == nil {
+			return t
+		}
+		if vars, changed := substVars(t.Vars, smap); changed {
+			return &Tuple{Vars: vars}
+		}
+	case *Signature:
+		params := subst(t.Params, smap).(*Tuple)
+		results := subst(t.Results, smap).(*Tuple)
+		if params != t.Params || results != t.Results {
+			sig := *t
+			sig.Params, sig.Results = params, results
+			return &sig
+		}
+	case *Interface:
+		changed := false
+		methods := make([]*Func, len(t.Methods))
+		for i, m := range t.Methods {
+			sig := subst(m.Sig, smap).(*Signature)
+			if sig != m.Sig {
+				changed = true
+			}
+			methods[i] = &Func{Name: m.Name, Sig: sig, PtrRecv: m.PtrRecv, Obj: m.Obj}
+		}
+		embeddeds := make([]Type, len(t.Embeddeds))
+		for i, e := range t.Embeddeds {
+			embeddeds[i] = subst(e, smap)
+			if embeddeds[i] != e {
+				changed = true
+			}
+		}
+		if changed {
+			return &Interface{Methods: methods, Embeddeds: embeddeds}
+		}
+	case *Union:
+		changed := false
+		terms := make([]*Term, len(t.Terms))
+		for i, term := range t.Terms {
+			terms[i] = &Term{Tilde: term.Tilde, Type: subst(term.Type, smap)}
+			if terms[i].Type != term.Type {
+				changed = true
+			}
+		}
+		if changed {
+			return &Union{Terms: terms}
+		}
+	case *Named:
+		if len(t.TypeArgs) == 0 {
+			return t
+		}
+		changed := false
+		targs := make([]Type, len(t.TypeArgs))
+		for i, arg := range t.TypeArgs {
+			targs[i] = subst(arg, smap)
+			if targs[i] != arg {
+				changed = true
+			}
+		}
+		if changed {
+			return instantiate(t.Orig, targs)
+		}
+	}
+	return t
+}
+
+func substVars(vars []*Var, smap substMap) ([]*Var, bool) {
+	changed := false
+	list := make([]*Var, len(vars))
+	for i, v := range vars {
+		typ := subst(v.Type, smap)
+		if typ != v.Type {
+			changed = true
+		}
+		list[i] = &Var{Name: v.Name, Type: typ, Embedded: v.Embedded, Obj: v.Obj}
+	}
+	return list, changed
+}
+
+// unify matches the parameter type x, which may mention the type parameters
+// of smap, against the argument type y, recording the type inferred for each
+// type parameter met in smap. It reports whether the types match.
+func unify(x, y Type, smap substMap) bool {
+	if tp, ok := x.(*TypeParam); ok {
+		if _, isParam := smap[tp]; isParam {
+			if bound := smap[tp]; bound != nil {
+				return Identical(bound, y) || (!isNamedOrBasic(bound) && unify(under(bound), under(y), smap))
+			}
+			smap[tp] = y
+			return true
+		}
+	}
+	if x == y {
+		return true
+	}
+	// a named argument matches an unnamed parameter type by its underlying
+	// type, as in assignments
+	if _, ok := y.(*Named); ok {
+		if _, ok := x.(*Named); !ok {
+			y = under(y)
+		}
+	}
+	switch x := x.(type) {
+	case *Pointer:
+		if y, ok := y.(*Pointer); ok {
+			return unify(x.Elem, y.Elem, smap)
+		}
+	case *Array:
+		if y, ok := y.(*Array); ok {
+			return unify(x.Elem, y.Elem, smap)
+		}
+	case *Slice:
+		if y, ok := y.(*Slice); ok {
+			return unify(x.Elem, y.Elem, smap)
+		}
+	case *Map:
+		if y, ok := y.(*Map); ok {
+			return unify(x.Key, y.Key, smap) && unify(x.Elem, y.Elem, smap)
+		}
+	case *Chan:
+		if y, ok := y.(*Chan); ok {
+			return unify(x.Elem, y.Elem, smap)
+		}
+	case *Signature:
+		if y, ok := y.(*Signature); ok && x.Params.Len() == y.Params.Len() && x.Results.Len() == y.Results.Len() {
+			for i, v := range x.Params.vars() {
+				if !unify(v.Type, y.Params.Vars[i].Type, smap) {
+					return false
+				}
+			}
+			for i, v := range x.Results.vars() {
+				if !unify(v.Type, y.Results.Vars[i].Type, smap) {
+					return false
+				}
+			}
+			return true
+		}
+	case *Named:
+		if y, ok := y.(*Named); ok && x.Orig == y.Orig && len(x.TypeArgs) == len(y.TypeArgs) {
+			for i, arg := range x.TypeArgs {
+				if !unify(arg, y.TypeArgs[i], smap) {
+					return false
+				}
+			}
+			return true
+		}
+	}
+	return Identical(x, y)
+}
+
+func isNamedOrBasic(t Type) bool {
+	switch t.(type) {
+	case *Named, *Basic, *TypeParam:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,513 @@
+// Copyright 2011 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE-GO file.
+//
+// Adapted from go/types (cmd/compile/internal/types2).
+
+package types
+
+import (
+	"strconv"
+	"strings"
+
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+	resolve "github.com/mohit-bhandari45/Compiler-GO.git/internal/resolve"
+)
+
+// A Type is a Go type. Types are compared with Identical, not with ==, except
+// for the basic types, of which there is one value per kind (and alias).
+type Type interface {
+	// Underlying returns the underlying type: the type itself for all but
+	// named types and type parameters.
+	Underlying() Type
+
+	// String returns the type as it is written in Go source.
+	String() string
+}
+
+// ----------------------------------------------------------------------------
+// Basic types
+
+// BasicKind describes the kind of a basic type.
+type BasicKind int
+
+const (
+	Invalid BasicKind = iota // the type of an invalid operand, silently accepted to avoid follow-up errors
+
+	// predeclared types
+	Bool
+	Int
+	Int8
+	Int16
+	Int32
+	Int64
+	Uint
+	Uint8
+	Uint16
+	Uint32
+	Uint64
+	Uintptr
+	Float32
+	Float64
+	Complex64
+	Complex128
+	String
+
+	// types of untyped values
+	UntypedBool
+	UntypedInt
+	UntypedRune
+	UntypedFloat
+	UntypedComplex
+	UntypedString
+	UntypedNil
+
+	// aliases
+	Byte = Uint8
+	Rune = Int32
+)
+
+// BasicInfo is a set of flags describing the properties of a basic type.
+type BasicInfo int
+
+const (
+	IsBoolean BasicInfo = 1 << iota
+	IsInteger
+	IsUnsigned
+	IsFloat
+	IsComplex
+	IsString
+	IsUntyped
+
+	IsOrdered   = IsInteger | IsFloat | IsString
+	IsNumeric   = IsInteger | IsFloat | IsComplex
+	IsConstType = IsBoolean | IsNumeric | IsString
+)
+
+// A Basic is a predeclared type, or the type of an untyped value.
+type Basic struct {
+	kind BasicKind
+	info BasicInfo
+	name string
+}
+
+func (b *Basic) Kind() BasicKind  { return b.kind }
+func (b *Basic) Info() BasicInfo  { return b.info }
+func (b *Basic) Name() string     { return b.name }
+func (b *Basic) Underlying() Type { return b }
+func (b *Basic) String() string   { return b.name }
+
+// Typ holds the basic types, indexed by kind.
+var Typ = [...]*Basic{
+	Invalid: {Invalid, 0, "invalid type"},
+
+	Bool:       {Bool, IsBoolean, "bool"},
+	Int:        {Int, IsInteger, "int"},
+	Int8:       {Int8, IsInteger, "int8"},
+	Int16:      {Int16, IsInteger, "int16"},
+	Int32:      {Int32, IsInteger, "int32"},
+	Int64:      {Int64, IsInteger, "int64"},
+	Uint:       {Uint, IsInteger | IsUnsigned, "uint"},
+	Uint8:      {Uint8, IsInteger | IsUnsigned, "uint8"},
+	Uint16:     {Uint16, IsInteger | IsUnsigned, "uint16"},
+	Uint32:     {Uint32, IsInteger | IsUnsigned, "uint32"},
+	Uint64:     {Uint64, IsInteger | IsUnsigned, "uint64"},
+	Uintptr:    {Uintptr, IsInteger | IsUnsigned, "uintptr"},
+	Float32:    {Float32, IsFloat, "float32"},
+	Float64:    {Float64, IsFloat, "float64"},
+	Complex64:  {Complex64, IsComplex, "complex64"},
+	Complex128: {Complex128, IsComplex, "complex128"},
+	String:     {String, IsString, "string"},
+
+	UntypedBool:    {UntypedBool, IsBoolean | IsUntyped, "untyped bool"},
+	UntypedInt:     {UntypedInt, IsInteger | IsUntyped, "untyped int"},
+	UntypedRune:    {UntypedRune, IsInteger | IsUntyped, "untyped rune"},
+	UntypedFloat:   {UntypedFloat, IsFloat | IsUntyped, "untyped float"},
+	UntypedComplex: {UntypedComplex, IsComplex | IsUntyped, "untyped complex"},
+	UntypedString:  {UntypedString, IsString | IsUntyped, "untyped string"},
+	UntypedNil:     {UntypedNil, IsUntyped, "untyped nil"},
+}
+
+// The aliases byte and rune are distinct values, so that types print as
+// they were written, but identical to uint8 and int32.
+var (
+	universeByte = &Basic{Byte, IsInteger | IsUnsigned, "byte"}
+	universeRune = &Basic{Rune, IsInteger, "rune"}
+)
+
+// ----------------------------------------------------------------------------
+// Composite types
+
+// A Pointer is a pointer type *Elem.
+type Pointer struct {
+	Elem Type
+}
+
+func (p *Pointer) Underlying() Type { return p }
+func (p *Pointer) String() string   { return typeString(p) }
+
+// An Array is an array type [Len]Elem. Len is -1 if the length is not known,
+// because it is not a constant the checker can evaluate.
+type Array struct {
+	Len  int64
+	Elem Type
+}
+
+func (a *Array) Underlying() Type { return a }
+func (a *Array) String() string   { return typeString(a) }
+
+// A Slice is a slice type []Elem.
+type Slice struct {
+	Elem Type
+}
+
+func (s *Slice) Underlying() Type { return s }
+func (s *Slice) String() string   { return typeString(s) }
+
+// A Map is a map type map[Key]Elem.
+type Map struct {
+	Key, Elem Type
+}
+
+func (m *Map) Underlying() Type { return m }
+func (m *Map) String() string   { return typeString(m) }
+
+// A Chan is a channel type. Dir is the set of permitted operations, as in the
+// syntax tree: ast.SEND, ast.RECV, or both.
+type Chan struct {
+	Dir  ast.ChanDir
+	Elem Type
+}
+
+func (c *Chan) Underlying() Type { return c }
+func (c *Chan) String() string   { return typeString(c) }
+
+// A Var is a struct field, or a parameter or result of a signature.
+type Var struct {
+	Name     string          // "" for an unnamed parameter
+	Type     Type            //
+	Embedded bool            // an embedded struct field
+	Obj      *resolve.Object // the declared object; nil for fields, and for parameters without names
+}
+
+// A Struct is a struct type.
+type Struct struct {
+	Fields []*Var
+	Tags   []string // the field tags, if any field has one; else nil
+}
+
+func (s *Struct) Underlying() Type { return s }
+func (s *Struct) String() string   { return typeString(s) }
+
+// A Tuple is an ordered list of variables: the parameters or results of a
+// signature, or the type of a call with several results.
+type Tuple struct {
+	Vars []*Var
+}
+
+// Len returns the number of variables of t, which may be nil.
+func (t *Tuple) Len() int {
+	if t == nil {
+		return 0
+	}
+	return len(t.Vars)
+}
+
+// At returns the i'th variable of t.
+func (t *Tuple) At(i int) *Var { return t.Vars[i] }
+
+func (t *Tuple) Underlying() Type { return t }
+func (t *Tuple) String() string   { return typeString(t) }
+
+// A Signature is a function type. The receiver of a method is not part of
+// its type, but is recorded so that the signature can be printed.
+type Signature struct {
+	Recv           *Var
+	RecvTypeParams []*TypeParam // the type parameters named by the receiver of a method of a generic type
+	TypeParams     []*TypeParam // for a generic function
+	Params         *Tuple
+	Results        *Tuple
+	Variadic       bool // the last parameter is ...T, and has type []T
+}
+
+func (s *Signature) Underlying() Type { return s }
+func (s *Signature) String() string   { return typeString(s) }
+
+// A Func is a method: declared with a receiver, or in an interface.
+type Func struct {
+	Name    string
+	Sig     *Signature
+	PtrRecv bool            // the receiver is a pointer
+	Obj     *resolve.Object // the declared object; nil for interface methods
+}
+
+// A Term is an element of a union in a constraint: T or ~T.
+type Term struct {
+	Tilde bool
+	Type  Type
+}
+
+// An Interface is an interface type. Methods and Embeddeds are as written;
+// the method set, including the methods of embedded interfaces, and the
+// type set of a constraint are computed when first needed.
+type Interface struct {
+	Methods   []*Func
+	Embeddeds []Type // embedded interfaces, and union or ~T elements (as *Union)
+
+	completed  bool
+	allMethods []*Func // sorted by name
+	terms      []*Term // the permitted types; nil if unrestricted
+	comparable bool    // the type set is restricted to comparable types
+}
+
+func (t *Interface) Underlying() Type { return t }
+func (t *Interface) String() string   { return typeString(t) }
+
+// A Union is a union of terms, which may only appear in constraints.
+type Union struct {
+	Terms []*Term
+}
+
+func (u *Union) Underlying() Type { return u }
+func (u *Union) String() string   { return typeString(u) }
+
+// A Named is a defined type: a type declared with a name, possibly generic
+// (TypeParams) or an instance of a generic type (TypeArgs, Orig).
+type Named struct {
+	Obj        *resolve.Object
+	TypeParams []*TypeParam
+	TypeArgs   []Type
+	Orig       *Named // the generic type of an instance; the type itself otherwise
+	Methods    []*Func
+
+	underlying Type // nil while being declared
+}
+
+// Underlying returns the underlying type of n, substituting the type
+// arguments of an instance.
+func (n *Named) Underlying() Type {
+	if n.underlying == nil && n.Orig != n && n.Orig.underlying != nil {
+		n.underlying = subst(n.Orig.underlying, makeSubstMap(n.Orig.TypeParams, n.TypeArgs))
+	}
+	if n.underlying == nil {
+		return Typ[Invalid]
+	}
+	return n.underlying
+}
+
+func (n *Named) String() string { return typeString(n) }
+
+// A TypeParam is a type parameter of a generic function or type.
+type TypeParam struct {
+	Obj        *resolve.Object
+	Index      int
+	Constraint Type // an interface, or a type that stands for interface{T}
+}
+
+// Underlying returns the constraint's interface.
+func (t *TypeParam) Underlying() Type { return t.iface() }
+func (t *TypeParam) String() string   { return t.Obj.Name }
+
+func (t *TypeParam) iface() *Interface {
+	switch u := under(t.Constraint).(type) {
+	case *Interface:
+		return u
+	case *Basic:
+		if u.kind == Invalid {
+			return &Interface{completed: true}
+		}
+	}
+	// a constraint written as a plain type or union, as in [T ~int]
+	return &Interface{Embeddeds: []Type{t.Constraint}}
+}
+
+// ----------------------------------------------------------------------------
+// Printing
+
+func typeString(t Type) string {
+	var buf strings.Builder
+	writeType(&buf, t, nil)
+	return buf.String()
+}
+
+// writeType writes t to buf; seen guards against recursive interfaces and
+// structs, which can only recur through named types, but are printed
+// structurally.
+func writeType(buf *strings.Builder, t Type, seen []Type) {
+	for _, s := range seen {
+		if s == t {
+			buf.WriteString("…")
+			return
+		}
+	}
+	seen = append(seen, t)
+	switch t := t.(type) {
+	case nil:
+		buf.WriteString("<nil>")
+	case *Basic:
+		buf.WriteString(t.name)
+	case *Pointer:
+		buf.WriteString("*")
+		writeType(buf, t.Elem, seen)
+	case *Array:
+		if t.Len < 0 {
+			buf.WriteString("[?]")
+		} else {
+			buf.WriteString("[" + strconv.FormatInt(t.Len, 10) + "]")
+		}
+		writeType(buf, t.Elem, seen)
+	case *Slice:
+		buf.WriteString("[]")
+		writeType(buf, t.Elem, seen)
+	case *Map:
+		buf.WriteString("map[")
+		writeType(buf, t.Key, seen)
+		buf.WriteString("]")
+		writeType(buf, t.Elem, seen)
+	case *Chan:
+		parens := false
+		switch t.Dir {
+		case ast.SEND:
+			buf.WriteString("chan<- ")
+		case ast.RECV:
+			buf.WriteString("<-chan ")
+		default:
+			buf.WriteString("chan ")
+			// chan (<-chan T) is not chan<- (chan T)
+			if c, ok := t.Elem.(*Chan); ok && c.Dir == ast.RECV {
+				parens = true
+			}
+		}
+		if parens {
+			buf.WriteString("(")
+		}
+		writeType(buf, t.Elem, seen)
+		if parens {
+			buf.WriteString(")")
+		}
+	case *Struct:
+		buf.WriteString("struct{")
+		for i, f := range t.Fields {
+			if i > 0 {
+				buf.WriteString("; ")
+			}
+			if !f.Embedded {
+				buf.WriteString(f.Name + " ")
+			}
+			writeType(buf, f.Type, seen)
+			if t.Tags != nil && t.Tags[i] != "" {
+				buf.WriteString(" " + strconv.Quote(t.Tags[i]))
+			}
+		}
+		buf.WriteString("}")
+	case *Tuple:
+		writeTuple(buf, t, false, seen)
+	case *Signature:
+		buf.WriteString("func")
+		writeSignature(buf, t, seen)
+	case *Interface:
+		if t == universeAny {
+			buf.WriteString("any")
+			return
+		}
+		buf.WriteString("interface{")
+		first := true
+		for _, m := range t.Methods {
+			if !first {
+				buf.WriteString("; ")
+			}
+			first = false
+			buf.WriteString(m.Name)
+			writeSignature(buf, m.Sig, seen)
+		}
+		for _, e := range t.Embeddeds {
+			if !first {
+				buf.WriteString("; ")
+			}
+			first = false
+			writeType(buf, e, seen)
+		}
+		if first && t.comparable && t.completed && len(t.Embeddeds) == 0 {
+			buf.WriteString("comparable")
+		}
+		buf.WriteString("}")
+	case *Union:
+		for i, term := range t.Terms {
+			if i > 0 {
+				buf.WriteString(" | ")
+			}
+			if term.Tilde {
+				buf.WriteString("~")
+			}
+			writeType(buf, term.Type, seen)
+		}
+	case *Named:
+		buf.WriteString(t.Obj.Name)
+		if len(t.TypeArgs) > 0 {
+			buf.WriteString("[")
+			for i, arg := range t.TypeArgs {
+				if i > 0 {
+					buf.WriteString(", ")
+				}
+				writeType(buf, arg, seen)
+			}
+			buf.WriteString("]")
+		}
+	case *TypeParam:
+		buf.WriteString(t.Obj.Name)
+	default:
+		buf.WriteString("<unknown type>")
+	}
+}
+
+func writeTuple(buf *strings.Builder, t *Tuple, variadic bool, seen []Type) {
+	buf.WriteString("(")
+	for i, v := range t.vars() {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		if v.Name != "" {
+			buf.WriteString(v.Name + " ")
+		}
+		if variadic && i == t.Len()-1 {
+			buf.WriteString("...")
+			if s, ok := v.Type.(*Slice); ok {
+				writeType(buf, s.Elem, seen)
+				continue
+			}
+		}
+		writeType(buf, v.Type, seen)
+	}
+	buf.WriteString(")")
+}
+
+func writeSignature(buf *strings.Builder, sig *Signature, seen []Type) {
+	if len(sig.TypeParams) > 0 {
+		buf.WriteString("[")
+		for i, tp := range sig.TypeParams {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteString(tp.Obj.Name + " ")
+			writeType(buf, tp.Constraint, seen)
+		}
+		buf.WriteString("]")
+	}
+	writeTuple(buf, sig.Params, sig.Variadic, seen)
+	switch n := sig.Results.Len(); {
+	case n == 0:
+	case n == 1 && sig.Results.Vars[0].Name == "":
+		buf.WriteString(" ")
+		writeType(buf, sig.Results.Vars[0].Type, seen)
+	default:
+		buf.WriteString(" ")
+		writeTuple(buf, sig.Results, false, seen)
+	}
+}
+
+func (t *Tuple) vars() []*Var {
+	if t == nil {
+		return nil
+	}
+	return t.Vars
+}
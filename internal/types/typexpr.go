@@ -0,0 +1,377 @@
+// Copyright 2013 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE-GO file.
+//
+// Adapted from go/types (cmd/compile/internal/types2).
+
+package types
+
+import (
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+	constant "github.com/mohit-bhandari45/Compiler-GO.git/internal/constant"
+)
+
+// typExpr checks the type expression e and returns the type it denotes. A
+// generic type must be instantiated.
+func (c *Checker) typExpr(e ast.Expression) Type {
+	typ := c.typInternal(e)
+	if n, ok := typ.(*Named); ok && len(n.TypeParams) > 0 && len(n.TypeArgs) == 0 {
+		c.errorf(e, "cannot use generic type %s without instantiation", n.Obj.Name)
+		typ = Typ[Invalid]
+	}
+	c.info.Types[e] = typ
+	return typ
+}
+
+// varType checks the type of a variable, parameter or field, which may not
+// be a constraint interface.
+func (c *Checker) varType(e ast.Expression) Type {
+	typ := c.typExpr(e)
+	if t, ok := under(typ).(*Interface); ok && !isTypeParam(typ) && t.isConstraint() {
+		c.errorf(e, "cannot use type %s outside a type constraint: interface contains type constraints", typ)
+		return Typ[Invalid]
+	}
+	return typ
+}
+
+func (c *Checker) typInternal(e ast.Expression) Type {
+	switch e := e.(type) {
+	case nil:
+		return Typ[Invalid]
+
+	case *ast.ParenExpr:
+		return c.typExpr(e.X)
+
+	case *ast.ArrayType:
+		if e.Len == nil {
+			return &Slice{Elem: c.varType(e.Elt)}
+		}
+		if _, ok := e.Len.(*ast.Ellipsis); ok {
+			c.errorf(e.Len, "invalid use of [...] array (outside a composite literal)")
+			c.varType(e.Elt)
+			return Typ[Invalid]
+		}
+		return &Array{Len: c.arrayLength(e.Len), Elem: c.varType(e.Elt)}
+
+	case *ast.Ellipsis:
+		c.errorf(e, "invalid use of ...")
+		c.varType(e.Elt)
+		return Typ[Invalid]
+
+	case *ast.StarExpr:
+		return &Pointer{Elem: c.varType(e.X)}
+
+	case *ast.MapType:
+		key, elem := c.varType(e.Key), c.varType(e.Value)
+		// the key type may not be known yet while its declaration is
+		// checked, as in type T map[T]int; it is then accepted
+		if n, ok := key.(*Named); !ok || n.underlying != nil {
+			if isValid(key) && !isTypeParam(key) && !comparable(key) {
+				c.errorf(e.Key, "invalid map key type %s", key)
+			} else if tp, ok := key.(*TypeParam); ok {
+				c.later(func() {
+					if !comparable(tp) {
+						c.errorf(e.Key, "invalid map key type %s (missing comparable constraint)", key)
+					}
+				})
+			}
+		}
+		return &Map{Key: key, Elem: elem}
+
+	case *ast.ChanType:
+		return &Chan{Dir: e.Dir, Elem: c.varType(e.Value)}
+
+	case *ast.FuncType:
+		return c.funcType(nil, nil, e)
+
+	case *ast.StructType:
+		return c.structType(e)
+
+	case *ast.InterfaceType:
+		return c.interfaceType(e)
+
+	case *ast.InfixExpression, *ast.PrefixExpression:
+		if isUnionExpr(e) {
+			c.errorf(e, "cannot use %s outside a type constraint", exprString(e))
+			c.unionType(e)
+			return Typ[Invalid]
+		}
+	}
+
+	// an identifier, qualified identifier, or an expression that is not
+	// a type
+	var x operand
+	c.exprOrType(&x, e)
+	switch x.mode {
+	case invalid:
+		return Typ[Invalid]
+	case typexpr:
+		return x.typ
+	}
+	c.errorf(e, "%s is not a type", &x)
+	return Typ[Invalid]
+}
+
+// arrayLength returns the length of an array type, or -1 if it is not known.
+// The length must be a non-negative constant representable by an int.
+func (c *Checker) arrayLength(e ast.Expression) int64 {
+	var x operand
+	c.expr(&x, e)
+	if x.mode != constant_ {
+		if x.mode != invalid {
+			c.errorf(e, "array length %s must be constant", &x)
+		}
+		return -1
+	}
+	if isUntyped(x.typ) || isInteger(x.typ) {
+		if val := constant.ToInt(x.val); val.Kind() == constant.Int && representableConst(val, Typ[Int], nil) {
+			if n, ok := constant.Int64Val(val); ok && n >= 0 {
+				if isUntyped(x.typ) {
+					c.updateExprType(e, Typ[Int])
+					c.updateExprVal(e, val)
+				}
+				return n
+			}
+		}
+	}
+	if isInteger(x.typ) {
+		c.errorf(e, "invalid array length %s", &x)
+	} else {
+		c.errorf(e, "array length %s must be integer", &x)
+	}
+	return -1
+}
+
+// structType checks a struct type. An embedded field is named after its
+// type name.
+func (c *Checker) structType(e *ast.StructType) *Struct {
+	s := &Struct{}
+	if e.Fields == nil {
+		return s
+	}
+	seen := make(map[string]ast.Node)
+	hasTags := false
+	add := func(name string, at ast.Node, typ Type, embedded bool, tag string) {
+		if name != "_" {
+			if alt, ok := seen[name]; ok {
+				c.errorf(at, "%s redeclared\n\t%s: other declaration of %s", name, c.position(alt.Pos()), name)
+				return
+			}
+			seen[name] = at
+		}
+		s.Fields = append(s.Fields, &Var{Name: name, Type: typ, Embedded: embedded})
+		s.Tags = append(s.Tags, tag)
+		if tag != "" {
+			hasTags = true
+		}
+	}
+	for _, f := range e.Fields.List {
+		typ := c.varType(f.Type)
+		tag := ""
+		if f.Tag != nil {
+			tag = f.Tag.Value
+		}
+		if len(f.Names) > 0 {
+			for _, name := range f.Names {
+				add(name.Value, name, typ, false, tag)
+			}
+			continue
+		}
+		name := embeddedFieldName(f.Type)
+		if name == nil {
+			c.errorf(f.Type, "invalid embedded field type %s", exprString(f.Type))
+			continue
+		}
+		if isValid(typ) {
+			t := typ
+			if p, ok := typ.(*Pointer); ok {
+				t = p.Elem
+				switch under(t).(type) {
+				case *Pointer:
+					c.errorf(f.Type, "embedded field type cannot be a pointer")
+				case *Interface:
+					if !isTypeParam(t) {
+						c.errorf(f.Type, "embedded field type cannot be a pointer to an interface")
+					}
+				}
+			}
+			if isTypeParam(t) {
+				c.errorf(f.Type, "embedded field type cannot be a (pointer to a) type parameter")
+			} else if _, ok := under(t).(*Pointer); ok {
+				if _, named := t.(*Named); named {
+					c.errorf(f.Type, "embedded field type cannot be a pointer")
+				}
+			}
+		}
+		add(name.Value, name, typ, true, tag)
+	}
+	if !hasTags {
+		s.Tags = nil
+	}
+	return s
+}
+
+// embeddedFieldName returns the type name of an embedded field type, T, *T,
+// p.T or T[A], or nil if it is not one of those.
+func embeddedFieldName(e ast.Expression) *ast.Identifier {
+	switch e := e.(type) {
+	case *ast.Identifier:
+		return e
+	case *ast.StarExpr:
+		if _, ok := e.X.(*ast.StarExpr); !ok {
+			return embeddedFieldName(e.X)
+		}
+	case *ast.SelectorExpr:
+		return e.Sel
+	case *ast.IndexExpr:
+		return embeddedFieldName(e.X)
+	case *ast.IndexListExpr:
+		return embeddedFieldName(e.X)
+	case *ast.ParenExpr:
+		return embeddedFieldName(e.X)
+	}
+	return nil
+}
+
+// interfaceType checks an interface type: its methods, embedded interfaces,
+// and the type elements of a constraint.
+func (c *Checker) interfaceType(e *ast.InterfaceType) *Interface {
+	t := &Interface{}
+	if e.Methods == nil {
+		return t
+	}
+	seen := make(map[string]ast.Node)
+	for _, f := range e.Methods.List {
+		if len(f.Names) == 0 {
+			// an embedded element
+			if isUnionExpr(f.Type) {
+				u := c.unionType(f.Type)
+				c.info.Types[f.Type] = u
+				t.Embeddeds = append(t.Embeddeds, u)
+				continue
+			}
+			typ := c.typExpr(f.Type)
+			if isTypeParam(typ) {
+				c.errorf(f.Type, "term cannot be a type parameter")
+				continue
+			}
+			t.Embeddeds = append(t.Embeddeds, typ)
+			continue
+		}
+		ftyp, _ := f.Type.(*ast.FuncType)
+		if ftyp == nil {
+			c.errorf(f.Type, "%s is not a method signature", exprString(f.Type))
+			continue
+		}
+		sig := c.funcType(nil, nil, ftyp)
+		for _, name := range f.Names {
+			if name.Value == "_" {
+				c.errorf(name, "methods must have a unique non-blank name")
+				continue
+			}
+			if alt, ok := seen[name.Value]; ok {
+				c.errorf(name, "duplicate method %s\n\t%s: other declaration of method %s", name.Value, c.position(alt.Pos()), name.Value)
+				continue
+			}
+			seen[name.Value] = name
+			t.Methods = append(t.Methods, &Func{Name: name.Value, Sig: sig})
+		}
+	}
+	return t
+}
+
+// isUnionExpr reports whether e is a union of terms or a ~T term, which can
+// only appear in constraints.
+func isUnionExpr(e ast.Expression) bool {
+	switch e := unparen(e).(type) {
+	case *ast.InfixExpression:
+		return e.Operator == "|"
+	case *ast.PrefixExpression:
+		return e.Operator == "~"
+	}
+	return false
+}
+
+// unionType checks a union of terms such as ~int | ~string.
+func (c *Checker) unionType(e ast.Expression) *Union {
+	u := &Union{}
+	var collect func(e ast.Expression)
+	collect = func(e ast.Expression) {
+		if x, ok := unparen(e).(*ast.InfixExpression); ok && x.Operator == "|" {
+			collect(x.Left)
+			collect(x.Right)
+			return
+		}
+		term := &Term{}
+		if x, ok := unparen(e).(*ast.PrefixExpression); ok && x.Operator == "~" {
+			term.Tilde = true
+			term.Type = c.typExpr(x.Right)
+			c.info.Types[x] = term.Type
+		} else {
+			term.Type = c.typExpr(e)
+		}
+		if !isValid(term.Type) {
+			return
+		}
+		if isTypeParam(term.Type) {
+			c.errorf(e, "term cannot be a type parameter")
+			return
+		}
+		if term.Tilde && !Identical(term.Type, under(term.Type)) {
+			c.errorf(e, "invalid use of ~ (underlying type of %s is %s)", term.Type, under(term.Type))
+			return
+		}
+		if t, ok := under(term.Type).(*Interface); ok && len(t.methodSet()) > 0 {
+			c.errorf(e, "cannot use %s in union (%s contains methods)", term.Type, term.Type)
+			return
+		}
+		if term.Type == universeComparable {
+			c.errorf(e, "cannot use comparable in union")
+			return
+		}
+		for _, t := range u.Terms {
+			if t.Tilde == term.Tilde && Identical(t.Type, term.Type) || t.Tilde && termIncludes(t, term.Type) || term.Tilde && termIncludes(term, t.Type) {
+				c.errorf(e, "overlapping terms %s and %s", termListString([]*Term{term}), termListString([]*Term{t}))
+				return
+			}
+		}
+		u.Terms = append(u.Terms, term)
+	}
+	collect(e)
+	return u
+}
+
+// validTypeArgs reports whether the type arguments targs, written as args,
+// satisfy the constraints of the type parameters of the generic type or
+// function name, reporting the first that does not.
+func (c *Checker) validTypeArgs(at ast.Node, name string, tparams []*TypeParam, targs []Type, args []ast.Expression) bool {
+	switch {
+	case len(targs) < len(tparams):
+		c.errorf(at, "not enough type arguments for %s: have %d, want %d", name, len(targs), len(tparams))
+		return false
+	case len(targs) > len(tparams):
+		c.errorf(args[len(tparams)], "too many type arguments for %s: have %d, want %d", name, len(targs), len(tparams))
+		return false
+	}
+	smap := makeSubstMap(tparams, targs)
+	for i, tp := range tparams {
+		if !isValid(targs[i]) {
+			return false
+		}
+		// the constraint may not be known yet if it refers to the type
+		// being declared
+		if n, ok := tp.Constraint.(*Named); ok && n.underlying == nil {
+			continue
+		}
+		bound := subst(tp.Constraint, smap)
+		if cause := implements(targs[i], bound, true); cause != "" {
+			var pos ast.Node = at
+			if i < len(args) {
+				pos = args[i]
+			}
+			c.errorf(pos, "%s", cause)
+			return false
+		}
+	}
+	return true
+}
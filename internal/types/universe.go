@@ -0,0 +1,114 @@
+// Copyright 2011 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE-GO file.
+//
+// Adapted from go/types (cmd/compile/internal/types2).
+
+package types
+
+import (
+	resolve "github.com/mohit-bhandari45/Compiler-GO.git/internal/resolve"
+)
+
+// The types of the predeclared objects of resolve.Universe.
+var (
+	universeAny        = &Interface{completed: true}
+	universeError      *Named
+	universeComparable *Named
+	universeTypes      = make(map[*resolve.Object]Type)
+)
+
+// builtinId identifies a predeclared function.
+type builtinId int
+
+const (
+	_Append builtinId = iota
+	_Cap
+	_Clear
+	_Close
+	_Complex
+	_Copy
+	_Delete
+	_Imag
+	_Len
+	_Make
+	_Max
+	_Min
+	_New
+	_Panic
+	_Print
+	_Println
+	_Real
+	_Recover
+)
+
+var predeclaredFuncs = [...]struct {
+	name     string
+	nargs    int  // the number of required arguments
+	variadic bool // more arguments are permitted
+}{
+	_Append:  {"append", 1, true},
+	_Cap:     {"cap", 1, false},
+	_Clear:   {"clear", 1, false},
+	_Close:   {"close", 1, false},
+	_Complex: {"complex", 2, false},
+	_Copy:    {"copy", 2, false},
+	_Delete:  {"delete", 2, false},
+	_Imag:    {"imag", 1, false},
+	_Len:     {"len", 1, false},
+	_Make:    {"make", 1, true},
+	_Max:     {"max", 1, true},
+	_Min:     {"min", 1, true},
+	_New:     {"new", 1, false},
+	_Panic:   {"panic", 1, false},
+	_Print:   {"print", 0, true},
+	_Println: {"println", 0, true},
+	_Real:    {"real", 1, false},
+	_Recover: {"recover", 0, false},
+}
+
+var builtinIds = make(map[string]builtinId)
+
+func init() {
+	for id, f := range predeclaredFuncs {
+		builtinIds[f.name] = builtinId(id)
+	}
+
+	// type error interface{ Error() string }
+	universeError = &Named{Obj: resolve.Universe.Lookup("error")}
+	universeError.Orig = universeError
+	errorSig := &Signature{Results: &Tuple{Vars: []*Var{{Type: Typ[String]}}}}
+	universeError.underlying = &Interface{Methods: []*Func{{Name: "Error", Sig: errorSig}}}
+
+	// type comparable interface{ /* comparable types */ }
+	universeComparable = &Named{Obj: resolve.Universe.Lookup("comparable")}
+	universeComparable.Orig = universeComparable
+	universeComparable.underlying = &Interface{completed: true, comparable: true}
+
+	for _, name := range resolve.Universe.Names() {
+		obj := resolve.Universe.Lookup(name)
+		if obj.Kind != resolve.Typ {
+			continue
+		}
+		var typ Type
+		switch name {
+		case "any":
+			typ = universeAny
+		case "error":
+			typ = universeError
+		case "comparable":
+			typ = universeComparable
+		case "byte":
+			typ = universeByte
+		case "rune":
+			typ = universeRune
+		default:
+			for _, b := range Typ {
+				if b.name == name {
+					typ = b
+				}
+			}
+		}
+		universeTypes[obj] = typ
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2013 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE-GO file.
+//
+// Adapted from go/types (cmd/compile/internal/types2).
+
+package types
+
+import (
+	"path"
+	"sort"
+	"strconv"
+
+	ast "github.com/mohit-bhandari45/Compiler-GO.git/internal/ast"
+	resolve "github.com/mohit-bhandari45/Compiler-GO.git/internal/resolve"
+)
+
+// A local variable is used when its value is read: assigning to it does not
+// use it, but x++, x += y and p.f = y do. The variable of a type switch is
+// checked with its statement.
+
+// unusedVars reports the local variables that are declared and never used.
+// Parameters and results need not be used, and neither need package-level
+// variables.
+func (c *Checker) unusedVars() {
+	var unused []*ast.Identifier
+	for id, obj := range c.info.Defs {
+		if obj == nil || obj.Kind != resolve.Var || obj.Parent == nil || obj.Parent.Kind == resolve.PackageScope || c.used[obj] {
+			continue
+		}
+		if _, ok := obj.Decl.(*ast.Field); ok {
+			continue
+		}
+		unused = append(unused, id)
+	}
+	sort.Slice(unused, func(i, j int) bool { return unused[i].Pos() < unused[j].Pos() })
+	for _, id := range unused {
+		c.errorf(id, "declared and not used: %s", id.Value)
+	}
+}
+
+// unusedImports reports the imports of files whose package name is never
+// used. Blank and dot imports need not be used: the names a dot import
+// provides are not known.
+func (c *Checker) unusedImports(files []*ast.File) {
+	used := make(map[*resolve.Object]bool)
+	for _, obj := range c.info.Uses {
+		if obj.Kind == resolve.Pkg {
+			used[obj] = true
+		}
+	}
+	for _, file := range files {
+		scope := c.info.Scopes[file]
+		if scope == nil {
+			continue
+		}
+		for _, name := range scope.Names() {
+			obj := scope.Objects[name]
+			if obj.Kind != resolve.Pkg || used[obj] {
+				continue
+			}
+			importPath := obj.Data.(string)
+			if obj.Name != path.Base(importPath) {
+				c.errorfAt(obj.Pos(), "%s imported as %s and not used", strconv.Quote(importPath), obj.Name)
+				continue
+			}
+			c.errorfAt(obj.Pos(), "%s imported and not used", strconv.Quote(importPath))
+		}
+	}
+}